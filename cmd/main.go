@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/mattn/go-sqlite3"
 	"log/slog"
 	"os"
@@ -20,5 +22,25 @@ func main() {
 
 	App := app.NewApp(cfg, logger)
 
+	// подкоманды идут после флагов: evbot -config config.yaml backup events.json
+	switch flag.Arg(0) {
+	case "":
+	case "backup":
+		if err := App.Backup(ctx, flag.Arg(1)); err != nil {
+			logger.Error("backup failed", slog.String("[error]", err.Error()))
+			os.Exit(1)
+		}
+		return
+	case "restore":
+		if err := App.Restore(ctx, flag.Arg(1)); err != nil {
+			logger.Error("restore failed", slog.String("[error]", err.Error()))
+			os.Exit(1)
+		}
+		return
+	default:
+		logger.Error("unknown command", slog.String("command", flag.Arg(0)))
+		os.Exit(2)
+	}
+
 	App.Start(ctx)
 }
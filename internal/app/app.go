@@ -3,12 +3,18 @@ package app
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"io"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/binaryty/evbot/internal/config"
 	"github.com/binaryty/evbot/internal/delivery/telegram"
+	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/repository/memory"
+	"github.com/binaryty/evbot/internal/repository/postgres"
 	"github.com/binaryty/evbot/internal/repository/sqlite"
 	"github.com/binaryty/evbot/internal/usecase"
 )
@@ -30,21 +36,26 @@ func NewApp(
 
 // Start ...
 func (a *App) Start(ctx context.Context) {
-	db := a.initDB()
+	store := a.initStorage(ctx)
 	bot := a.initBot()
 
 	logger := a.initLogger()
 
-	eventRepo := sqlite.NewEventRepository(db)
-	userRepo := sqlite.NewUserRepository(db)
-	stateRepo := sqlite.NewStateRepository(db)
-	registrationRepo := sqlite.NewRegistrationRepository(db)
+	notifier := telegram.NewNotifier(bot, logger)
 
-	eventUC := usecase.NewEventUseCase(eventRepo)
-	userUC := usecase.NewUserUseCase(userRepo)
-	registrationUC := usecase.NewRegistrationUseCase(eventRepo, registrationRepo)
+	eventUC := usecase.NewEventUseCase(store.events, store.questions, store.transactor)
+	userUC := usecase.NewUserUseCase(store.users)
+	registrationUC := usecase.NewRegistrationUseCase(store.events, store.registrations, store.questions, store.notifications, notifier, store.transactor)
+	channelUC := usecase.NewChannelUseCase(store.channels, a.cfg.ChannelID)
+	templateUC := usecase.NewTemplateUseCase(store.templates, store.events)
+	feedbackUC := usecase.NewFeedbackUseCase(store.feedback, store.registrations)
+	backupUC := usecase.NewBackupUseCase(store.backup)
 
-	handler := telegram.NewHandler(a.cfg, bot, logger, eventUC, registrationUC, userUC, stateRepo)
+	handler := telegram.NewHandler(a.cfg, bot, logger, eventUC, registrationUC, userUC, channelUC, templateUC, feedbackUC, backupUC, store.states)
+
+	go a.runStateJanitor(ctx, store.states)
+	go a.runDigests(ctx, registrationUC)
+	go a.runSurveys(ctx, handler)
 
 	u := tgbotapi.NewUpdate(0)
 	updates := bot.GetUpdatesChan(u)
@@ -59,13 +70,154 @@ func (a *App) Start(ctx context.Context) {
 	}
 }
 
-// initDB ...
-func (a *App) initDB() *sql.DB {
-	db, err := sql.Open("sqlite3", a.cfg.DBPath)
+// Backup записывает резервную копию базы в файл path, а если он не задан — в stdout.
+func (a *App) Backup(ctx context.Context, path string) error {
+	db := a.initDB(ctx)
+	defer db.Close()
+
+	backupUC := usecase.NewBackupUseCase(newStorage(a.cfg.DBDriver, db).backup)
+
+	if path == "" {
+		return backupUC.Backup(ctx, os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	if err := backupUC.Backup(ctx, f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Restore восстанавливает базу из копии в файле path, а если он не задан — из stdin.
+// Повторное восстановление той же копии безопасно.
+func (a *App) Restore(ctx context.Context, path string) error {
+	db := a.initDB(ctx)
+	defer db.Close()
+
+	backupUC := usecase.NewBackupUseCase(newStorage(a.cfg.DBDriver, db).backup)
+
+	var r io.Reader = os.Stdin
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open backup file: %w", err)
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	n, err := backupUC.Restore(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("backup restored", slog.Int("rows", n))
+
+	return nil
+}
+
+// runStateJanitor периодически удаляет просроченные незавершённые диалоги.
+func (a *App) runStateJanitor(ctx context.Context, stateRepo repository.StateRepository) {
+	if a.cfg.StateTTL <= 0 || a.cfg.StateCleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.StateCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := stateRepo.DeleteExpired(ctx, time.Now().Add(-a.cfg.StateTTL))
+			if err != nil {
+				a.logger.Error("failed to delete expired states", slog.String("[error]", err.Error()))
+				continue
+			}
+
+			if n > 0 {
+				a.logger.Info("expired states deleted", slog.Int64("count", n))
+			}
+		}
+	}
+}
+
+// runDigests периодически отправляет авторам событий сводки регистраций.
+func (a *App) runDigests(ctx context.Context, registrationUC *usecase.RegistrationUseCase) {
+	if a.cfg.DigestInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.DigestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := registrationUC.SendDigests(ctx); err != nil {
+				a.logger.Error("failed to send digests", slog.String("[error]", err.Error()))
+			}
+		}
+	}
+}
+
+// runSurveys периодически рассылает опросы участникам прошедших событий.
+func (a *App) runSurveys(ctx context.Context, handler *telegram.Handler) {
+	if a.cfg.FeedbackInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.cfg.FeedbackInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := handler.SendSurveys(ctx); err != nil {
+				a.logger.Error("failed to send surveys", slog.String("[error]", err.Error()))
+			}
+		}
+	}
+}
+
+// initStorage репозитории базы из конфигурации, а в демо-режиме — в памяти.
+func (a *App) initStorage(ctx context.Context) storage {
+	if a.cfg.Demo {
+		a.logger.Warn("demo mode: data is kept in memory and will be lost on exit")
+		return newMemoryStorage(memory.NewStore())
+	}
+
+	return newStorage(a.cfg.DBDriver, a.initDB(ctx))
+}
+
+// initDB открывает базу и обновляет её схему до последней версии.
+func (a *App) initDB(ctx context.Context) *sql.DB {
+	db, err := sql.Open(sqlDriver(a.cfg.DBDriver), dataSource(a.cfg.DBDriver, a.cfg.DBPath))
 	if err != nil {
 		panic("failed to init db " + err.Error())
 	}
 
+	migrate := sqlite.Migrate
+	if a.cfg.DBDriver == config.DriverPostgres {
+		migrate = postgres.Migrate
+	}
+
+	if err := migrate(ctx, db); err != nil {
+		panic("failed to migrate db " + err.Error())
+	}
+
 	return db
 }
 
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"database/sql"
+
+	"github.com/binaryty/evbot/internal/config"
+	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/repository/memory"
+	"github.com/binaryty/evbot/internal/repository/postgres"
+	"github.com/binaryty/evbot/internal/repository/sqlite"
+)
+
+// storage репозитории выбранного в конфигурации хранилища.
+type storage struct {
+	events        repository.EventRepository
+	users         repository.UserRepository
+	states        repository.StateRepository
+	registrations repository.RegistrationRepository
+	channels      repository.ChannelRepository
+	templates     repository.TemplateRepository
+	notifications repository.NotificationRepository
+	feedback      repository.FeedbackRepository
+	questions     repository.QuestionRepository
+	backup        repository.BackupRepository
+	transactor    repository.Transactor
+}
+
+// newStorage ...
+func newStorage(driver string, db *sql.DB) storage {
+	if driver == config.DriverPostgres {
+		return storage{
+			events:        postgres.NewEventRepository(db),
+			users:         postgres.NewUserRepository(db),
+			states:        postgres.NewStateRepository(db),
+			registrations: postgres.NewRegistrationRepository(db),
+			channels:      postgres.NewChannelRepository(db),
+			templates:     postgres.NewTemplateRepository(db),
+			notifications: postgres.NewNotificationRepository(db),
+			feedback:      postgres.NewFeedbackRepository(db),
+			questions:     postgres.NewQuestionRepository(db),
+			backup:        postgres.NewBackupRepository(db),
+			transactor:    postgres.NewTransactor(db),
+		}
+	}
+
+	return storage{
+		events:        sqlite.NewEventRepository(db),
+		users:         sqlite.NewUserRepository(db),
+		states:        sqlite.NewStateRepository(db),
+		registrations: sqlite.NewRegistrationRepository(db),
+		channels:      sqlite.NewChannelRepository(db),
+		templates:     sqlite.NewTemplateRepository(db),
+		notifications: sqlite.NewNotificationRepository(db),
+		feedback:      sqlite.NewFeedbackRepository(db),
+		questions:     sqlite.NewQuestionRepository(db),
+		backup:        sqlite.NewBackupRepository(db),
+		transactor:    sqlite.NewTransactor(db),
+	}
+}
+
+// newMemoryStorage ...
+func newMemoryStorage(s *memory.Store) storage {
+	return storage{
+		events:        memory.NewEventRepository(s),
+		users:         memory.NewUserRepository(s),
+		states:        memory.NewStateRepository(s),
+		registrations: memory.NewRegistrationRepository(s),
+		channels:      memory.NewChannelRepository(s),
+		templates:     memory.NewTemplateRepository(s),
+		notifications: memory.NewNotificationRepository(s),
+		feedback:      memory.NewFeedbackRepository(s),
+		questions:     memory.NewQuestionRepository(s),
+		backup:        memory.NewBackupRepository(),
+		transactor:    memory.NewTransactor(s),
+	}
+}
+
+// sqlDriver имя драйвера database/sql для хранилища.
+func sqlDriver(driver string) string {
+	if driver == config.DriverPostgres {
+		return "pgx"
+	}
+
+	return "sqlite3"
+}
+
+// dataSource строка подключения к хранилищу.
+func dataSource(driver string, path string) string {
+	if driver == config.DriverPostgres {
+		return path
+	}
+
+	return sqlite.DataSource(path)
+}
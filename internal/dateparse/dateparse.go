@@ -0,0 +1,408 @@
+// Package dateparse распознаёт даты и время, введённые текстом на русском
+// или английском: «завтра в 19:00», «в пятницу», «через 2 часа»,
+// «25.12 18:30», «tomorrow at 7pm».
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmpty        = errors.New("empty input")
+	ErrUnrecognized = errors.New("unrecognized expression")
+	ErrInvalidDate  = errors.New("invalid date")
+)
+
+// Result распознанный момент. Time задан в часовом поясе now;
+// если время не указано, оно равно 00:00, если не указана дата — сегодняшняя.
+type Result struct {
+	Time    time.Time
+	HasDate bool
+	HasTime bool
+}
+
+var (
+	dateRe     = regexp.MustCompile(`^(\d{1,2})[./](\d{1,2})(?:[./](\d{2}|\d{4}))?$`)
+	isoDateRe  = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	clockRe    = regexp.MustCompile(`^(\d{1,2}):(\d{2})(am|pm)?$`)
+	meridiemRe = regexp.MustCompile(`^(\d{1,2})(am|pm)$`)
+	numberRe   = regexp.MustCompile(`^\d{1,3}$`)
+)
+
+// phrases многословные выражения, заменяемые до разбора на отдельные слова.
+var phrases = strings.NewReplacer(
+	"day after tomorrow", "послезавтра",
+	"через полчаса", "через 30 минут",
+	"in half an hour", "in 30 minutes",
+)
+
+// fillers служебные слова, не влияющие на результат.
+var fillers = map[string]bool{
+	"в": true, "во": true, "на": true, "это": true, "эту": true, "этот": true,
+	"at": true, "on": true, "this": true, "the": true,
+}
+
+// nextWords «следующий»: «в следующую пятницу», «next friday», «next week».
+var nextWords = map[string]bool{
+	"следующий": true, "следующую": true, "следующее": true, "следующей": true, "next": true,
+}
+
+var dayWords = map[string]int{
+	"сегодня":     0,
+	"today":       0,
+	"завтра":      1,
+	"tomorrow":    1,
+	"послезавтра": 2,
+}
+
+var weekdays = map[string]time.Weekday{
+	"понедельник": time.Monday, "пн": time.Monday, "monday": time.Monday, "mon": time.Monday,
+	"вторник": time.Tuesday, "вт": time.Tuesday, "tuesday": time.Tuesday, "tue": time.Tuesday,
+	"среда": time.Wednesday, "среду": time.Wednesday, "ср": time.Wednesday, "wednesday": time.Wednesday, "wed": time.Wednesday,
+	"четверг": time.Thursday, "чт": time.Thursday, "thursday": time.Thursday, "thu": time.Thursday,
+	"пятница": time.Friday, "пятницу": time.Friday, "пт": time.Friday, "friday": time.Friday, "fri": time.Friday,
+	"суббота": time.Saturday, "субботу": time.Saturday, "сб": time.Saturday, "saturday": time.Saturday, "sat": time.Saturday,
+	"воскресенье": time.Sunday, "вс": time.Sunday, "sunday": time.Sunday, "sun": time.Sunday,
+}
+
+type unit int
+
+const (
+	unitMinute unit = iota
+	unitHour
+	unitDay
+	unitWeek
+)
+
+var units = map[string]unit{
+	"минуту": unitMinute, "минуты": unitMinute, "минут": unitMinute, "мин": unitMinute,
+	"minute": unitMinute, "minutes": unitMinute, "min": unitMinute, "mins": unitMinute,
+	"час": unitHour, "часа": unitHour, "часов": unitHour, "ч": unitHour,
+	"hour": unitHour, "hours": unitHour, "h": unitHour,
+	"день": unitDay, "дня": unitDay, "дней": unitDay, "сутки": unitDay,
+	"day": unitDay, "days": unitDay,
+	"неделю": unitWeek, "недели": unitWeek, "недель": unitWeek,
+	"week": unitWeek, "weeks": unitWeek,
+}
+
+// dayParts слова после часа: «в 7 вечера», «в 2 ночи».
+var dayParts = map[string]string{
+	"утра":   "am",
+	"дня":    "pm",
+	"вечера": "pm",
+	"ночи":   "night",
+	"am":     "am",
+	"pm":     "pm",
+}
+
+type parser struct {
+	now    time.Time
+	tokens []string
+	pos    int
+
+	date    time.Time
+	hasDate bool
+	hour    int
+	minute  int
+	hasTime bool
+}
+
+// Parse разбирает выражение относительно момента now.
+func Parse(input string, now time.Time) (Result, error) {
+	text := strings.ToLower(strings.TrimSpace(input))
+	text = phrases.Replace(strings.ReplaceAll(text, "ё", "е"))
+
+	tokens := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t' || r == '\n'
+	})
+	if len(tokens) == 0 {
+		return Result{}, ErrEmpty
+	}
+
+	p := &parser{now: now, tokens: tokens}
+	for p.pos < len(p.tokens) {
+		if err := p.step(); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return p.result()
+}
+
+// step разбирает одно выражение, начиная с текущего слова.
+func (p *parser) step() error {
+	tok := p.tokens[p.pos]
+
+	if n, ok := dayWords[tok]; ok {
+		p.pos++
+		return p.setDate(p.today().AddDate(0, 0, n))
+	}
+
+	if wd, ok := weekdays[tok]; ok {
+		p.pos++
+		ahead := (int(wd) - int(p.now.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		return p.setDate(p.today().AddDate(0, 0, ahead))
+	}
+
+	if nextWords[tok] {
+		p.pos++
+		return p.next()
+	}
+
+	if tok == "через" || tok == "in" {
+		p.pos++
+		return p.relative()
+	}
+
+	if m := dateRe.FindStringSubmatch(tok); m != nil {
+		p.pos++
+		return p.absoluteDate(m[3], m[2], m[1])
+	}
+
+	if m := isoDateRe.FindStringSubmatch(tok); m != nil {
+		p.pos++
+		return p.absoluteDate(m[1], m[2], m[3])
+	}
+
+	if m := clockRe.FindStringSubmatch(tok); m != nil {
+		p.pos++
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		return p.setClock(h, min, p.meridiem(m[3]))
+	}
+
+	if m := meridiemRe.FindStringSubmatch(tok); m != nil {
+		p.pos++
+		h, _ := strconv.Atoi(m[1])
+		return p.setClock(h, 0, m[2])
+	}
+
+	// «в 19», «в 7 вечера», «at 7 pm», «12 ночи»
+	if numberRe.MatchString(tok) && (p.afterPreposition() || p.beforeDayPart()) {
+		p.pos++
+		h, _ := strconv.Atoi(tok)
+		return p.setClock(h, 0, p.meridiem(""))
+	}
+
+	if fillers[tok] {
+		p.pos++
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnrecognized, tok)
+}
+
+// relative разбирает «через N единиц» / «in N units»; число можно опустить: «через час».
+func (p *parser) relative() error {
+	n := 1
+	if p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if numberRe.MatchString(tok) {
+			n, _ = strconv.Atoi(tok)
+			p.pos++
+		} else if tok == "a" || tok == "an" {
+			p.pos++
+		}
+	}
+
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("%w: missing unit", ErrUnrecognized)
+	}
+
+	u, ok := units[p.tokens[p.pos]]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnrecognized, p.tokens[p.pos])
+	}
+	p.pos++
+
+	switch u {
+	case unitDay:
+		return p.setDate(p.today().AddDate(0, 0, n))
+	case unitWeek:
+		return p.setDate(p.today().AddDate(0, 0, 7*n))
+	}
+
+	d := time.Duration(n) * time.Minute
+	if u == unitHour {
+		d = time.Duration(n) * time.Hour
+	}
+
+	t := p.now.Add(d)
+	if err := p.setDate(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.now.Location())); err != nil {
+		return err
+	}
+
+	return p.setClock(t.Hour(), t.Minute(), "")
+}
+
+// next разбирает «следующий <день недели>» — день следующей календарной
+// недели (с понедельника): в среду «следующий понедельник» — через 5 дней,
+// «следующая пятница» — через 9. «Следующая неделя» — через 7 дней.
+func (p *parser) next() error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("%w: missing weekday", ErrUnrecognized)
+	}
+
+	tok := p.tokens[p.pos]
+	if tok == "неделе" || tok == "неделю" || tok == "week" {
+		p.pos++
+		return p.setDate(p.today().AddDate(0, 0, 7))
+	}
+
+	wd, ok := weekdays[tok]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnrecognized, tok)
+	}
+	p.pos++
+
+	nextMonday := p.today().AddDate(0, 0, 7-isoWeekday(p.now.Weekday())+1)
+
+	return p.setDate(nextMonday.AddDate(0, 0, isoWeekday(wd)-1))
+}
+
+// isoWeekday номер дня недели с понедельника: 1 — понедельник, 7 — воскресенье.
+func isoWeekday(wd time.Weekday) int {
+	if wd == time.Sunday {
+		return 7
+	}
+
+	return int(wd)
+}
+
+// absoluteDate дата из чисел; без года — ближайшая ещё не прошедшая.
+func (p *parser) absoluteDate(year, month, day string) error {
+	d, _ := strconv.Atoi(day)
+	m, _ := strconv.Atoi(month)
+
+	y := p.now.Year()
+	explicitYear := year != ""
+	if explicitYear {
+		y, _ = strconv.Atoi(year)
+		if len(year) == 2 {
+			y += 2000
+		}
+	}
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, p.now.Location())
+	if date.Day() != d || int(date.Month()) != m {
+		return fmt.Errorf("%w: %s.%s", ErrInvalidDate, day, month)
+	}
+
+	if !explicitYear && date.Before(p.today()) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	return p.setDate(date)
+}
+
+// meridiem ищет после часа уточнение «утра/вечера/am/pm» и поглощает его.
+func (p *parser) meridiem(suffix string) string {
+	if suffix != "" {
+		return suffix
+	}
+
+	if p.pos < len(p.tokens) {
+		if part, ok := dayParts[p.tokens[p.pos]]; ok {
+			p.pos++
+			return part
+		}
+	}
+
+	return ""
+}
+
+// afterPreposition число стоит после «в» / «at» и поэтому означает час.
+func (p *parser) afterPreposition() bool {
+	if p.pos == 0 {
+		return false
+	}
+
+	prev := p.tokens[p.pos-1]
+
+	return prev == "в" || prev == "at"
+}
+
+// beforeDayPart за числом следует часть суток, и оно означает час: «12 ночи».
+func (p *parser) beforeDayPart() bool {
+	if p.pos+1 >= len(p.tokens) {
+		return false
+	}
+
+	_, ok := dayParts[p.tokens[p.pos+1]]
+
+	return ok
+}
+
+func (p *parser) setDate(date time.Time) error {
+	if p.hasDate {
+		return fmt.Errorf("%w: date is specified twice", ErrUnrecognized)
+	}
+
+	p.date = date
+	p.hasDate = true
+
+	return nil
+}
+
+func (p *parser) setClock(hour, minute int, part string) error {
+	if p.hasTime {
+		return fmt.Errorf("%w: time is specified twice", ErrUnrecognized)
+	}
+
+	if (part == "am" || part == "pm") && hour > 12 {
+		return fmt.Errorf("%w: %d%s", ErrInvalidDate, hour, part)
+	}
+
+	switch part {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	case "night":
+		// «в 11 ночи» — 23:00, «в 12 ночи» — 00:00, «в 2 ночи» — 02:00
+		switch {
+		case hour == 12:
+			hour = 0
+		case hour >= 9 && hour < 12:
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return fmt.Errorf("%w: %02d:%02d", ErrInvalidDate, hour, minute)
+	}
+
+	p.hour, p.minute = hour, minute
+	p.hasTime = true
+
+	return nil
+}
+
+func (p *parser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *parser) result() (Result, error) {
+	date := p.today()
+	if p.hasDate {
+		date = p.date
+	}
+
+	return Result{
+		Time:    time.Date(date.Year(), date.Month(), date.Day(), p.hour, p.minute, 0, 0, p.now.Location()),
+		HasDate: p.hasDate,
+		HasTime: p.hasTime,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// среда, 14 октября 2026, 15:04
+	now := time.Date(2026, time.October, 14, 15, 4, 0, 0, loc)
+
+	at := func(month time.Month, day, hour, min int) time.Time {
+		year := 2026
+		if month < time.October {
+			year = 2027
+		}
+		return time.Date(year, month, day, hour, min, 0, 0, loc)
+	}
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		hasDate bool
+		hasTime bool
+	}{
+		// относительные дни
+		{"сегодня", at(time.October, 14, 0, 0), true, false},
+		{"завтра", at(time.October, 15, 0, 0), true, false},
+		{"послезавтра", at(time.October, 16, 0, 0), true, false},
+		{"day after tomorrow", at(time.October, 16, 0, 0), true, false},
+		{"завтра в 19:00", at(time.October, 15, 19, 0), true, true},
+		{"tomorrow at 7pm", at(time.October, 15, 19, 0), true, true},
+		{"через 3 дня", at(time.October, 17, 0, 0), true, false},
+		{"через неделю", at(time.October, 21, 0, 0), true, false},
+		{"in 2 days", at(time.October, 16, 0, 0), true, false},
+		{"через 2 часа", at(time.October, 14, 17, 4), true, true},
+		{"через полчаса", at(time.October, 14, 15, 34), true, true},
+		{"in an hour", at(time.October, 14, 16, 4), true, true},
+		{"через 10 часов", at(time.October, 15, 1, 4), true, true},
+
+		// дни недели: сегодняшний день недели означает следующую неделю
+		{"в пятницу", at(time.October, 16, 0, 0), true, false},
+		{"в среду", at(time.October, 21, 0, 0), true, false},
+		{"пн", at(time.October, 19, 0, 0), true, false},
+		{"sunday", at(time.October, 18, 0, 0), true, false},
+
+		// следующая неделя: дни с понедельника 19 по воскресенье 25 октября
+		{"next sunday", at(time.October, 25, 0, 0), true, false},
+		{"next monday", at(time.October, 19, 0, 0), true, false},
+		{"next friday at 6pm", at(time.October, 23, 18, 0), true, true},
+		{"в следующую пятницу", at(time.October, 23, 0, 0), true, false},
+		{"в следующий вторник в 10:00", at(time.October, 20, 10, 0), true, true},
+		{"next week", at(time.October, 21, 0, 0), true, false},
+		{"на следующей неделе", at(time.October, 21, 0, 0), true, false},
+		{"в субботу в 12:30", at(time.October, 17, 12, 30), true, true},
+
+		// части суток
+		{"в 7 утра", at(time.October, 14, 7, 0), false, true},
+		{"в 2 дня", at(time.October, 14, 14, 0), false, true},
+		{"в 7 вечера", at(time.October, 14, 19, 0), false, true},
+		{"в 11 ночи", at(time.October, 14, 23, 0), false, true},
+		{"в 12 ночи", at(time.October, 14, 0, 0), false, true},
+		{"12 ночи", at(time.October, 14, 0, 0), false, true},
+		{"завтра 7 вечера", at(time.October, 15, 19, 0), true, true},
+		{"7 pm", at(time.October, 14, 19, 0), false, true},
+		{"в 2 ночи", at(time.October, 14, 2, 0), false, true},
+		{"в 19", at(time.October, 14, 19, 0), false, true},
+		{"12am", at(time.October, 14, 0, 0), false, true},
+		{"12pm", at(time.October, 14, 12, 0), false, true},
+		{"7:15pm", at(time.October, 14, 19, 15), false, true},
+
+		// абсолютные даты
+		{"25.12 18:30", at(time.December, 25, 18, 30), true, true},
+		{"14.10", at(time.October, 14, 0, 0), true, false},
+		{"01.02", at(time.February, 1, 0, 0), true, false},
+		{"5/11/26", at(time.November, 5, 0, 0), true, false},
+		{"2026-11-05 09:00", at(time.November, 5, 9, 0), true, true},
+		{"29.02.2028", time.Date(2028, time.February, 29, 0, 0, 0, 0, loc), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, now)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+
+			if !got.Time.Equal(tt.want) || got.HasDate != tt.hasDate || got.HasTime != tt.hasTime {
+				t.Errorf("Parse(%q) = %v (date %v, time %v), want %v (date %v, time %v)",
+					tt.input, got.Time, got.HasDate, got.HasTime, tt.want, tt.hasDate, tt.hasTime)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 15, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		wantErr error
+	}{
+		{"", ErrEmpty},
+		{"  ", ErrEmpty},
+		{"скоро", ErrUnrecognized},
+		{"19", ErrUnrecognized},
+		{"через", ErrUnrecognized},
+		{"next", ErrUnrecognized},
+		{"next 5", ErrUnrecognized},
+		{"следующий месяц", ErrUnrecognized},
+		{"через 2 года", ErrUnrecognized},
+		{"завтра в пятницу", ErrUnrecognized},
+		{"18:00 19:00", ErrUnrecognized},
+		{"30.02", ErrInvalidDate},
+		{"31.04.2027", ErrInvalidDate},
+		{"25:00", ErrInvalidDate},
+		{"18:75", ErrInvalidDate},
+		{"13pm", ErrInvalidDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if _, err := Parse(tt.input, now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
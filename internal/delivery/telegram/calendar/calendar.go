@@ -0,0 +1,334 @@
+// Package calendar инлайн-клавиатура выбора даты: сетка месяца с границами
+// допустимых дат и отметками занятых дней, а также обзор года для быстрого
+// перехода к нужному месяцу.
+//
+// Кнопки кодируются через callback.Codec под действием, переданным в New;
+// первый аргумент — команда календаря:
+//
+//	m <ГГГГММ>     показать месяц
+//	y <ГГГГ>       показать месяцы года
+//	s <ГГГГММДД>   выбрать день
+//	ok             подтвердить выбор
+//	noop           неактивная кнопка
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"time"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+)
+
+// DayKey формат ключей View.Marked.
+const DayKey = "2006-01-02"
+
+const (
+	emPrev     = "◀️"
+	emNext     = "▶️"
+	emSelected = "✅"
+	emToday    = "🟢"
+	emMarked   = "•"
+)
+
+var ErrInvalidCallback = errors.New("invalid calendar callback")
+
+// Action команда календаря, закодированная в кнопке.
+type Action string
+
+const (
+	ActionMonth   Action = "m"
+	ActionYear    Action = "y"
+	ActionSelect  Action = "s"
+	ActionConfirm Action = "ok"
+	ActionNoop    Action = "noop"
+)
+
+var valueLayouts = map[Action]string{
+	ActionMonth:  "200601",
+	ActionYear:   "2006",
+	ActionSelect: "20060102",
+}
+
+// Callback разобранное нажатие.
+type Callback struct {
+	Action Action
+	Date   time.Time
+}
+
+// Parse разбирает данные нажатия, закодированные календарём.
+func Parse(data callback.Data) (Callback, error) {
+	name, err := data.String(0)
+	if err != nil {
+		return Callback{}, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+	}
+
+	cb := Callback{Action: Action(name)}
+
+	if layout, ok := valueLayouts[cb.Action]; ok {
+		value, err := data.String(1)
+		if err != nil || data.Len() != 2 {
+			return Callback{}, fmt.Errorf("%w: %s needs a date", ErrInvalidCallback, name)
+		}
+
+		if cb.Date, err = time.Parse(layout, value); err != nil {
+			return Callback{}, fmt.Errorf("%w: %s %q", ErrInvalidCallback, name, value)
+		}
+
+		return cb, nil
+	}
+
+	if (cb.Action != ActionConfirm && cb.Action != ActionNoop) || data.Len() != 1 {
+		return Callback{}, fmt.Errorf("%w: %s", ErrInvalidCallback, name)
+	}
+
+	return cb, nil
+}
+
+// locale названия и первый день недели.
+type locale struct {
+	weekStart time.Weekday
+	// weekdays короткие названия дней, начиная с воскресенья.
+	weekdays [7]string
+	months   [12]string
+	short    [12]string
+	confirm  string
+}
+
+var locales = map[string]locale{
+	"ru": {
+		weekStart: time.Monday,
+		weekdays:  [7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+		months: [12]string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь",
+			"Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"},
+		short: [12]string{"Янв", "Фев", "Мар", "Апр", "Май", "Июн",
+			"Июл", "Авг", "Сен", "Окт", "Ноя", "Дек"},
+		confirm: "Готово",
+	},
+	"en": {
+		weekStart: time.Sunday,
+		weekdays:  [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		short: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		confirm: "Done",
+	},
+}
+
+// DefaultLocale используется для неизвестных локалей.
+const DefaultLocale = "ru"
+
+// View параметры отрисовки. Даты сравниваются по дню, часовой пояс не учитывается.
+type View struct {
+	// Month любой день показываемого месяца (или года в обзоре года).
+	Month    time.Time
+	Selected time.Time
+	Today    time.Time
+	// Min, Max границы доступных дат; нулевое значение — без ограничения.
+	Min, Max time.Time
+	// Marked дни (в формате DayKey), на которые уже есть события.
+	Marked map[string]bool
+}
+
+// Allowed день входит в допустимые границы.
+func (v View) Allowed(day time.Time) bool {
+	d := dayOf(day)
+
+	if !v.Min.IsZero() && d.Before(dayOf(v.Min)) {
+		return false
+	}
+
+	if !v.Max.IsZero() && d.After(dayOf(v.Max)) {
+		return false
+	}
+
+	return true
+}
+
+// Calendar настройки отображения.
+type Calendar struct {
+	codec  *callback.Codec
+	action callback.Action
+	loc    locale
+}
+
+// New создаёт календарь, кнопки которого кодируются под действием action,
+// для локали ("ru" — неделя с понедельника, "en" — с воскресенья).
+func New(codec *callback.Codec, action callback.Action, localeName string) *Calendar {
+	loc, ok := locales[localeName]
+	if !ok {
+		loc = locales[DefaultLocale]
+	}
+
+	return &Calendar{
+		codec:  codec,
+		action: action,
+		loc:    loc,
+	}
+}
+
+// MonthMarkup сетка дней месяца.
+func (c *Calendar) MonthMarkup(v View) tgbotapi.InlineKeyboardMarkup {
+	first := time.Date(v.Month.Year(), v.Month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	prev := first.AddDate(0, -1, 0)
+	next := first.AddDate(0, 1, 0)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	keyboard = append(keyboard, []tgbotapi.InlineKeyboardButton{
+		c.navButton(emPrev, Callback{Action: ActionMonth, Date: prev}, v.monthAllowed(prev)),
+		c.button(fmt.Sprintf("%s %d", c.loc.months[first.Month()-1], first.Year()),
+			Callback{Action: ActionYear, Date: first}),
+		c.navButton(emNext, Callback{Action: ActionMonth, Date: next}, v.monthAllowed(next)),
+	})
+
+	var weekRow []tgbotapi.InlineKeyboardButton
+	for i := 0; i < 7; i++ {
+		wd := (int(c.loc.weekStart) + i) % 7
+		weekRow = append(weekRow, c.button(c.loc.weekdays[wd], Callback{Action: ActionNoop}))
+	}
+	keyboard = append(keyboard, weekRow)
+
+	var row []tgbotapi.InlineKeyboardButton
+
+	// пустые клетки до первого дня с учётом первого дня недели
+	offset := (int(first.Weekday()) - int(c.loc.weekStart) + 7) % 7
+	for i := 0; i < offset; i++ {
+		row = append(row, c.button(" ", Callback{Action: ActionNoop}))
+	}
+
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		row = append(row, c.dayButton(d, v))
+
+		if len(row) == 7 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+
+	if len(row) > 0 {
+		for len(row) < 7 {
+			row = append(row, c.button(" ", Callback{Action: ActionNoop}))
+		}
+		keyboard = append(keyboard, row)
+	}
+
+	keyboard = append(keyboard, []tgbotapi.InlineKeyboardButton{
+		c.button(c.loc.confirm, Callback{Action: ActionConfirm}),
+	})
+
+	return tgbotapi.NewInlineKeyboardMarkup(keyboard...)
+}
+
+// YearMarkup обзор месяцев года для быстрого перехода.
+func (c *Calendar) YearMarkup(v View) tgbotapi.InlineKeyboardMarkup {
+	year := v.Month.Year()
+	prev := time.Date(year-1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	next := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var keyboard [][]tgbotapi.InlineKeyboardButton
+
+	keyboard = append(keyboard, []tgbotapi.InlineKeyboardButton{
+		c.navButton(emPrev, Callback{Action: ActionYear, Date: prev}, v.yearAllowed(year-1)),
+		c.button(fmt.Sprintf("%d", year), Callback{Action: ActionNoop}),
+		c.navButton(emNext, Callback{Action: ActionYear, Date: next}, v.yearAllowed(year+1)),
+	})
+
+	var row []tgbotapi.InlineKeyboardButton
+	for m := 0; m < 12; m++ {
+		month := time.Date(year, time.Month(m+1), 1, 0, 0, 0, 0, time.UTC)
+
+		label := c.loc.short[m]
+		cb := Callback{Action: ActionMonth, Date: month}
+		if !v.monthAllowed(month) {
+			label = "·"
+			cb = Callback{Action: ActionNoop}
+		}
+		row = append(row, c.button(label, cb))
+
+		if len(row) == 3 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(keyboard...)
+}
+
+// dayButton кнопка дня; недоступные дни неактивны.
+func (c *Calendar) dayButton(d time.Time, v View) tgbotapi.InlineKeyboardButton {
+	text := fmt.Sprintf("%d", d.Day())
+
+	if !v.Allowed(d) {
+		return c.button("·", Callback{Action: ActionNoop})
+	}
+
+	if v.Marked[d.Format(DayKey)] {
+		text += emMarked
+	}
+
+	switch {
+	case !v.Selected.IsZero() && sameDay(d, v.Selected):
+		text = emSelected + " " + text
+	case !v.Today.IsZero() && sameDay(d, v.Today):
+		text = emToday + " " + text
+	}
+
+	return c.button(text, Callback{Action: ActionSelect, Date: d})
+}
+
+// navButton кнопка перехода; если переходить некуда, она пустая.
+func (c *Calendar) navButton(text string, cb Callback, enabled bool) tgbotapi.InlineKeyboardButton {
+	if !enabled {
+		return c.button(" ", Callback{Action: ActionNoop})
+	}
+
+	return c.button(text, cb)
+}
+
+// monthAllowed в месяце есть хотя бы один доступный день.
+func (v View) monthAllowed(month time.Time) bool {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+
+	if !v.Min.IsZero() && last.Before(dayOf(v.Min)) {
+		return false
+	}
+
+	if !v.Max.IsZero() && first.After(dayOf(v.Max)) {
+		return false
+	}
+
+	return true
+}
+
+func (v View) yearAllowed(year int) bool {
+	if !v.Min.IsZero() && year < v.Min.Year() {
+		return false
+	}
+
+	if !v.Max.IsZero() && year > v.Max.Year() {
+		return false
+	}
+
+	return true
+}
+
+func (c *Calendar) button(text string, cb Callback) tgbotapi.InlineKeyboardButton {
+	if layout, ok := valueLayouts[cb.Action]; ok {
+		return c.codec.Button(text, c.action, string(cb.Action), cb.Date.Format(layout))
+	}
+
+	return c.codec.Button(text, c.action, string(cb.Action))
+}
+
+// dayOf полночь того же календарного дня в UTC.
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
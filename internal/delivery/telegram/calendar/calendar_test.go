@@ -0,0 +1,407 @@
+package calendar
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+)
+
+const testAction callback.Action = "cal"
+
+func newTestCodec() *callback.Codec {
+	return callback.NewCodec([]byte("secret"), time.Hour)
+}
+
+// button кнопка клавиатуры с разобранным нажатием.
+type button struct {
+	text string
+	cb   Callback
+}
+
+// render разбирает каждую кнопку клавиатуры обратно.
+func render(t *testing.T, codec *callback.Codec, markup tgbotapi.InlineKeyboardMarkup) [][]button {
+	t.Helper()
+
+	var rows [][]button
+	for _, r := range markup.InlineKeyboard {
+		var row []button
+		for _, b := range r {
+			data, err := codec.Decode(*b.CallbackData)
+			if err != nil {
+				t.Fatalf("Decode(%q): %v", b.Text, err)
+			}
+
+			cb, err := Parse(data)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", b.Text, err)
+			}
+			row = append(row, button{text: b.Text, cb: cb})
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func texts(row []button) []string {
+	var s []string
+	for _, b := range row {
+		s = append(s, b.text)
+	}
+
+	return s
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var noop = Callback{Action: ActionNoop}
+
+func TestParse(t *testing.T) {
+	codec := newTestCodec()
+
+	tests := []struct {
+		name    string
+		args    []interface{}
+		want    Callback
+		wantErr bool
+	}{
+		{name: "month", args: []interface{}{"m", "202611"}, want: Callback{Action: ActionMonth, Date: date(2026, time.November, 1)}},
+		{name: "year", args: []interface{}{"y", "2027"}, want: Callback{Action: ActionYear, Date: date(2027, time.January, 1)}},
+		{name: "select", args: []interface{}{"s", "20261120"}, want: Callback{Action: ActionSelect, Date: date(2026, time.November, 20)}},
+		{name: "confirm", args: []interface{}{"ok"}, want: Callback{Action: ActionConfirm}},
+		{name: "noop", args: []interface{}{"noop"}, want: noop},
+
+		{name: "no command", args: nil, wantErr: true},
+		{name: "unknown command", args: []interface{}{"jump"}, wantErr: true},
+		{name: "month without date", args: []interface{}{"m"}, wantErr: true},
+		{name: "month with two dates", args: []interface{}{"m", "202611", "202612"}, wantErr: true},
+		{name: "invalid day", args: []interface{}{"s", "20260231"}, wantErr: true},
+		{name: "day in month format", args: []interface{}{"s", "202611"}, wantErr: true},
+		{name: "confirm with value", args: []interface{}{"ok", "20261120"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := codec.Decode(codec.MustEncode(testAction, tt.args...))
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			got, err := Parse(data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCallback) {
+					t.Errorf("Parse(%v) error = %v, want ErrInvalidCallback", tt.args, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%v): %v", tt.args, err)
+			}
+			if got.Action != tt.want.Action || !got.Date.Equal(tt.want.Date) {
+				t.Errorf("Parse(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonthGrid(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		month  time.Time
+		title  string
+		week   []string
+		first  []string
+		last   []string
+		// weeks число строк с днями.
+		weeks   int
+		confirm string
+	}{
+		{
+			// 1 октября 2026 — четверг
+			name: "ru month from thursday", locale: "ru", month: date(2026, time.October, 20),
+			title: "Октябрь 2026",
+			week:  []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"},
+			first: []string{" ", " ", " ", "1", "2", "3", "4"},
+			last:  []string{"26", "27", "28", "29", "30", "31", " "},
+			weeks: 5, confirm: "Готово",
+		},
+		{
+			name: "en month from thursday", locale: "en", month: date(2026, time.October, 1),
+			title: "October 2026",
+			week:  []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+			first: []string{" ", " ", " ", " ", "1", "2", "3"},
+			last:  []string{"25", "26", "27", "28", "29", "30", "31"},
+			weeks: 5, confirm: "Done",
+		},
+		{
+			// 1 ноября 2026 — воскресенье: в русской сетке это последняя клетка строки
+			name: "ru month from sunday", locale: "ru", month: date(2026, time.November, 1),
+			title: "Ноябрь 2026",
+			week:  []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"},
+			first: []string{" ", " ", " ", " ", " ", " ", "1"},
+			last:  []string{"30", " ", " ", " ", " ", " ", " "},
+			weeks: 6, confirm: "Готово",
+		},
+		{
+			name: "en month from sunday", locale: "en", month: date(2026, time.November, 30),
+			title: "November 2026",
+			week:  []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+			first: []string{"1", "2", "3", "4", "5", "6", "7"},
+			last:  []string{"29", "30", " ", " ", " ", " ", " "},
+			weeks: 5, confirm: "Done",
+		},
+		{
+			name: "unknown locale falls back to ru", locale: "de", month: date(2026, time.November, 1),
+			title: "Ноябрь 2026",
+			week:  []string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"},
+			first: []string{" ", " ", " ", " ", " ", " ", "1"},
+			last:  []string{"30", " ", " ", " ", " ", " ", " "},
+			weeks: 6, confirm: "Готово",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := newTestCodec()
+			rows := render(t, codec, New(codec, testAction, tt.locale).MonthMarkup(View{Month: tt.month}))
+
+			// заголовок, дни недели, недели месяца, «Готово»
+			if len(rows) != tt.weeks+3 {
+				t.Fatalf("rows = %d, want %d", len(rows), tt.weeks+3)
+			}
+
+			header := rows[0]
+			if header[1].text != tt.title || header[1].cb.Action != ActionYear || header[1].cb.Date.Year() != tt.month.Year() {
+				t.Errorf("title = %+v, want %q opening the year", header[1], tt.title)
+			}
+
+			checks := []struct {
+				name string
+				got  []string
+				want []string
+			}{
+				{"weekdays", texts(rows[1]), tt.week},
+				{"first week", texts(rows[2]), tt.first},
+				{"last week", texts(rows[len(rows)-2]), tt.last},
+				{"confirm", texts(rows[len(rows)-1]), []string{tt.confirm}},
+			}
+			for _, c := range checks {
+				if !reflect.DeepEqual(c.got, c.want) {
+					t.Errorf("%s = %q, want %q", c.name, c.got, c.want)
+				}
+			}
+
+			// каждый день выбирает свою дату
+			day := 1
+			for _, row := range rows[2 : len(rows)-1] {
+				for _, b := range row {
+					if b.cb == noop {
+						continue
+					}
+					if want := date(tt.month.Year(), tt.month.Month(), day); b.cb.Action != ActionSelect || !b.cb.Date.Equal(want) {
+						t.Errorf("day %d = %+v, want select %v", day, b, want)
+					}
+					day++
+				}
+			}
+			if last := date(tt.month.Year(), tt.month.Month()+1, 0).Day(); day != last+1 {
+				t.Errorf("days = %d, want %d", day-1, last)
+			}
+		})
+	}
+}
+
+func TestMonthBounds(t *testing.T) {
+	codec := newTestCodec()
+	c := New(codec, testAction, "ru")
+
+	view := View{
+		Today:    date(2026, time.October, 14),
+		Min:      date(2026, time.October, 14),
+		Max:      time.Date(2026, time.December, 5, 18, 30, 0, 0, time.UTC),
+		Selected: date(2026, time.October, 20),
+		Marked:   map[string]bool{"2026-10-20": true, "2026-10-21": true, "2026-10-10": true},
+	}
+
+	// день по его номеру в сетке месяца
+	days := func(v View) map[int]button {
+		rows := render(t, codec, c.MonthMarkup(v))
+
+		byDay := make(map[int]button)
+		n := 0
+		for _, row := range rows[2 : len(rows)-1] {
+			for _, b := range row {
+				if b.text == " " {
+					continue
+				}
+				n++
+				byDay[n] = b
+			}
+		}
+
+		return byDay
+	}
+	nav := func(v View) (prev, next button) {
+		header := render(t, codec, c.MonthMarkup(v))[0]
+		return header[0], header[2]
+	}
+
+	t.Run("first month", func(t *testing.T) {
+		v := view
+		v.Month = date(2026, time.October, 1)
+		d := days(v)
+
+		tests := []struct {
+			day  int
+			want button
+		}{
+			{1, button{"·", noop}},
+			{10, button{"·", noop}}, // занятый, но прошедший
+			{13, button{"·", noop}},
+			{14, button{"🟢 14", Callback{Action: ActionSelect, Date: date(2026, time.October, 14)}}},
+			{20, button{"✅ 20•", Callback{Action: ActionSelect, Date: date(2026, time.October, 20)}}},
+			{21, button{"21•", Callback{Action: ActionSelect, Date: date(2026, time.October, 21)}}},
+			{31, button{"31", Callback{Action: ActionSelect, Date: date(2026, time.October, 31)}}},
+		}
+		for _, tt := range tests {
+			if got := d[tt.day]; got.text != tt.want.text || got.cb.Action != tt.want.cb.Action || !got.cb.Date.Equal(tt.want.cb.Date) {
+				t.Errorf("day %d = %+v, want %+v", tt.day, got, tt.want)
+			}
+		}
+
+		// в сентябрь переходить некуда
+		prev, next := nav(v)
+		if prev.text != " " || prev.cb != noop {
+			t.Errorf("prev = %+v, want disabled", prev)
+		}
+		if next.text != emNext || next.cb.Action != ActionMonth || !next.cb.Date.Equal(date(2026, time.November, 1)) {
+			t.Errorf("next = %+v, want November", next)
+		}
+	})
+
+	t.Run("middle month", func(t *testing.T) {
+		v := view
+		v.Month = date(2026, time.November, 15)
+
+		prev, next := nav(v)
+		if prev.cb.Action != ActionMonth || !prev.cb.Date.Equal(date(2026, time.October, 1)) {
+			t.Errorf("prev = %+v, want October", prev)
+		}
+		if next.cb.Action != ActionMonth || !next.cb.Date.Equal(date(2026, time.December, 1)) {
+			t.Errorf("next = %+v, want December", next)
+		}
+	})
+
+	t.Run("last month", func(t *testing.T) {
+		v := view
+		v.Month = date(2026, time.December, 1)
+		d := days(v)
+
+		// последний доступный день — по дате, а не по времени Max
+		if got := d[5]; got.cb.Action != ActionSelect {
+			t.Errorf("day 5 = %+v, want selectable", got)
+		}
+		if got := d[6]; got.text != "·" || got.cb != noop {
+			t.Errorf("day 6 = %+v, want disabled", got)
+		}
+
+		// в январь переходить некуда
+		if _, next := nav(v); next.text != " " || next.cb != noop {
+			t.Errorf("next = %+v, want disabled", next)
+		}
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		prev, next := nav(View{Month: date(2026, time.October, 1)})
+		if prev.cb.Action != ActionMonth || next.cb.Action != ActionMonth {
+			t.Errorf("navigation = %+v, %+v; want both enabled", prev, next)
+		}
+	})
+}
+
+func TestYearMarkup(t *testing.T) {
+	codec := newTestCodec()
+
+	view := View{
+		Min: date(2026, time.October, 14),
+		Max: date(2027, time.October, 14),
+	}
+	month := func(year int, m time.Month) Callback {
+		return Callback{Action: ActionMonth, Date: date(year, m, 1)}
+	}
+	yearCb := func(year int) Callback {
+		return Callback{Action: ActionYear, Date: date(year, time.January, 1)}
+	}
+	disabled := button{" ", noop}
+
+	tests := []struct {
+		name   string
+		locale string
+		year   int
+		header []button
+		// months кнопки месяцев по порядку.
+		months []button
+	}{
+		{
+			name: "first year", locale: "ru", year: 2026,
+			header: []button{disabled, {"2026", noop}, {emNext, yearCb(2027)}},
+			months: []button{
+				{"·", noop}, {"·", noop}, {"·", noop},
+				{"·", noop}, {"·", noop}, {"·", noop},
+				{"·", noop}, {"·", noop}, {"·", noop},
+				{"Окт", month(2026, time.October)}, {"Ноя", month(2026, time.November)}, {"Дек", month(2026, time.December)},
+			},
+		},
+		{
+			name: "last year", locale: "en", year: 2027,
+			header: []button{{emPrev, yearCb(2026)}, {"2027", noop}, disabled},
+			months: []button{
+				{"Jan", month(2027, time.January)}, {"Feb", month(2027, time.February)}, {"Mar", month(2027, time.March)},
+				{"Apr", month(2027, time.April)}, {"May", month(2027, time.May)}, {"Jun", month(2027, time.June)},
+				{"Jul", month(2027, time.July)}, {"Aug", month(2027, time.August)}, {"Sep", month(2027, time.September)},
+				{"Oct", month(2027, time.October)}, {"·", noop}, {"·", noop},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := view
+			v.Month = date(tt.year, time.June, 15)
+			rows := render(t, codec, New(codec, testAction, tt.locale).YearMarkup(v))
+
+			if len(rows) != 5 {
+				t.Fatalf("rows = %d, want header and 4 rows of months", len(rows))
+			}
+
+			var months []button
+			for _, row := range rows[1:] {
+				if len(row) != 3 {
+					t.Errorf("row = %q, want 3 months", texts(row))
+				}
+				months = append(months, row...)
+			}
+
+			for name, pair := range map[string][2][]button{"header": {rows[0], tt.header}, "months": {months, tt.months}} {
+				got, want := pair[0], pair[1]
+				if len(got) != len(want) {
+					t.Fatalf("%s = %+v, want %+v", name, got, want)
+				}
+				for i := range want {
+					if got[i].text != want[i].text || got[i].cb.Action != want[i].cb.Action || !got[i].cb.Date.Equal(want[i].cb.Date) {
+						t.Errorf("%s %d = %+v, want %+v", name, i, got[i], want[i])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Package callback кодирует данные инлайн-кнопок. Каждая кнопка несёт
+// версию формата, типизированное действие и аргументы; числа записываются
+// в base36, а итоговая строка гарантированно укладывается в 64 байта,
+// которые Telegram разрешает для callback_data.
+//
+// Данные подписываются HMAC-SHA256 с секретом сервера и сроком действия,
+// поэтому клиент не может подделать кнопку (например, подставить чужой ID
+// события) или воспользоваться слишком старой. Срок ограничивает шаги
+// диалогов; кнопки карточек и анонсов событий бессрочны.
+//
+// Формат: <версия>:<действие>[:<аргумент>...]:<срок>:<подпись>,
+// например "2:r:2n9c:q1x3kg:Zk3b0v8l2aQ".
+package callback
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Version версия формата; данные других версий (кнопки старых сообщений) отклоняются.
+	Version = "2"
+	// MaxLen ограничение Telegram на длину callback_data.
+	MaxLen = 64
+
+	sep = ":"
+	// sigLen байт HMAC в подписи: 64 бита достаточно против перебора через Telegram.
+	sigLen = 8
+)
+
+var (
+	ErrTooLong       = errors.New("callback data exceeds 64 bytes")
+	ErrMalformed     = errors.New("malformed callback data")
+	ErrVersion       = errors.New("unsupported callback data version")
+	ErrUnknownAction = errors.New("unknown callback action")
+	ErrArgument      = errors.New("invalid callback argument")
+	ErrSignature     = errors.New("invalid callback signature")
+	ErrExpired       = errors.New("callback data expired")
+)
+
+// Action короткий код действия кнопки.
+type Action string
+
+// Data разобранные данные нажатия.
+type Data struct {
+	Action Action
+	args   []string
+}
+
+// Len количество аргументов.
+func (d Data) Len() int {
+	return len(d.args)
+}
+
+// String i-й аргумент как строка.
+func (d Data) String(i int) (string, error) {
+	if i < 0 || i >= len(d.args) {
+		return "", fmt.Errorf("%w: %s has no argument %d", ErrArgument, d.Action, i)
+	}
+
+	return d.args[i], nil
+}
+
+// Int64 i-й аргумент как число.
+func (d Data) Int64(i int) (int64, error) {
+	s, err := d.String(i)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.ParseInt(s, 36, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s[%d]=%q", ErrArgument, d.Action, i, s)
+	}
+
+	return v, nil
+}
+
+// Int i-й аргумент как int.
+func (d Data) Int(i int) (int, error) {
+	v, err := d.Int64(i)
+
+	return int(v), err
+}
+
+// Codec кодирует, подписывает и проверяет данные кнопок.
+type Codec struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewCodec создаёт кодек с секретом подписи; кнопки действуют ttl
+// с момента отправки (0 — бессрочно).
+func NewCodec(secret []byte, ttl time.Duration) *Codec {
+	return &Codec{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Encode кодирует действие и аргументы (int, int64 или string без ":").
+func (c *Codec) Encode(action Action, args ...interface{}) (string, error) {
+	return c.encode(c.ttl, action, args...)
+}
+
+// encode кодирует данные со сроком действия ttl (0 — бессрочно).
+func (c *Codec) encode(ttl time.Duration, action Action, args ...interface{}) (string, error) {
+	if action == "" || strings.Contains(string(action), sep) {
+		return "", fmt.Errorf("%w: action %q", ErrArgument, action)
+	}
+
+	parts := make([]string, 0, len(args)+2)
+	parts = append(parts, Version, string(action))
+
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case int:
+			parts = append(parts, strconv.FormatInt(int64(v), 36))
+		case int64:
+			parts = append(parts, strconv.FormatInt(v, 36))
+		case string:
+			if strings.Contains(v, sep) {
+				return "", fmt.Errorf("%w: %q contains %q", ErrArgument, v, sep)
+			}
+			parts = append(parts, v)
+		default:
+			return "", fmt.Errorf("%w: unsupported type %T", ErrArgument, arg)
+		}
+	}
+
+	// срок действия в минутах Unix; 0 — бессрочно
+	var expiry int64
+	if ttl > 0 {
+		expiry = c.now().Add(ttl).Unix() / 60
+	}
+	parts = append(parts, strconv.FormatInt(expiry, 36))
+
+	payload := strings.Join(parts, sep)
+	data := payload + sep + c.sign(payload)
+	if len(data) > MaxLen {
+		return "", fmt.Errorf("%w: %s", ErrTooLong, data)
+	}
+
+	return data, nil
+}
+
+// MustEncode как Encode, но паникует: аргументы кнопок задаются кодом,
+// и выход за лимит — ошибка программиста, а не пользователя.
+func (c *Codec) MustEncode(action Action, args ...interface{}) string {
+	data, err := c.Encode(action, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// Button инлайн-кнопка с закодированными данными.
+func (c *Codec) Button(text string, action Action, args ...interface{}) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(text, c.MustEncode(action, args...))
+}
+
+// PermanentButton бессрочная кнопка для сообщений, которые живут столько же,
+// сколько событие: карточки, анонсы в канале, уведомления.
+func (c *Codec) PermanentButton(text string, action Action, args ...interface{}) tgbotapi.InlineKeyboardButton {
+	data, err := c.encode(0, action, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return tgbotapi.NewInlineKeyboardButtonData(text, data)
+}
+
+// Decode разбирает данные нажатия.
+func (c *Codec) Decode(data string) (Data, error) {
+	if len(data) > MaxLen {
+		return Data{}, ErrTooLong
+	}
+
+	parts := strings.Split(data, sep)
+	if parts[0] != Version {
+		return Data{}, fmt.Errorf("%w: %q", ErrVersion, data)
+	}
+
+	// версия, действие, срок, подпись
+	if len(parts) < 4 || parts[1] == "" {
+		return Data{}, fmt.Errorf("%w: %q", ErrMalformed, data)
+	}
+
+	payload := strings.Join(parts[:len(parts)-1], sep)
+	if !hmac.Equal([]byte(parts[len(parts)-1]), []byte(c.sign(payload))) {
+		return Data{}, fmt.Errorf("%w: %q", ErrSignature, data)
+	}
+
+	expiry, err := strconv.ParseInt(parts[len(parts)-2], 36, 64)
+	if err != nil {
+		return Data{}, fmt.Errorf("%w: %q", ErrMalformed, data)
+	}
+
+	if expiry != 0 && c.now().Unix()/60 > expiry {
+		return Data{}, fmt.Errorf("%w: %q", ErrExpired, data)
+	}
+
+	return Data{
+		Action: Action(parts[1]),
+		args:   parts[2 : len(parts)-2],
+	}, nil
+}
+
+// sign усечённый HMAC-SHA256 данных в base64url.
+func (c *Codec) sign(payload string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:sigLen])
+}
@@ -0,0 +1,48 @@
+package callback
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpiry(t *testing.T) {
+	const ttl = 30 * 24 * time.Hour
+
+	sent := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	codec := NewCodec([]byte("secret"), ttl)
+	codec.now = func() time.Time { return sent }
+
+	temporary := *codec.Button("Шаг", "s", 1).CallbackData
+	permanent := *codec.PermanentButton("Регистрация", "r", 1).CallbackData
+
+	tests := []struct {
+		name    string
+		data    string
+		at      time.Time
+		wantErr error
+	}{
+		{name: "temporary before expiry", data: temporary, at: sent.Add(ttl - time.Minute)},
+		{name: "temporary after expiry", data: temporary, at: sent.Add(ttl + 2*time.Minute), wantErr: ErrExpired},
+		{name: "permanent after ttl", data: permanent, at: sent.Add(ttl + 2*time.Minute)},
+		{name: "permanent years later", data: permanent, at: sent.AddDate(5, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec.now = func() time.Time { return tt.at }
+
+			data, err := codec.Decode(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode(%q) error = %v, want %v", tt.data, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if id, err := data.Int64(0); err != nil || id != 1 {
+				t.Errorf("Int64(0) = %d, %v", id, err)
+			}
+		})
+	}
+}
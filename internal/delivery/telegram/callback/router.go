@@ -0,0 +1,48 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HandlerFunc обработчик нажатия с уже разобранными данными.
+type HandlerFunc func(ctx context.Context, query *tgbotapi.CallbackQuery, data Data) error
+
+// Router направляет нажатия обработчикам по действию.
+type Router struct {
+	codec  *Codec
+	routes map[Action]HandlerFunc
+}
+
+func NewRouter(codec *Codec) *Router {
+	return &Router{
+		codec:  codec,
+		routes: make(map[Action]HandlerFunc),
+	}
+}
+
+// Handle регистрирует обработчик действия; повторная регистрация — ошибка программиста.
+func (r *Router) Handle(action Action, fn HandlerFunc) *Router {
+	if _, ok := r.routes[action]; ok {
+		panic(fmt.Sprintf("callback: action %q is already registered", action))
+	}
+	r.routes[action] = fn
+
+	return r
+}
+
+// Dispatch разбирает данные нажатия и вызывает обработчик действия.
+func (r *Router) Dispatch(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+	data, err := r.codec.Decode(query.Data)
+	if err != nil {
+		return err
+	}
+
+	fn, ok := r.routes[data.Action]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAction, data.Action)
+	}
+
+	return fn(ctx, query, data)
+}
@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+)
+
+// Действия инлайн-кнопок. Коды короткие: они входят в callback_data,
+// ограниченную 64 байтами.
+const (
+	actRegister        callback.Action = "r"
+	actParticipants    callback.Action = "p"
+	actDeleteAsk       callback.Action = "da"
+	actDelete          callback.Action = "de"
+	actDeleteCancel    callback.Action = "dc"
+	actClone           callback.Action = "cl"
+	actTemplateSave    callback.Action = "ts"
+	actTemplateUse     callback.Action = "tu"
+	actTemplateDel     callback.Action = "td"
+	actDraftResume     callback.Action = "dr"
+	actDraftDelete     callback.Action = "dd"
+	actDraftRename     callback.Action = "dn"
+	actMediaSkip       callback.Action = "ms"
+	actTime            callback.Action = "t"
+	actCalendar        callback.Action = "c"
+	actConfirm         callback.Action = "cf"
+	actBroadcast       callback.Action = "bc"
+	actNotifyMenu      callback.Action = "nm"
+	actNotifySet       callback.Action = "ns"
+	actFeedbackRate    callback.Action = "fr"
+	actFeedbackComment callback.Action = "fc"
+	actFeedbackSummary callback.Action = "fs"
+	actQuestions       callback.Action = "qs"
+	actAnswer          callback.Action = "an"
+	actApprovalMenu    callback.Action = "am"
+	actApprovalSet     callback.Action = "as"
+	actApprovalDecide  callback.Action = "ad"
+	actExport          callback.Action = "ex"
+	actExportFile      callback.Action = "ef"
+	actImport          callback.Action = "im"
+)
+
+// Команды предпросмотра, первый аргумент actConfirm.
+const (
+	confirmSave   = "save"
+	confirmEdit   = "edit"
+	confirmBack   = "back"
+	confirmField  = "field"
+	confirmCancel = "cancel"
+)
+
+// newCallbackRouter связывает действия кнопок с обработчиками.
+func (h *Handler) newCallbackRouter() *callback.Router {
+	return callback.NewRouter(h.callbacks).
+		Handle(actRegister, h.handleRegistration).
+		Handle(actParticipants, h.handleParticipants).
+		Handle(actDeleteAsk, h.handleDeleteConfirmation).
+		Handle(actDelete, h.handleEventDelete).
+		Handle(actDeleteCancel, h.handleDeleteCancel).
+		Handle(actClone, h.handleCloneCallback).
+		Handle(actTemplateSave, h.handleTemplateSave).
+		Handle(actTemplateUse, h.handleTemplateUse).
+		Handle(actTemplateDel, h.handleTemplateDelete).
+		Handle(actDraftResume, h.handleDraftResume).
+		Handle(actDraftDelete, h.handleDraftDelete).
+		Handle(actDraftRename, h.handleDraftRename).
+		Handle(actMediaSkip, h.handleMediaCallback).
+		Handle(actTime, h.handleTimeCallback).
+		Handle(actCalendar, h.handleCalendarCallback).
+		Handle(actConfirm, h.handleConfirmCallback).
+		Handle(actBroadcast, h.handleBroadcastStart).
+		Handle(actNotifyMenu, h.handleNotifyMenu).
+		Handle(actNotifySet, h.handleNotifySet).
+		Handle(actFeedbackRate, h.handleFeedbackRate).
+		Handle(actFeedbackComment, h.handleFeedbackComment).
+		Handle(actFeedbackSummary, h.handleFeedbackSummary).
+		Handle(actQuestions, h.handleQuestionsCallback).
+		Handle(actAnswer, h.handleAnswerCallback).
+		Handle(actApprovalMenu, h.handleApprovalMenu).
+		Handle(actApprovalSet, h.handleApprovalSet).
+		Handle(actApprovalDecide, h.handleApprovalDecide).
+		Handle(actExport, h.handleExport).
+		Handle(actExportFile, h.handleExportFile).
+		Handle(actImport, h.handleImportCallback)
+}
@@ -0,0 +1,327 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/binaryty/evbot/internal/dateparse"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+)
+
+const (
+	// flowSave подтверждение и сохранение черновика на шаге предпросмотра.
+	flowSave fsm.Event = "save"
+	// flowKeepTime дата выбрана, а время уже известно (копия или шаблон).
+	flowKeepTime fsm.Event = "keep_time"
+)
+
+// Состояния служебных диалогов.
+const (
+	// stepBroadcastMessage ожидание сообщения для рассылки.
+	stepBroadcastMessage fsm.State = "message"
+	// stepCheckInTicket ожидание кода или фото билета.
+	stepCheckInTicket fsm.State = "ticket"
+	// stepFeedbackComment ожидание комментария к оценке.
+	stepFeedbackComment fsm.State = "comment"
+	// stepAnswerText ожидание текстового ответа на вопрос регистрации.
+	stepAnswerText fsm.State = "answer_text"
+	// stepAnswerChoice ожидание выбора вариантов кнопками.
+	stepAnswerChoice fsm.State = "answer_choice"
+	// stepImportFile ожидание файла с событиями.
+	stepImportFile fsm.State = "file"
+	// stepImportPreview предпросмотр импорта до подтверждения.
+	stepImportPreview fsm.State = "preview"
+	// stepDraftName ожидание имени черновика.
+	stepDraftName fsm.State = "name"
+)
+
+const (
+	// flowAskText следующий вопрос регистрации с ответом текстом.
+	flowAskText fsm.Event = "ask_text"
+	// flowAskChoice следующий вопрос регистрации с вариантами ответа.
+	flowAskChoice fsm.Event = "ask_choice"
+)
+
+// broadcastPayload данные диалога рассылки.
+type broadcastPayload struct {
+	EventID int64
+}
+
+// feedbackPayload данные диалога комментария к оценке.
+type feedbackPayload struct {
+	EventID int64
+}
+
+// registrationPayload ответы, собранные до подтверждения регистрации.
+type registrationPayload struct {
+	EventID int64
+	Answers []domain.Answer
+	// Choices отмеченные варианты текущего вопроса с множественным выбором.
+	Choices []int
+}
+
+// importPayload события из файла, ожидающие подтверждения импорта.
+type importPayload struct {
+	// ChatID пространство, в котором создаются события.
+	ChatID int64
+	Events []domain.Event
+}
+
+// draftNamePayload черновик, которому задаётся имя.
+type draftNamePayload struct {
+	DraftID int64
+}
+
+// editFieldEvent переход из предпросмотра к редактированию поля.
+func editFieldEvent(step string) fsm.Event {
+	return fsm.Event("edit_" + step)
+}
+
+// newEventFlow мастер создания события:
+// название → описание → вложения → дата → время → предпросмотр.
+// Из предпросмотра можно вернуться к любому полю; после ввода (Done)
+// пользователь снова попадает в предпросмотр. Вопросы участникам
+// необязательны и открываются только из предпросмотра.
+func newEventFlow() *fsm.Machine {
+	var (
+		title       = fsm.State(domain.StepTitle)
+		description = fsm.State(domain.StepDescription)
+		media       = fsm.State(domain.StepMedia)
+		date        = fsm.State(domain.StepDate)
+		clock       = fsm.State(domain.StepTime)
+		confirm     = fsm.State(domain.StepConfirm)
+		questions   = fsm.State(domain.StepQuestions)
+	)
+
+	m := fsm.New("event_creation", title).
+		State(title, validateTitle).
+		State(description, validateDescription).
+		State(media, nil).
+		State(date, nil).
+		State(clock, validateTime).
+		State(confirm, nil).
+		State(questions, validateQuestion)
+
+	m.Transition(title, fsm.Next, description).
+		Transition(description, fsm.Next, media).
+		Transition(description, fsm.Back, title).
+		Transition(media, fsm.Next, date).
+		Transition(media, fsm.Skip, date).
+		Transition(media, fsm.Back, description).
+		Transition(date, fsm.Next, clock).
+		Transition(date, fsm.Back, media).
+		Transition(date, flowKeepTime, confirm).
+		Transition(clock, fsm.Next, confirm).
+		Transition(clock, fsm.Back, date).
+		Transition(confirm, fsm.Back, clock).
+		Transition(confirm, flowSave, fsm.Final)
+
+	for _, field := range []fsm.State{title, description, media, date, clock, questions} {
+		m.Transition(confirm, editFieldEvent(string(field)), field).
+			Transition(field, fsm.Done, confirm)
+	}
+
+	return m
+}
+
+// newServiceFlows автоматы служебных диалогов по значению EventState.Flow.
+// Отмена (/cancel) допустима из любого состояния.
+func newServiceFlows() map[string]*fsm.Machine {
+	return map[string]*fsm.Machine{
+		domain.FlowBroadcast:    newBroadcastFlow(),
+		domain.FlowCheckIn:      newCheckInFlow(),
+		domain.FlowFeedback:     newFeedbackFlow(),
+		domain.FlowRegistration: newRegistrationFlow(),
+		domain.FlowImport:       newImportFlow(),
+		domain.FlowDraftName:    newDraftNameFlow(),
+	}
+}
+
+// newBroadcastFlow рассылка: одно сообщение от организатора завершает диалог.
+func newBroadcastFlow() *fsm.Machine {
+	return fsm.New(domain.FlowBroadcast, stepBroadcastMessage).
+		Transition(stepBroadcastMessage, fsm.Done, fsm.Final)
+}
+
+// newCheckInFlow режим отметки: каждый код остаётся в том же состоянии
+// и продлевает режим, выйти можно только отменой.
+func newCheckInFlow() *fsm.Machine {
+	return fsm.New(domain.FlowCheckIn, stepCheckInTicket).
+		Transition(stepCheckInTicket, fsm.Next, stepCheckInTicket)
+}
+
+// newFeedbackFlow комментарий к оценке.
+func newFeedbackFlow() *fsm.Machine {
+	return fsm.New(domain.FlowFeedback, stepFeedbackComment).
+		State(stepFeedbackComment, validateComment).
+		Transition(stepFeedbackComment, fsm.Done, fsm.Final)
+}
+
+// newRegistrationFlow вопросы регистрации: состояние зависит от вида
+// текущего вопроса, ответ на последний завершает диалог.
+func newRegistrationFlow() *fsm.Machine {
+	m := fsm.New(domain.FlowRegistration, stepAnswerText).
+		State(stepAnswerText, validateAnswer).
+		State(stepAnswerChoice, rejectText)
+
+	for _, step := range []fsm.State{stepAnswerText, stepAnswerChoice} {
+		m.Transition(step, flowAskText, stepAnswerText).
+			Transition(step, flowAskChoice, stepAnswerChoice).
+			Transition(step, fsm.Done, fsm.Final)
+	}
+
+	return m
+}
+
+// newImportFlow импорт: файл → предпросмотр → создание событий. Новый файл
+// в предпросмотре заменяет прежний.
+func newImportFlow() *fsm.Machine {
+	return fsm.New(domain.FlowImport, stepImportFile).
+		State(stepImportPreview, nil).
+		Transition(stepImportFile, fsm.Next, stepImportPreview).
+		Transition(stepImportPreview, fsm.Next, stepImportPreview).
+		Transition(stepImportPreview, fsm.Done, fsm.Final)
+}
+
+// newDraftNameFlow переименование черновика: одно сообщение с именем.
+func newDraftNameFlow() *fsm.Machine {
+	return fsm.New(domain.FlowDraftName, stepDraftName).
+		State(stepDraftName, validateDraftName).
+		Transition(stepDraftName, fsm.Done, fsm.Final)
+}
+
+// answerStep состояние ожидания ответа на вопрос регистрации.
+func answerStep(q domain.Question) fsm.State {
+	if q.Kind == domain.QuestionText {
+		return stepAnswerText
+	}
+
+	return stepAnswerChoice
+}
+
+// askEvent переход к вопросу регистрации в зависимости от его вида.
+func askEvent(q domain.Question) fsm.Event {
+	if q.Kind == domain.QuestionText {
+		return flowAskText
+	}
+
+	return flowAskChoice
+}
+
+// encodePayload ...
+func encodePayload(payload any) (json.RawMessage, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dialog payload: %w", err)
+	}
+
+	return data, nil
+}
+
+// decodePayload читает данные служебного диалога.
+func decodePayload[T any](state domain.EventState) (T, error) {
+	var payload T
+	if len(state.Payload) == 0 {
+		return payload, nil
+	}
+
+	if err := json.Unmarshal(state.Payload, &payload); err != nil {
+		return payload, fmt.Errorf("failed to decode %s payload: %w", state.Flow, err)
+	}
+
+	return payload, nil
+}
+
+// validateTitle ...
+func validateTitle(input string) error {
+	if input == "" {
+		return fsm.Invalid("Название не может быть пустым")
+	}
+
+	if utf8.RuneCountInString(input) > 100 {
+		return fsm.Invalid("Слишком длинное название (макс. 100 символов)")
+	}
+
+	return nil
+}
+
+// validateDescription ...
+func validateDescription(input string) error {
+	if utf8.RuneCountInString(input) > 500 {
+		return fsm.Invalid("Слишком длинное описание (макс. 500 символов)")
+	}
+
+	return nil
+}
+
+// validateQuestion ...
+func validateQuestion(input string) error {
+	_, err := parseQuestion(input)
+
+	return err
+}
+
+// validateTime принимает «18:30», «в 7 вечера», «завтра в 19:00» и т.п.
+func validateTime(input string) error {
+	res, err := dateparse.Parse(input, time.Now())
+	if err != nil || !res.HasTime {
+		return fsm.Invalid("Введите время, например 18:30, «в 7 вечера» или «завтра в 19:00»")
+	}
+
+	return nil
+}
+
+// validateComment ...
+func validateComment(input string) error {
+	text := strings.TrimSpace(input)
+	if text == "" {
+		return fsm.Invalid("Комментарий должен быть текстом")
+	}
+
+	if utf8.RuneCountInString(text) > maxCommentLen {
+		return fsm.Invalid(fmt.Sprintf("Комментарий не должен превышать %d символов", maxCommentLen))
+	}
+
+	return nil
+}
+
+// validateDraftName ...
+func validateDraftName(input string) error {
+	name := strings.TrimSpace(input)
+	if name == "" {
+		return fsm.Invalid("Имя черновика должно быть текстом")
+	}
+
+	if utf8.RuneCountInString(name) > maxDraftNameLen {
+		return fsm.Invalid(fmt.Sprintf("Слишком длинное имя (макс. %d символов)", maxDraftNameLen))
+	}
+
+	return nil
+}
+
+// validateAnswer текстовый ответ на вопрос регистрации.
+func validateAnswer(input string) error {
+	text := strings.TrimSpace(input)
+	if text == "" {
+		return fsm.Invalid("Ответ должен быть текстом")
+	}
+
+	if utf8.RuneCountInString(text) > maxTextAnswerLen {
+		return fsm.Invalid(fmt.Sprintf("Слишком длинный ответ (макс. %d символов)", maxTextAnswerLen))
+	}
+
+	return nil
+}
+
+// rejectText на вопрос с вариантами отвечают кнопками.
+func rejectText(string) error {
+	return fsm.Invalid("Выберите вариант кнопками под вопросом")
+}
@@ -0,0 +1,317 @@
+package telegram
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+)
+
+func TestEventFlowTransitions(t *testing.T) {
+	const (
+		title       = fsm.State(domain.StepTitle)
+		description = fsm.State(domain.StepDescription)
+		media       = fsm.State(domain.StepMedia)
+		date        = fsm.State(domain.StepDate)
+		clock       = fsm.State(domain.StepTime)
+		confirm     = fsm.State(domain.StepConfirm)
+		questions   = fsm.State(domain.StepQuestions)
+	)
+
+	tests := []struct {
+		from  fsm.State
+		event fsm.Event
+		want  fsm.State
+	}{
+		// основной путь
+		{title, fsm.Next, description},
+		{description, fsm.Next, media},
+		{media, fsm.Next, date},
+		{media, fsm.Skip, date},
+		{date, fsm.Next, clock},
+		{clock, fsm.Next, confirm},
+		{confirm, flowSave, fsm.Final},
+
+		// шаг назад
+		{description, fsm.Back, title},
+		{media, fsm.Back, description},
+		{date, fsm.Back, media},
+		{clock, fsm.Back, date},
+		{confirm, fsm.Back, clock},
+
+		// время уже известно
+		{date, flowKeepTime, confirm},
+
+		// редактирование из предпросмотра
+		{confirm, editFieldEvent(domain.StepTitle), title},
+		{confirm, editFieldEvent(domain.StepDescription), description},
+		{confirm, editFieldEvent(domain.StepMedia), media},
+		{confirm, editFieldEvent(domain.StepDate), date},
+		{confirm, editFieldEvent(domain.StepTime), clock},
+		{confirm, editFieldEvent(domain.StepQuestions), questions},
+		{title, fsm.Done, confirm},
+		{description, fsm.Done, confirm},
+		{media, fsm.Done, confirm},
+		{date, fsm.Done, confirm},
+		{clock, fsm.Done, confirm},
+		{questions, fsm.Done, confirm},
+
+		// отмена с любого шага
+		{title, fsm.Cancel, fsm.Final},
+		{description, fsm.Cancel, fsm.Final},
+		{media, fsm.Cancel, fsm.Final},
+		{date, fsm.Cancel, fsm.Final},
+		{clock, fsm.Cancel, fsm.Final},
+		{confirm, fsm.Cancel, fsm.Final},
+		{questions, fsm.Cancel, fsm.Final},
+	}
+
+	flow := newEventFlow()
+	for _, tt := range tests {
+		got, err := flow.Fire(tt.from, tt.event)
+		if err != nil {
+			t.Errorf("Fire(%q, %q): %v", tt.from, tt.event, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Fire(%q, %q) = %q, want %q", tt.from, tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestEventFlowRejectsTransitions(t *testing.T) {
+	tests := []struct {
+		from    fsm.State
+		event   fsm.Event
+		wantErr error
+	}{
+		{fsm.State(domain.StepTitle), fsm.Back, fsm.ErrNoTransition},
+		{fsm.State(domain.StepTitle), fsm.Skip, fsm.ErrNoTransition},
+		{fsm.State(domain.StepDescription), fsm.Skip, fsm.ErrNoTransition},
+		{fsm.State(domain.StepTime), flowKeepTime, fsm.ErrNoTransition},
+		{fsm.State(domain.StepTitle), flowSave, fsm.ErrNoTransition},
+		{fsm.State(domain.StepConfirm), fsm.Next, fsm.ErrNoTransition},
+		{fsm.State(domain.StepConfirm), editFieldEvent(domain.StepConfirm), fsm.ErrNoTransition},
+		{fsm.State(domain.StepTitle), editFieldEvent(domain.StepDate), fsm.ErrNoTransition},
+		{fsm.State(domain.StepQuestions), fsm.Next, fsm.ErrNoTransition},
+		{fsm.State(domain.StepCompleted), fsm.Next, fsm.ErrUnknownState},
+		{fsm.State("unknown"), fsm.Cancel, fsm.ErrUnknownState},
+		{fsm.Final, fsm.Next, fsm.ErrUnknownState},
+	}
+
+	flow := newEventFlow()
+	for _, tt := range tests {
+		got, err := flow.Fire(tt.from, tt.event)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Fire(%q, %q) error = %v, want %v", tt.from, tt.event, err, tt.wantErr)
+		}
+		if got != tt.from {
+			t.Errorf("Fire(%q, %q) moved to %q", tt.from, tt.event, got)
+		}
+	}
+}
+
+func TestEventFlowValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    string
+		input   string
+		wantErr bool
+	}{
+		{name: "title", step: domain.StepTitle, input: "Митап"},
+		{name: "empty title", step: domain.StepTitle, input: "", wantErr: true},
+		{name: "title of 100 runes", step: domain.StepTitle, input: strings.Repeat("я", 100)},
+		{name: "long title", step: domain.StepTitle, input: strings.Repeat("я", 101), wantErr: true},
+
+		{name: "empty description", step: domain.StepDescription, input: ""},
+		{name: "description of 500 runes", step: domain.StepDescription, input: strings.Repeat("я", 500)},
+		{name: "long description", step: domain.StepDescription, input: strings.Repeat("я", 501), wantErr: true},
+
+		{name: "media has no validator", step: domain.StepMedia, input: ""},
+		{name: "date has no validator", step: domain.StepDate, input: "что угодно"},
+
+		{name: "clock time", step: domain.StepTime, input: "18:30"},
+		{name: "day part", step: domain.StepTime, input: "в 7 вечера"},
+		{name: "date with time", step: domain.StepTime, input: "завтра в 19:00"},
+		{name: "date without time", step: domain.StepTime, input: "завтра", wantErr: true},
+		{name: "not a time", step: domain.StepTime, input: "скоро", wantErr: true},
+
+		{name: "text question", step: domain.StepQuestions, input: "Откуда вы узнали о встрече?"},
+		{name: "single choice", step: domain.StepQuestions, input: "Размер футболки\n- S\n- M\n- L"},
+		{name: "multiple choice", step: domain.StepQuestions, input: "Что принесёте?\n+ чай\n+ печенье"},
+		{name: "empty question", step: domain.StepQuestions, input: " \n ", wantErr: true},
+		{name: "long question", step: domain.StepQuestions, input: strings.Repeat("я", maxQuestionLen+1), wantErr: true},
+		{name: "option without marker", step: domain.StepQuestions, input: "Размер\nS\nM", wantErr: true},
+		{name: "mixed markers", step: domain.StepQuestions, input: "Размер\n- S\n+ M", wantErr: true},
+		{name: "empty option", step: domain.StepQuestions, input: "Размер\n- S\n-", wantErr: true},
+		{name: "long option", step: domain.StepQuestions, input: "Размер\n- S\n- " + strings.Repeat("я", maxOptionLen+1), wantErr: true},
+		{name: "option with separator", step: domain.StepQuestions, input: "Размер\n- S;M\n- L", wantErr: true},
+		{name: "duplicate options", step: domain.StepQuestions, input: "Размер\n- S\n- S", wantErr: true},
+		{name: "single option", step: domain.StepQuestions, input: "Размер\n- S", wantErr: true},
+		{name: "too many options", step: domain.StepQuestions, input: "Число" + manyOptions(maxOptions+1), wantErr: true},
+	}
+
+	flow := newEventFlow()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := flow.Validate(fsm.State(tt.step), tt.input)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Validate(%q): %v", tt.step, err)
+				}
+				return
+			}
+
+			var verr *fsm.ValidationError
+			if !errors.As(err, &verr) {
+				t.Errorf("Validate(%q) = %v, want validation error", tt.step, err)
+			}
+		})
+	}
+}
+
+// manyOptions n разных вариантов ответа.
+func manyOptions(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteString("\n- ")
+		b.WriteRune(rune('a' + i))
+	}
+
+	return b.String()
+}
+
+func TestServiceFlows(t *testing.T) {
+	tests := []struct {
+		flow  string
+		from  fsm.State
+		event fsm.Event
+		want  fsm.State
+	}{
+		{domain.FlowBroadcast, stepBroadcastMessage, fsm.Done, fsm.Final},
+		{domain.FlowCheckIn, stepCheckInTicket, fsm.Next, stepCheckInTicket},
+		{domain.FlowFeedback, stepFeedbackComment, fsm.Done, fsm.Final},
+
+		// шаг регистрации выбирается по виду следующего вопроса
+		{domain.FlowRegistration, stepAnswerText, flowAskText, stepAnswerText},
+		{domain.FlowRegistration, stepAnswerText, flowAskChoice, stepAnswerChoice},
+		{domain.FlowRegistration, stepAnswerChoice, flowAskText, stepAnswerText},
+		{domain.FlowRegistration, stepAnswerChoice, flowAskChoice, stepAnswerChoice},
+		{domain.FlowRegistration, stepAnswerText, fsm.Done, fsm.Final},
+		{domain.FlowRegistration, stepAnswerChoice, fsm.Done, fsm.Final},
+
+		{domain.FlowImport, stepImportFile, fsm.Next, stepImportPreview},
+		{domain.FlowImport, stepImportPreview, fsm.Next, stepImportPreview},
+		{domain.FlowImport, stepImportPreview, fsm.Done, fsm.Final},
+		{domain.FlowDraftName, stepDraftName, fsm.Done, fsm.Final},
+
+		// отмена с любого шага
+		{domain.FlowBroadcast, stepBroadcastMessage, fsm.Cancel, fsm.Final},
+		{domain.FlowCheckIn, stepCheckInTicket, fsm.Cancel, fsm.Final},
+		{domain.FlowRegistration, stepAnswerChoice, fsm.Cancel, fsm.Final},
+		{domain.FlowImport, stepImportFile, fsm.Cancel, fsm.Final},
+		{domain.FlowDraftName, stepDraftName, fsm.Cancel, fsm.Final},
+	}
+
+	flows := newServiceFlows()
+	for _, tt := range tests {
+		got, err := flows[tt.flow].Fire(tt.from, tt.event)
+		if err != nil {
+			t.Errorf("%s: Fire(%q, %q): %v", tt.flow, tt.from, tt.event, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Fire(%q, %q) = %q, want %q", tt.flow, tt.from, tt.event, got, tt.want)
+		}
+	}
+
+	rejected := []struct {
+		flow  string
+		from  fsm.State
+		event fsm.Event
+	}{
+		// рассылка и комментарий не возвращаются к вводу
+		{domain.FlowBroadcast, stepBroadcastMessage, fsm.Next},
+		{domain.FlowFeedback, stepFeedbackComment, fsm.Next},
+		// импорт без предпросмотра не подтверждается
+		{domain.FlowImport, stepImportFile, fsm.Done},
+		{domain.FlowCheckIn, stepCheckInTicket, fsm.Done},
+	}
+
+	for _, tt := range rejected {
+		if _, err := flows[tt.flow].Fire(tt.from, tt.event); !errors.Is(err, fsm.ErrNoTransition) {
+			t.Errorf("%s: Fire(%q, %q) error = %v, want ErrNoTransition", tt.flow, tt.from, tt.event, err)
+		}
+	}
+}
+
+func TestServiceFlowsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flow    string
+		step    fsm.State
+		input   string
+		wantErr bool
+	}{
+		{name: "comment", flow: domain.FlowFeedback, step: stepFeedbackComment, input: "Отличная встреча"},
+		{name: "empty comment", flow: domain.FlowFeedback, step: stepFeedbackComment, input: "  ", wantErr: true},
+		{name: "long comment", flow: domain.FlowFeedback, step: stepFeedbackComment, input: strings.Repeat("я", maxCommentLen+1), wantErr: true},
+
+		{name: "text answer", flow: domain.FlowRegistration, step: stepAnswerText, input: "Москва"},
+		{name: "empty answer", flow: domain.FlowRegistration, step: stepAnswerText, input: "", wantErr: true},
+		{name: "long answer", flow: domain.FlowRegistration, step: stepAnswerText, input: strings.Repeat("я", maxTextAnswerLen+1), wantErr: true},
+		{name: "text for choice", flow: domain.FlowRegistration, step: stepAnswerChoice, input: "M", wantErr: true},
+
+		{name: "draft name", flow: domain.FlowDraftName, step: stepDraftName, input: " Осенний митап "},
+		{name: "empty draft name", flow: domain.FlowDraftName, step: stepDraftName, input: "", wantErr: true},
+		{name: "long draft name", flow: domain.FlowDraftName, step: stepDraftName, input: strings.Repeat("я", maxDraftNameLen+1), wantErr: true},
+
+		{name: "ticket has no validator", flow: domain.FlowCheckIn, step: stepCheckInTicket, input: ""},
+		{name: "file has no validator", flow: domain.FlowImport, step: stepImportFile, input: ""},
+	}
+
+	flows := newServiceFlows()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := flows[tt.flow].Validate(tt.step, tt.input)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Validate(%q): %v", tt.step, err)
+				}
+				return
+			}
+
+			var verr *fsm.ValidationError
+			if !errors.As(err, &verr) {
+				t.Errorf("Validate(%q) = %v, want validation error", tt.step, err)
+			}
+		})
+	}
+}
+
+func TestDialogPayload(t *testing.T) {
+	want := registrationPayload{
+		EventID: 7,
+		Answers: []domain.Answer{{QuestionID: 1, Value: "M"}},
+		Choices: []int{2},
+	}
+
+	data, err := encodePayload(want)
+	if err != nil {
+		t.Fatalf("encodePayload: %v", err)
+	}
+
+	got, err := decodePayload[registrationPayload](domain.EventState{Flow: domain.FlowRegistration, Payload: data})
+	if err != nil {
+		t.Fatalf("decodePayload: %v", err)
+	}
+	if got.EventID != want.EventID || len(got.Answers) != 1 || got.Answers[0] != want.Answers[0] || len(got.Choices) != 1 || got.Choices[0] != 2 {
+		t.Errorf("decodePayload = %+v, want %+v", got, want)
+	}
+
+	if _, err := decodePayload[registrationPayload](domain.EventState{Payload: []byte("{")}); err == nil {
+		t.Error("decodePayload of broken JSON: want error")
+	}
+}
@@ -1,5 +1,12 @@
 package telegram
 
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
 // isAdmin ...
 func (h *Handler) isAdmin(userID int64) bool {
 	for _, id := range h.cfg.AdminIDs {
@@ -10,3 +17,73 @@ func (h *Handler) isAdmin(userID int64) bool {
 
 	return false
 }
+
+// isChatAdmin проверяет через getChatMember, является ли пользователь
+// создателем или администратором чата.
+func (h *Handler) isChatAdmin(chatID int64, userID int64) bool {
+	member, err := h.bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{
+			ChatID: chatID,
+			UserID: userID,
+		},
+	})
+	if err != nil {
+		h.logger.Error("failed to get chat member",
+			slog.Int64("chat_id", chatID),
+			slog.String("[ERROR]", err.Error()))
+		return false
+	}
+
+	return member.IsCreator() || member.IsAdministrator()
+}
+
+// canModerate проверяет право управлять событиями пространства чата:
+// глобальные администраторы из конфига могут всё, администраторы группы —
+// только события своей группы.
+func (h *Handler) canModerate(chat *tgbotapi.Chat, userID int64) bool {
+	if h.isAdmin(userID) {
+		return true
+	}
+
+	space := spaceID(chat)
+	if space == domain.GlobalSpace {
+		return false
+	}
+
+	return h.isChatAdmin(space, userID)
+}
+
+// canModerateEvent проверяет право управлять событием. Событием группы
+// управляют её администраторы — в самой группе или в личном чате с ботом,
+// куда приходят кнопки организатора, но не из другой группы.
+func (h *Handler) canModerateEvent(chat *tgbotapi.Chat, userID int64, event *domain.Event) bool {
+	if h.isAdmin(userID) {
+		return true
+	}
+
+	if event.ChatID == domain.GlobalSpace || isGroupChat(chat) && event.ChatID != chat.ID {
+		return false
+	}
+
+	return h.isChatAdmin(event.ChatID, userID)
+}
+
+// isOrganizer автор события или модератор его пространства.
+func (h *Handler) isOrganizer(chat *tgbotapi.Chat, userID int64, event *domain.Event) bool {
+	return event.UserID == userID || h.canModerateEvent(chat, userID, event)
+}
+
+// spaceID возвращает пространство событий для чата: у групп оно своё,
+// личные сообщения относятся к общему пространству.
+func spaceID(chat *tgbotapi.Chat) int64 {
+	if isGroupChat(chat) {
+		return chat.ID
+	}
+
+	return domain.GlobalSpace
+}
+
+// isGroupChat сообщения чата видят все его участники.
+func isGroupChat(chat *tgbotapi.Chat) bool {
+	return chat != nil && (chat.IsGroup() || chat.IsSuperGroup())
+}
@@ -0,0 +1,228 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"strings"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// Решение по заявке, последний аргумент actApprovalDecide.
+const (
+	decisionReject  = "n"
+	decisionApprove = "y"
+)
+
+// handleApprovalMenu показывает автору события настройку подтверждения заявок.
+func (h *Handler) handleApprovalMenu(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.UserID != query.From.ID {
+		h.sendCallback(query.ID, EmCross, "Заявки подтверждает автор события")
+		return nil
+	}
+
+	msg := tgbotapi.NewMessage(query.Message.Chat.ID, approvalMenuText(event))
+	msg.ReplyMarkup = h.createApprovalButtons(event)
+	h.bot.Send(msg)
+
+	return nil
+}
+
+// handleApprovalSet сохраняет настройку подтверждения и обновляет меню.
+func (h *Handler) handleApprovalSet(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	required, err := data.Int(1)
+	if err != nil {
+		return fmt.Errorf("failed to parse approval mode: %w", err)
+	}
+
+	if err := h.eventUC.SetApproval(ctx, query.From.ID, eventID, required == 1); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAccessDenied):
+			h.sendCallback(query.ID, EmCross, "Заявки подтверждает автор события")
+			return nil
+		case errors.Is(err, domain.ErrEventNotFound):
+			h.sendCallback(query.ID, EmCross, "Событие не найдено")
+			return nil
+		}
+		return fmt.Errorf("failed to set approval mode: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	h.sendCallback(query.ID, EmOk, "Настройка сохранена")
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(
+		query.Message.Chat.ID,
+		query.Message.MessageID,
+		approvalMenuText(event),
+		h.createApprovalButtons(event),
+	)
+	h.bot.Send(edit)
+
+	return nil
+}
+
+// createApprovalButtons ...
+func (h *Handler) createApprovalButtons(event *domain.Event) tgbotapi.InlineKeyboardMarkup {
+	open, closed := "🔓 Все сразу", "🔐 После подтверждения"
+	if event.RequiresApproval {
+		closed = EmOk + " " + closed
+	} else {
+		open = EmOk + " " + open
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		h.callbacks.Button(open, actApprovalSet, event.ID, 0),
+		h.callbacks.Button(closed, actApprovalSet, event.ID, 1),
+	))
+}
+
+// approvalMenuText ...
+func approvalMenuText(event *domain.Event) string {
+	return fmt.Sprintf("🔐 Регистрация на «%s»\n\n"+
+		"🔓 Все сразу — участник регистрируется одной кнопкой\n"+
+		"🔐 После подтверждения — регистрация становится заявкой, "+
+		"вы принимаете или отклоняете её в личных сообщениях", event.Title)
+}
+
+// requestApproval присылает автору события заявку с кнопками решения.
+// Ошибки только логируются: заявка уже сохранена, а автор увидит её
+// в списке участников.
+func (h *Handler) requestApproval(ctx context.Context, event *domain.Event, user domain.User) {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🔐 Заявка на «%s»: %s", event.Title, userLabel(user)))
+
+	pending, err := h.registrationUC.GetPending(ctx, event.ID)
+	if err != nil {
+		h.logger.Error("failed to get pending registrations", slog.String("[ERROR]", err.Error()))
+	}
+
+	questions, err := h.eventUC.Questions(ctx, event.ID)
+	if err != nil {
+		h.logger.Error("failed to get questions", slog.String("[ERROR]", err.Error()))
+	}
+
+	for _, p := range pending {
+		if p.ID != user.ID {
+			continue
+		}
+
+		for _, a := range p.Answers {
+			for _, q := range questions {
+				if q.ID == a.QuestionID {
+					text.WriteString(fmt.Sprintf("\n▫️ %s: %s", q.Text, a.Value))
+				}
+			}
+		}
+	}
+
+	msg := tgbotapi.NewMessage(event.UserID, text.String())
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		h.callbacks.PermanentButton(EmOk+" Принять", actApprovalDecide, event.ID, user.ID, decisionApprove),
+		h.callbacks.PermanentButton(EmCross+" Отклонить", actApprovalDecide, event.ID, user.ID, decisionReject),
+	))
+
+	if _, err := h.bot.Send(msg); err != nil {
+		h.logger.Error("failed to send approval request",
+			slog.Int64("event_id", event.ID),
+			slog.Int64("user_id", event.UserID),
+			slog.String("[ERROR]", err.Error()))
+	}
+}
+
+// handleApprovalDecide принимает или отклоняет заявку и сообщает участнику о решении.
+func (h *Handler) handleApprovalDecide(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	userID, err := data.Int64(1)
+	if err != nil {
+		return fmt.Errorf("failed to parse user ID: %w", err)
+	}
+
+	decision, err := data.String(2)
+	if err != nil {
+		return fmt.Errorf("failed to parse decision: %w", err)
+	}
+
+	approve := decision == decisionApprove
+
+	var event *domain.Event
+	if approve {
+		event, err = h.registrationUC.Approve(ctx, query.From.ID, eventID, userID)
+	} else {
+		event, err = h.registrationUC.Reject(ctx, query.From.ID, eventID, userID)
+	}
+
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+	noButtons := tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAccessDenied):
+			h.sendCallback(query.ID, EmCross, "Заявки подтверждает автор события")
+			return nil
+		case errors.Is(err, domain.ErrEventNotFound):
+			h.sendCallback(query.ID, EmCross, "Событие не найдено")
+			h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, noButtons))
+			return nil
+		case errors.Is(err, domain.ErrRegistrationNotFound):
+			h.sendCallback(query.ID, EmCross, "Заявка уже рассмотрена или отозвана")
+			h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, noButtons))
+			return nil
+		}
+		return fmt.Errorf("failed to decide on registration: %w", err)
+	}
+
+	verdict, notice := EmCross+" Отклонена", fmt.Sprintf("%s Ваша заявка на «%s» отклонена", EmCross, event.Title)
+	if approve {
+		verdict, notice = EmOk+" Принята", fmt.Sprintf("%s Ваша заявка на «%s» подтверждена", EmOk, event.Title)
+	}
+
+	h.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, query.Message.Text+"\n\n"+verdict))
+
+	// участник, закрывший бота, узнает о решении по кнопке регистрации
+	if _, err := h.bot.Send(tgbotapi.NewMessage(userID, notice)); err != nil {
+		h.logger.Error("failed to notify participant", slog.String("[ERROR]", err.Error()))
+	}
+
+	if !approve {
+		return nil
+	}
+
+	if err := h.sendTicket(ctx, eventID, userID); err != nil {
+		h.logger.Error("failed to send ticket", slog.String("[ERROR]", err.Error()))
+	}
+
+	if err := h.refreshEventPosts(ctx, eventID); err != nil {
+		h.logger.Error("failed to refresh event posts", slog.String("[ERROR]", err.Error()))
+	}
+
+	return nil
+}
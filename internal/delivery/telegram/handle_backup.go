@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"time"
+)
+
+// handleBackupCommand присылает администратору бота резервную копию базы.
+// Копия содержит данные всех пользователей, поэтому уходит только в личные сообщения.
+func (h *Handler) handleBackupCommand(ctx context.Context, update *tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+
+	if !h.isAdmin(userID) {
+		h.sendError(chatID, "Команда доступна только администраторам бота")
+		return nil
+	}
+
+	var file bytes.Buffer
+	if err := h.backupUC.Backup(ctx, &file); err != nil {
+		h.sendError(chatID, "Ошибка создания резервной копии")
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	doc := tgbotapi.NewDocument(userID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("evbot-backup-%s.json", time.Now().In(h.loc).Format("20060102-1504")),
+		Bytes: file.Bytes(),
+	})
+	doc.Caption = "💾 Резервная копия. Восстановление: evbot -config <файл> restore <копия>"
+
+	if _, err := h.bot.Send(doc); err != nil {
+		h.sendError(chatID, "Не удалось отправить копию: напишите боту в личные сообщения")
+		return fmt.Errorf("failed to send backup: %w", err)
+	}
+
+	if chatID != userID {
+		h.sendMsg(chatID, EmOk, "Резервная копия отправлена в личные сообщения")
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+)
+
+// broadcastPause пауза между получателями, чтобы не упереться в лимиты Telegram
+// (около 30 сообщений в секунду; каждому получателю уходит два сообщения).
+const broadcastPause = 70 * time.Millisecond
+
+// broadcastReport итог рассылки.
+type broadcastReport struct {
+	sent    int
+	failed  int
+	blocked int
+}
+
+// handleBroadcastStart ждёт от организатора сообщение для участников события.
+func (h *Handler) handleBroadcastStart(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	userID := query.From.ID
+
+	if !h.isOrganizer(query.Message.Chat, userID, event) {
+		h.sendCallback(query.ID, EmCross, "Доступ запрещен")
+		return nil
+	}
+
+	participants, err := h.registrationUC.GetParticipants(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get list of participants: %w", err)
+	}
+
+	if len(participants) == 0 {
+		h.sendCallback(query.ID, EmCross, "На событие еще никто не зарегистрирован")
+		return nil
+	}
+
+	if err := h.saveDialog(ctx, userID, domain.FlowBroadcast, stepBroadcastMessage, broadcastPayload{EventID: eventID}); err != nil {
+		return err
+	}
+
+	h.bot.Send(tgbotapi.NewMessage(query.Message.Chat.ID, fmt.Sprintf(
+		"📣 Отправьте сообщение для участников события «%s» (%d чел.): текст, фото или документ.\n"+
+			"/cancel — отменить рассылку",
+		event.Title, len(participants),
+	)))
+
+	return nil
+}
+
+// handleBroadcastInput рассылает полученное сообщение участникам события.
+func (h *Handler) handleBroadcastInput(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+	chatID := msg.Chat.ID
+
+	if msg.Text == "" && len(msg.Photo) == 0 && msg.Document == nil {
+		h.sendError(chatID, "Можно отправить текст, фото или документ")
+		return nil
+	}
+
+	payload, err := decodePayload[broadcastPayload](state)
+	if err != nil {
+		return err
+	}
+
+	if err := h.fireDialog(ctx, msg.From.ID, state, fsm.Done, nil); err != nil {
+		return err
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, payload.EventID)
+	if err != nil {
+		h.sendError(chatID, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !h.isOrganizer(msg.Chat, msg.From.ID, event) {
+		h.sendError(chatID, "Доступ запрещен")
+		return nil
+	}
+
+	participants, err := h.registrationUC.GetParticipants(ctx, event.ID)
+	if err != nil {
+		h.sendError(chatID, "Ошибка получения участников")
+		return fmt.Errorf("failed to get list of participants: %w", err)
+	}
+
+	h.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("⏳ Рассылка %d участникам началась", len(participants))))
+
+	// рассылка может занять время, не задерживаем обработку остальных обновлений
+	go func() {
+		report := h.deliverBroadcast(event, participants, msg)
+
+		h.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf(
+			"📣 Рассылка по событию «%s» завершена\n"+
+				"✅ Доставлено: %d\n"+
+				"🚫 Заблокировали бота: %d\n"+
+				"❌ Ошибки: %d",
+			event.Title, report.sent, report.blocked, report.failed,
+		)))
+	}()
+
+	return nil
+}
+
+// deliverBroadcast копирует сообщение каждому участнику, кроме отправителя.
+func (h *Handler) deliverBroadcast(event *domain.Event, participants []domain.Participant, msg *tgbotapi.Message) broadcastReport {
+	var report broadcastReport
+
+	header := fmt.Sprintf("📣 Сообщение от организатора события «%s»:", event.Title)
+
+	for _, p := range participants {
+		if p.ID == msg.From.ID {
+			continue
+		}
+
+		_, err := h.bot.Send(tgbotapi.NewMessage(p.ID, header))
+		if err == nil {
+			_, err = h.bot.CopyMessage(tgbotapi.NewCopyMessage(p.ID, msg.Chat.ID, msg.MessageID))
+		}
+
+		switch {
+		case err == nil:
+			report.sent++
+		case isBlocked(err):
+			report.blocked++
+		default:
+			report.failed++
+			h.logger.Error("failed to deliver broadcast",
+				slog.Int64("user_id", p.ID),
+				slog.String("[ERROR]", err.Error()))
+		}
+
+		time.Sleep(broadcastPause)
+	}
+
+	return report
+}
+
+// isBlocked пользователь заблокировал бота или ни разу ему не писал.
+func isBlocked(err error) bool {
+	var tgErr *tgbotapi.Error
+
+	return errors.As(err, &tgErr) && tgErr.Code == http.StatusForbidden
+}
@@ -4,73 +4,135 @@ import (
 	"context"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"strings"
+	"log/slog"
 	"time"
 
-	"github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/delivery/telegram/calendar"
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
 )
 
+// calendarHorizonMonths насколько вперёд можно планировать события.
+const calendarHorizonMonths = 24
+
 // handleCalendarCallback ...
-func (h *Handler) handleCalendarCallback(ctx context.Context, query *tgbotapi.CallbackQuery) error {
-	parts := strings.Split(query.Data, ":")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid calendar callback")
+func (h *Handler) handleCalendarCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	cb, err := calendar.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	if cb.Action == calendar.ActionNoop {
+		return nil
 	}
 
 	userID := query.From.ID
-	state, err := h.stateRepo.GetState(ctx, userID)
+	chatID := query.Message.Chat.ID
+
+	state, err := h.loadCallbackState(ctx, query)
 	if err != nil {
-		return err
+		return ignoreStateGone(err)
 	}
 
-	switch parts[1] {
-	case "prev", "next":
-		// Обработка навигации
-		date, _ := time.Parse(dateFormat, parts[2])
-		calendar := &domain.Calendar{CurrentDate: date}
+	// календарь из уже пройденного шага
+	if state.Step != domain.StepDate {
+		return nil
+	}
 
-		if parts[1] == "prev" {
-			calendar.PrevMonth()
-		} else {
-			calendar.NextMonth()
+	switch cb.Action {
+	case calendar.ActionMonth, calendar.ActionYear:
+		// навигация по месяцам и быстрый переход через обзор года
+		view := h.calendarView(ctx, *state, cb.Date)
+
+		markup := h.calendar.MonthMarkup(view)
+		if cb.Action == calendar.ActionYear {
+			markup = h.calendar.YearMarkup(view)
 		}
 
-		state.SelectedDate = time.Time{}
-		editMarkup := tgbotapi.NewEditMessageReplyMarkup(
-			query.Message.Chat.ID,
-			query.Message.MessageID,
-			generateCalendar(calendar.CurrentDate, state.SelectedDate),
-		)
-		_, err := h.bot.Send(editMarkup)
+		_, err := h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID, markup))
 		return err
 
-	case "select":
+	case calendar.ActionSelect:
 		// выбор даты
-		selectedDate, _ := time.Parse(dateFormat, parts[2])
+		selectedDate := cb.Date
+
+		view := h.calendarView(ctx, *state, selectedDate)
+		if !view.Allowed(selectedDate) {
+			h.sendCallback(query.ID, EmCross, "Эта дата недоступна")
+			return nil
+		}
+
 		state.SelectedDate = selectedDate
-		state.TempEvent.Date = selectedDate
 
-		state.Step = domain.StepTime
+		if state.Editing || state.KeepTime {
+			// время события уже известно — меняется только дата
+			d := state.TempEvent.Date
+			state.TempEvent.Date = wallClock(selectedDate, d.Hour(), d.Minute())
+		} else {
+			state.TempEvent.Date = selectedDate
+		}
+
 		err := h.stateRepo.SaveState(ctx, userID, *state)
 		if err != nil {
 			return fmt.Errorf("failed to save state: %w", err)
 		}
-		edit := tgbotapi.NewEditMessageReplyMarkup(
-			query.Message.Chat.ID,
-			query.Message.MessageID,
-			generateCalendar(selectedDate, state.SelectedDate),
-		)
-		h.bot.Send(edit)
+
+		view.Selected = selectedDate
+		h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID, h.calendar.MonthMarkup(view)))
 
 		return nil
 
-	case "confirm":
+	case calendar.ActionConfirm:
 		// Подтвержение даты
-		if state.TempEvent.Date.IsZero() {
-			h.sendError(query.Message.Chat.ID, " Дата не выбрана")
+		if state.TempEvent.Date.IsZero() || (state.KeepTime && state.SelectedDate.IsZero()) {
+			h.sendError(chatID, " Дата не выбрана")
 			return nil
 		}
+
+		h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
+
+		next := fsm.Next
+		if state.KeepTime && !state.Editing {
+			next = flowKeepTime
+		}
+
+		return h.advance(ctx, userID, chatID, *state, next)
+	}
+
+	return nil
+}
+
+// calendarView параметры календаря: прошедшие дни недоступны, дни,
+// на которые в пространстве черновика уже есть события, отмечены.
+func (h *Handler) calendarView(ctx context.Context, state domain.EventState, month time.Time) calendar.View {
+	min, max := h.dateBounds()
+
+	view := calendar.View{
+		Month:    month,
+		Selected: state.SelectedDate,
+		Today:    min,
+		Min:      min,
+		Max:      max,
+		Marked:   make(map[string]bool),
 	}
 
-	return h.handleTimeStep(ctx, userID, query.Message.Chat.ID)
+	events, err := h.eventUC.ListEvents(ctx, state.TempEvent.ChatID)
+	if err != nil {
+		h.logger.Error("failed to list events for calendar", slog.String("[ERROR]", err.Error()))
+		return view
+	}
+
+	for _, e := range events {
+		view.Marked[e.Date.Format(calendar.DayKey)] = true
+	}
+
+	return view
+}
+
+// dateBounds диапазон дат, доступных для новых событий.
+func (h *Handler) dateBounds() (time.Time, time.Time) {
+	today := startOfDay(time.Now().In(h.loc))
+
+	return today, today.AddDate(0, calendarHorizonMonths, 0)
 }
@@ -2,9 +2,12 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"log"
-	"strings"
+	"log/slog"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 )
 
 // handleCallback ...
@@ -18,27 +21,23 @@ func (h *Handler) handleCallback(ctx context.Context, update *tgbotapi.Update) e
 		}
 	}()
 
-	// обработка callback
-	data := query.Data
-	parts := strings.Split(data, ":")
-	if len(parts) == 0 {
-		return nil
-	}
+	err := h.router.Dispatch(ctx, query)
 
-	switch parts[0] {
-	case "register":
-		return h.handleRegistration(ctx, query)
-	case "participants":
-		return h.handleParticipants(ctx, query)
-	case "calendar":
-		return h.handleCalendarCallback(ctx, query)
-	case "delete_confirm":
-		return h.handleDeleteConfirmation(ctx, update)
-	case "delete_event":
-		return h.handleEventDelete(ctx, query)
-	case "delete_cancel":
-		return h.handleCancelCommand(ctx, update)
+	switch {
+	case errors.Is(err, callback.ErrSignature), errors.Is(err, callback.ErrMalformed),
+		errors.Is(err, callback.ErrUnknownAction):
+		// данные сформированы не ботом
+		h.logger.Warn("rejected forged callback",
+			slog.Int64("user_id", query.From.ID),
+			slog.String("data", query.Data),
+		)
+		h.sendCallback(query.ID, EmCross, "Недействительная кнопка")
+		return nil
+	case errors.Is(err, callback.ErrExpired), errors.Is(err, callback.ErrVersion):
+		// кнопка из старого сообщения
+		h.sendCallback(query.ID, EmCross, "Кнопка устарела, откройте список заново")
+		return nil
 	}
 
-	return nil
+	return err
 }
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"strconv"
-	"strings"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 )
 
 // handleCancelCommand ...
@@ -16,11 +16,6 @@ func (h *Handler) handleCancelCommand(ctx context.Context, update *tgbotapi.Upda
 	if update.Message != nil && update.Message.From != nil {
 		userID = update.Message.From.ID
 		chatID = update.Message.Chat.ID
-	} else if update.CallbackQuery != nil && update.CallbackQuery.From != nil {
-		userID = update.CallbackQuery.From.ID
-		if update.CallbackQuery.Message != nil {
-			chatID = update.CallbackQuery.Message.Chat.ID
-		}
 	} else {
 		return errors.New("failed to get user ID")
 	}
@@ -30,31 +25,43 @@ func (h *Handler) handleCancelCommand(ctx context.Context, update *tgbotapi.Upda
 		return err
 	}
 
-	if update.CallbackQuery != nil {
-		parts := strings.Split(update.CallbackQuery.Data, ":")
-		eventID, err := strconv.ParseInt(parts[1], 10, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse event ID: %w", err)
-		}
+	text := "Текущее действие отменено"
+	h.sendMsg(chatID, EmOk, text)
+
+	return nil
+}
 
-		isRegistered, err := h.registrationUC.IsRegistered(ctx, eventID, userID)
+// handleDeleteCancel отмена удаления события возвращает обычные кнопки
+// карточки и не затрагивает черновики.
+func (h *Handler) handleDeleteCancel(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	buttons := h.groupEventButtons(eventID)
+	if !isGroupChat(query.Message.Chat) {
+		status, err := h.registrationUC.Status(ctx, eventID, query.From.ID)
 		if err != nil {
 			return fmt.Errorf("failed to get registraion of user: %w", err)
 		}
-		isAdmin := h.isAdmin(userID)
 
-		buttons := createEventButtons(eventID, isRegistered, isAdmin)
-
-		editMarkup := tgbotapi.NewEditMessageReplyMarkup(
-			update.CallbackQuery.Message.Chat.ID,
-			update.CallbackQuery.Message.MessageID,
-			buttons,
-		)
-		h.bot.Send(editMarkup)
-	} else {
-		text := "Текущее действие отменено"
-		h.sendMsg(chatID, EmOk, text)
+		buttons = h.createEventButtons(eventID, status,
+			h.canModerateEvent(query.Message.Chat, query.From.ID, event),
+			h.isOrganizer(query.Message.Chat, query.From.ID, event))
 	}
 
+	editMarkup := tgbotapi.NewEditMessageReplyMarkup(
+		query.Message.Chat.ID,
+		query.Message.MessageID,
+		buttons,
+	)
+	h.bot.Send(editMarkup)
+
 	return nil
 }
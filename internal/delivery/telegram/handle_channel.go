@@ -0,0 +1,233 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/util"
+)
+
+// handleSetChannelCommand привязывает канал для анонсов к пространству чата.
+// Использование: /set_channel @channel | -100123 | off
+func (h *Handler) handleSetChannelCommand(ctx context.Context, update *tgbotapi.Update) error {
+	msg := update.Message
+	chatID := msg.Chat.ID
+	space := spaceID(msg.Chat)
+
+	if !h.canModerate(msg.Chat, msg.From.ID) {
+		h.sendError(chatID, "Доступ запрещен")
+		return nil
+	}
+
+	arg := strings.TrimSpace(msg.CommandArguments())
+	switch arg {
+	case "":
+		channelID, err := h.channelUC.ChannelFor(ctx, space)
+		if err != nil {
+			h.sendError(chatID, "Ошибка получения канала")
+			return fmt.Errorf("failed to get channel: %w", err)
+		}
+
+		if channelID == 0 {
+			h.sendMsg(chatID, EmPin, "Канал для анонсов не привязан\n\n"+
+				"Добавьте бота администратором канала и выполните\n*/set_channel @канал*")
+			return nil
+		}
+
+		h.sendMsg(chatID, EmPin, fmt.Sprintf("Анонсы публикуются в канал `%d`\n\n"+
+			"Отвязать: */set_channel off*", channelID))
+		return nil
+
+	case "off":
+		if err := h.channelUC.Unbind(ctx, space); err != nil {
+			h.sendError(chatID, "Ошибка отвязки канала")
+			return fmt.Errorf("failed to unbind channel: %w", err)
+		}
+
+		h.sendMsg(chatID, EmOk, "Канал для анонсов отвязан")
+		return nil
+	}
+
+	channel, err := h.resolveChannel(arg, msg.From.ID)
+	if errors.Is(err, errNotChannelAdmin) {
+		h.sendError(chatID, "Привязать канал может только его администратор")
+		return nil
+	}
+	if err != nil {
+		h.sendError(chatID, "Канал не найден или бот не является его администратором")
+		h.logger.Error("failed to resolve channel",
+			slog.String("channel", arg),
+			slog.String("[ERROR]", err.Error()))
+		return nil
+	}
+
+	if err := h.channelUC.Bind(ctx, space, channel.ID); err != nil {
+		h.sendError(chatID, "Ошибка привязки канала")
+		return fmt.Errorf("failed to bind channel: %w", err)
+	}
+
+	h.sendMsg(chatID, EmOk, fmt.Sprintf("Новые события будут публиковаться в канал `%d`", channel.ID))
+
+	return nil
+}
+
+// errNotChannelAdmin пользователь, привязывающий канал, не администрирует его.
+var errNotChannelAdmin = errors.New("user is not a channel admin")
+
+// resolveChannel находит канал по @username или ID и проверяет, что бот
+// может в нём публиковать, а пользователь — распоряжаться им. Иначе любой
+// участник чата направил бы анонсы в чужой канал, где бот администратор.
+func (h *Handler) resolveChannel(arg string, userID int64) (*tgbotapi.Chat, error) {
+	var cfg tgbotapi.ChatInfoConfig
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		cfg.ChatID = id
+	} else {
+		cfg.SuperGroupUsername = "@" + strings.TrimPrefix(arg, "@")
+	}
+
+	chat, err := h.bot.GetChat(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat: %w", err)
+	}
+
+	if !chat.IsChannel() {
+		return nil, errors.New("chat is not a channel")
+	}
+
+	if !h.isChatAdmin(chat.ID, h.bot.Self.ID) {
+		return nil, errors.New("bot is not a channel admin")
+	}
+
+	if !h.isChatAdmin(chat.ID, userID) {
+		return nil, errNotChannelAdmin
+	}
+
+	return &chat, nil
+}
+
+// publishEvent публикует анонс события в канал его пространства.
+func (h *Handler) publishEvent(ctx context.Context, event domain.Event) error {
+	channelID, err := h.channelUC.ChannelFor(ctx, event.ChatID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channelID == 0 {
+		return nil
+	}
+
+	messages := eventMessages(channelID, event, channelPostText(event, 0), h.createChannelButtons(event.ID, 0))
+
+	// кнопки — только у первого сообщения карточки, его и отслеживаем
+	sent, err := h.bot.Send(messages[0])
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	for _, msg := range messages[1:] {
+		h.bot.Send(msg)
+	}
+
+	return h.channelUC.AddPost(ctx, domain.EventPost{
+		EventID:   event.ID,
+		ChannelID: channelID,
+		MessageID: sent.MessageID,
+	})
+}
+
+// refreshEventPosts обновляет опубликованные анонсы события: детали и число участников.
+func (h *Handler) refreshEventPosts(ctx context.Context, eventID int64) error {
+	posts, err := h.channelUC.Posts(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	if len(posts) == 0 {
+		return nil
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	participants, err := h.registrationUC.GetParticipants(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	text := channelPostText(*event, len(participants))
+	markup := h.createChannelButtons(eventID, len(participants))
+
+	for _, p := range posts {
+		if _, err := h.bot.Send(editPost(p, *event, text, &markup)); err != nil {
+			h.logger.Error("failed to refresh channel post",
+				slog.Int64("event_id", eventID),
+				slog.String("[ERROR]", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// closeEventPosts помечает анонсы удалённого события как отменённые и убирает кнопки.
+// Записи об анонсах база удаляет вместе с событием, поэтому их читают заранее.
+func (h *Handler) closeEventPosts(event *domain.Event, posts []domain.EventPost) {
+	text := channelPostText(*event, 0) + "\n\n" + EmCross + " *Событие отменено*"
+
+	for _, p := range posts {
+		if _, err := h.bot.Send(editPost(p, *event, text, nil)); err != nil {
+			h.logger.Error("failed to close channel post",
+				slog.Int64("event_id", event.ID),
+				slog.String("[ERROR]", err.Error()))
+		}
+	}
+}
+
+// editPost редактирует текст или подпись анонса; markup == nil убирает кнопки.
+func editPost(post domain.EventPost, event domain.Event, text string, markup *tgbotapi.InlineKeyboardMarkup) tgbotapi.Chattable {
+	if hasMedia(event) {
+		edit := tgbotapi.NewEditMessageCaption(post.ChannelID, post.MessageID, text)
+		edit.ParseMode = tgbotapi.ModeMarkdownV2
+		edit.ReplyMarkup = markup
+
+		return edit
+	}
+
+	edit := tgbotapi.NewEditMessageText(post.ChannelID, post.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeMarkdownV2
+	edit.ReplyMarkup = markup
+
+	return edit
+}
+
+// channelPostText ...
+func channelPostText(event domain.Event, participants int) string {
+	return fmt.Sprintf(
+		"📌 *%s*\n"+
+			"📝 %s\n"+
+			"⏰ %s\n"+
+			"%s *Участников:* %d",
+		util.EscapeMarkdownV2(event.Title),
+		util.EscapeMarkdownV2(event.Description),
+		event.Date.Format("02\\.01\\.2006 15\\:04"),
+		EmPeople,
+		participants,
+	)
+}
+
+// createChannelButtons кнопки анонса общие для всех подписчиков канала,
+// поэтому не зависят от регистрации конкретного пользователя.
+func (h *Handler) createChannelButtons(eventID int64, participants int) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.PermanentButton(fmt.Sprintf("%s Зарегистрироваться (%d)", EmReg, participants), actRegister, eventID),
+		),
+	)
+}
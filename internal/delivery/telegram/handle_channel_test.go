@@ -0,0 +1,65 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestSetChannelRequiresChannelAdmin(t *testing.T) {
+	const (
+		organizer int64 = 10
+		stranger  int64 = 11
+		channelID int64 = -1001
+	)
+
+	group := &tgbotapi.Chat{ID: -42, Type: "supergroup"}
+
+	tests := []struct {
+		name string
+		// admins администраторы канала.
+		admins    []int64
+		wantBound bool
+		wantText  string
+	}{
+		{"requester and bot are admins", []int64{botID, organizer}, true, "будут публиковаться"},
+		{"bot is admin, requester is not", []int64{botID, stranger}, false, "только его администратор"},
+		{"bot is not admin", []int64{organizer}, false, "бот не является его администратором"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{
+				chats: map[string]tgbotapi.Chat{
+					"@news": {ID: channelID, Type: "channel", UserName: "news"},
+				},
+				admins: map[int64][]int64{
+					// команду в группе могут давать её администраторы
+					group.ID:  {organizer},
+					channelID: tt.admins,
+				},
+			}
+			h := newTestHandler(t, api)
+			ctx := context.Background()
+
+			if err := h.handleSetChannelCommand(ctx, command(group, organizer, "/set_channel @news")); err != nil {
+				t.Fatalf("handleSetChannelCommand: %v", err)
+			}
+
+			bound, err := h.channelUC.ChannelFor(ctx, group.ID)
+			if err != nil {
+				t.Fatalf("ChannelFor: %v", err)
+			}
+			if got := bound == channelID; got != tt.wantBound {
+				t.Errorf("channel bound = %v (channel %d), want %v", got, bound, tt.wantBound)
+			}
+
+			sent := api.sent(group.ID)
+			if len(sent) != 1 || !strings.Contains(sent[0].params["text"], tt.wantText) {
+				t.Errorf("replies = %+v, want one containing %q", sent, tt.wantText)
+			}
+		})
+	}
+}
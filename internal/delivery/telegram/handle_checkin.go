@@ -0,0 +1,198 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+	"github.com/binaryty/evbot/internal/qrcode"
+	"github.com/binaryty/evbot/internal/ticket"
+)
+
+const (
+	// checkInPrefix начало параметра /start в ссылке из QR-кода билета:
+	// организатор сканирует код камерой телефона, и бот сразу отмечает участника.
+	checkInPrefix = "ci_"
+	// ticketQRScale пикселей на модуль QR-кода в изображении билета.
+	ticketQRScale = 10
+	// maxPhotoSize предел размера фото билета, которое скачивает бот.
+	maxPhotoSize = 10 << 20
+)
+
+// sendTicket отправляет участнику в личные сообщения билет с QR-кодом.
+func (h *Handler) sendTicket(ctx context.Context, eventID int64, userID int64) error {
+	t, err := h.registrationUC.Ticket(ctx, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	code, err := qrcode.Encode(fmt.Sprintf("https://t.me/%s?start=%s%s", h.bot.Self.UserName, checkInPrefix, t.Token))
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket: %w", err)
+	}
+
+	picture, err := code.PNG(ticketQRScale)
+	if err != nil {
+		return fmt.Errorf("failed to render ticket: %w", err)
+	}
+
+	photo := tgbotapi.NewPhoto(userID, tgbotapi.FileBytes{Name: "ticket.png", Bytes: picture})
+	photo.Caption = fmt.Sprintf("%s Билет на «%s»\n📅 %s\n\nКод: %s\nПокажите QR-код организатору на входе.",
+		EmReg,
+		event.Title,
+		event.Date.Format("02.01.2006 15:04"),
+		ticket.Format(t.Token),
+	)
+
+	if _, err := h.bot.Send(photo); err != nil {
+		return fmt.Errorf("failed to send ticket: %w", err)
+	}
+
+	return nil
+}
+
+// handleCheckInCommand включает режим отметки участников; код можно передать
+// сразу: /checkin ABCD-EFGH-IJKL-MNOP.
+func (h *Handler) handleCheckInCommand(ctx context.Context, update *tgbotapi.Update) error {
+	msg := update.Message
+
+	if token, ok := ticket.Find(msg.CommandArguments()); ok {
+		return h.checkInTicket(ctx, msg.Chat, msg.From.ID, token)
+	}
+
+	if err := h.saveDialog(ctx, msg.From.ID, domain.FlowCheckIn, stepCheckInTicket, nil); err != nil {
+		return err
+	}
+
+	h.sendMsg(msg.Chat.ID, EmReg, "Режим отметки участников.\n"+
+		"Отправляйте коды билетов текстом, фото QR-кода с экрана участника или пересылайте сообщения с билетами. "+
+		"QR-код билета можно просто отсканировать камерой телефона — участник отметится сразу.\n"+
+		"/cancel — выйти из режима")
+
+	return nil
+}
+
+// handleCheckInInput отмечает участника по коду из текста, подписи к фото билета
+// или по QR-коду на самом фото.
+func (h *Handler) handleCheckInInput(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+
+	// каждое действие продлевает режим, иначе он истечёт посреди мероприятия
+	if err := h.fireDialog(ctx, msg.From.ID, state, fsm.Next, nil); err != nil {
+		return err
+	}
+
+	text := msg.Text
+	if msg.Caption != "" {
+		text = msg.Caption
+	}
+
+	if token, ok := ticket.Find(text); ok {
+		return h.checkInTicket(ctx, msg.Chat, msg.From.ID, token)
+	}
+
+	if len(msg.Photo) == 0 {
+		h.sendError(msg.Chat.ID, "Не похоже на код билета: он выглядит как ABCD-EFGH-IJKL-MNOP")
+		return nil
+	}
+
+	content, err := h.readQRCode(ctx, msg.Photo)
+	if errors.Is(err, qrcode.ErrNotFound) || errors.Is(err, qrcode.ErrUnreadable) {
+		h.sendError(msg.Chat.ID, "Не удалось распознать QR-код. Сфотографируйте его ближе и ровнее или введите код билета текстом")
+		return nil
+	}
+	if err != nil {
+		h.sendError(msg.Chat.ID, "Не удалось загрузить фото")
+		return fmt.Errorf("failed to read qr code: %w", err)
+	}
+
+	token, ok := ticket.Find(content)
+	if !ok {
+		h.sendError(msg.Chat.ID, "В QR-коде нет кода билета")
+		return nil
+	}
+
+	return h.checkInTicket(ctx, msg.Chat, msg.From.ID, token)
+}
+
+// readQRCode скачивает самый крупный вариант фото и читает QR-код на нём.
+func (h *Handler) readQRCode(ctx context.Context, sizes []tgbotapi.PhotoSize) (string, error) {
+	body, err := h.openFile(ctx, sizes[len(sizes)-1].FileID)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	img, _, err := image.Decode(io.LimitReader(body, maxPhotoSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode photo: %w", err)
+	}
+
+	return qrcode.Decode(img)
+}
+
+// checkInTicket отмечает приход по билету, если userID — организатор события.
+func (h *Handler) checkInTicket(ctx context.Context, chat *tgbotapi.Chat, userID int64, token string) error {
+	t, err := h.registrationUC.TicketByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, domain.ErrTicketNotFound) {
+			h.sendError(chat.ID, "Билет не найден: возможно, регистрация отменена")
+			return nil
+		}
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, t.EventID)
+	if err != nil {
+		h.sendError(chat.ID, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !h.isOrganizer(chat, userID, event) {
+		if t.UserID == userID {
+			h.bot.Send(tgbotapi.NewMessage(chat.ID, fmt.Sprintf(
+				"%s Это ваш билет на «%s». Покажите QR-код организатору на входе", EmReg, event.Title)))
+			return nil
+		}
+		h.sendError(chat.ID, "Отмечать участников может только организатор события")
+		return nil
+	}
+
+	t, err = h.registrationUC.CheckIn(ctx, token)
+	if errors.Is(err, domain.ErrRegistrationPending) {
+		h.sendError(chat.ID, "Заявка участника ещё не подтверждена")
+		return nil
+	}
+	if err != nil && !errors.Is(err, domain.ErrAlreadyCheckedIn) {
+		h.sendError(chat.ID, "Ошибка отметки участника")
+		return fmt.Errorf("failed to check in: %w", err)
+	}
+
+	name := "Участник"
+	if user, userErr := h.userUC.User(ctx, t.UserID); userErr == nil {
+		name = userLabel(*user)
+	}
+
+	if errors.Is(err, domain.ErrAlreadyCheckedIn) {
+		h.bot.Send(tgbotapi.NewMessage(chat.ID, fmt.Sprintf("⚠️ %s — билет на «%s» уже использован в %s",
+			name, event.Title, t.CheckedInAt.In(h.loc).Format("15:04 02.01"))))
+		return nil
+	}
+
+	h.bot.Send(tgbotapi.NewMessage(chat.ID, fmt.Sprintf("%s %s — отметка на «%s»", EmOk, name, event.Title)))
+
+	return nil
+}
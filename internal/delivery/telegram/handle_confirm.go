@@ -0,0 +1,236 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"time"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+	"github.com/binaryty/evbot/internal/util"
+)
+
+// sendConfirmation ...
+func (h *Handler) sendConfirmation(chatID int64, event domain.Event) error {
+	for _, msg := range eventMessages(chatID, event, previewText(event), h.createPreviewButtons()) {
+		if _, err := h.bot.Send(msg); err != nil {
+			return fmt.Errorf("failed to send preview: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// handleConfirmCallback ...
+func (h *Handler) handleConfirmCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	command, err := data.String(0)
+	if err != nil {
+		return fmt.Errorf("invalid confirm callback: %w", err)
+	}
+
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	state, err := h.loadCallbackState(ctx, query)
+	if err != nil {
+		return ignoreStateGone(err)
+	}
+
+	if state.Step != domain.StepConfirm {
+		return nil
+	}
+
+	switch command {
+	case confirmSave:
+		return h.handleFinishEventCreation(ctx, query, *state)
+
+	case confirmEdit:
+		edit := tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID, h.createEditFieldButtons())
+		_, err := h.bot.Send(edit)
+		return err
+
+	case confirmBack:
+		edit := tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID, h.createPreviewButtons())
+		_, err := h.bot.Send(edit)
+		return err
+
+	case confirmField:
+		field, err := data.String(1)
+		if err != nil {
+			return fmt.Errorf("invalid confirm callback: %w", err)
+		}
+		h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
+
+		return h.editField(ctx, userID, chatID, *state, field)
+
+	case confirmCancel:
+		if err := h.advance(ctx, userID, chatID, *state, fsm.Cancel); err != nil {
+			return err
+		}
+		h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
+		h.sendMsg(chatID, EmOk, "Создание события отменено")
+
+		return nil
+	}
+
+	return nil
+}
+
+// handleFinishEventCreation сохраняет подтверждённое событие.
+func (h *Handler) handleFinishEventCreation(ctx context.Context, query *tgbotapi.CallbackQuery, state domain.EventState) error {
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	// Валидация данных
+	if state.TempEvent.Title == "" || state.TempEvent.Date.IsZero() {
+		h.sendError(chatID, "Не все данные заполнены")
+		return errors.New("incomplete event data")
+	}
+
+	// черновик мог пролежать на предпросмотре, пока время события не прошло
+	if problem := h.momentProblem(state.TempEvent.Date); problem != "" {
+		h.sendError(chatID, problem+"\nИзмените дату и время кнопкой «Изменить поле»")
+		return nil
+	}
+
+	// создаем полный объект события
+	event := domain.Event{
+		UserID:         userID,
+		ChatID:         state.TempEvent.ChatID,
+		Title:          state.TempEvent.Title,
+		Description:    state.TempEvent.Description,
+		PhotoFileID:    state.TempEvent.PhotoFileID,
+		DocumentFileID: state.TempEvent.DocumentFileID,
+		Date:           state.TempEvent.Date,
+		Questions:      state.TempEvent.Questions,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	// Сохраняем в БД
+	var err error
+	event.ID, err = h.eventUC.CreateEvent(ctx, userID, event)
+	if err != nil {
+		h.sendError(chatID, "Ошибка сохранения события")
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+
+	// Завершаем мастер и очищаем состояние
+	if err := h.advance(ctx, userID, chatID, state, flowSave); err != nil {
+		h.logger.Error("failed to clear user state", slog.String("[ERROR]", err.Error()))
+	}
+
+	if err := h.publishEvent(ctx, event); err != nil {
+		h.logger.Error("failed to publish event", slog.String("[ERROR]", err.Error()))
+	}
+
+	// предпросмотр больше не нужен
+	h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
+
+	// Отправляем подтверждение
+	msgText := "🎉 *Событие успешно создано\\!*\n\n" + eventFieldsText(event)
+
+	// Создаем кнопки управления
+	isAdmin := h.canModerate(query.Message.Chat, userID)
+	markup := h.createEventButtons(event.ID, domain.RegistrationNone, isAdmin, true)
+
+	// в группе карточку видят все, кнопки управления уходят автору в личный чат
+	group := isGroupChat(query.Message.Chat)
+	cardMarkup := markup
+	if group {
+		cardMarkup = h.groupEventButtons(event.ID)
+	}
+
+	for _, msg := range eventMessages(chatID, event, msgText, cardMarkup) {
+		if _, err := h.bot.Send(msg); err != nil {
+			return fmt.Errorf("failed to send confirmation: %w", err)
+		}
+	}
+
+	if group {
+		h.sendOrganizerCards(query.Message.Chat, userID, eventMessages(userID, event, eventFieldsText(event), markup))
+	}
+
+	return nil
+}
+
+// previewText ...
+func previewText(event domain.Event) string {
+	attachments := "—"
+	switch {
+	case event.PhotoFileID != "" && event.DocumentFileID != "":
+		attachments = "обложка и документ"
+	case event.PhotoFileID != "":
+		attachments = "обложка"
+	case event.DocumentFileID != "":
+		attachments = "документ"
+	}
+
+	questions := "нет"
+	if n := len(event.Questions); n > 0 {
+		questions = fmt.Sprint(n)
+	}
+
+	return "👀 *Проверьте событие перед сохранением*\n\n" +
+		eventFieldsText(event) + "\n" +
+		"📎 *Вложения:* " + attachments + "\n" +
+		"❓ *Вопросы участникам:* " + questions
+}
+
+// eventFieldsText ...
+func eventFieldsText(event domain.Event) string {
+	description := event.Description
+	if description == "" {
+		description = "—"
+	}
+
+	return fmt.Sprintf(
+		"📌 *Название:* %s\n"+
+			"📝 *Описание:* %s\n"+
+			"⏰ *Дата и время:* %s",
+		util.EscapeMarkdownV2(event.Title),
+		util.EscapeMarkdownV2(description),
+		event.Date.Format("02\\.01\\.2006 15\\:04"),
+	)
+}
+
+// createPreviewButtons ...
+func (h *Handler) createPreviewButtons() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button("💾 Сохранить", actConfirm, confirmSave),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button("✏️ Изменить поле", actConfirm, confirmEdit),
+			h.callbacks.Button(EmCross+" Отмена", actConfirm, confirmCancel),
+		),
+	)
+}
+
+// createEditFieldButtons ...
+func (h *Handler) createEditFieldButtons() tgbotapi.InlineKeyboardMarkup {
+	field := func(text string, step string) tgbotapi.InlineKeyboardButton {
+		return h.callbacks.Button(text, actConfirm, confirmField, step)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			field("Название", domain.StepTitle),
+			field("Описание", domain.StepDescription),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			field("Вложения", domain.StepMedia),
+			field("Дата", domain.StepDate),
+			field("Время", domain.StepTime),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			field("❓ Вопросы участникам", domain.StepQuestions),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button(EmPrev+" Назад", actConfirm, confirmBack),
+		),
+	)
+}
@@ -5,38 +5,35 @@ import (
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"log"
-	"strconv"
-	"strings"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 )
 
 // handleDeleteConfirmation ...
-func (h *Handler) handleDeleteConfirmation(ctx context.Context, update *tgbotapi.Update) error {
-	query := update.CallbackQuery
+func (h *Handler) handleDeleteConfirmation(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
 	chatID := query.Message.Chat.ID
 
-	if !h.isAdmin(query.From.ID) {
-		h.sendError(chatID, "Доступ запрещен")
-		return nil
-	}
-
-	parts := strings.Split(query.Data, ":")
-	eventID, err := strconv.ParseInt(parts[1], 10, 64)
+	eventID, err := data.Int64(0)
 	if err != nil {
 		log.Printf("failed to parse event ID: %v", err)
 		return fmt.Errorf("failed to parse event ID: %w", err)
 	}
 
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendError(chatID, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !h.canModerateEvent(query.Message.Chat, query.From.ID, event) {
+		h.sendError(chatID, "Доступ запрещен")
+		return nil
+	}
+
 	markup := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(
-				"✅ Подтвердить удаление",
-				fmt.Sprintf("delete_event:%d", eventID),
-			),
-
-			tgbotapi.NewInlineKeyboardButtonData(
-				"❌ Отмена",
-				fmt.Sprintf("delete_cancel:%d", eventID),
-			),
+			h.callbacks.Button("✅ Подтвердить удаление", actDelete, eventID),
+			h.callbacks.Button("❌ Отмена", actDeleteCancel, eventID),
 		),
 	)
 
@@ -0,0 +1,189 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"strings"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/util"
+)
+
+// maxDraftNameLen ограничение длины имени черновика, в символах.
+const maxDraftNameLen = 64
+
+// stepNames названия шагов мастера для списка черновиков.
+var stepNames = map[string]string{
+	domain.StepTitle:       "название",
+	domain.StepDescription: "описание",
+	domain.StepMedia:       "вложения",
+	domain.StepDate:        "дата",
+	domain.StepTime:        "время",
+	domain.StepQuestions:   "вопросы",
+	domain.StepConfirm:     "подтверждение",
+}
+
+// handleDraftsCommand ...
+func (h *Handler) handleDraftsCommand(ctx context.Context, update *tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	text, markup, err := h.renderDrafts(ctx, update.Message.From.ID)
+	if err != nil {
+		h.sendError(chatID, "Ошибка получения черновиков")
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeMarkdownV2
+	if markup != nil {
+		msg.ReplyMarkup = *markup
+	}
+
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// handleDraftResume делает черновик текущим и продолжает мастер с его шага.
+func (h *Handler) handleDraftResume(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	draftID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse draft ID: %w", err)
+	}
+
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if err := h.stateRepo.ActivateDraft(ctx, userID, draftID); err != nil {
+		if errors.Is(err, repository.ErrStateNotFound) {
+			h.sendCallback(query.ID, EmCross, "Черновик не найден")
+			return nil
+		}
+		return fmt.Errorf("failed to activate draft: %w", err)
+	}
+
+	state, err := h.loadState(ctx, userID, chatID)
+	if err != nil {
+		return ignoreStateGone(err)
+	}
+
+	return h.promptStep(ctx, chatID, *state)
+}
+
+// handleDraftDelete удаляет черновик и обновляет список.
+func (h *Handler) handleDraftDelete(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	draftID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse draft ID: %w", err)
+	}
+
+	userID := query.From.ID
+
+	if err := h.stateRepo.DeleteDraft(ctx, userID, draftID); err != nil && !errors.Is(err, repository.ErrStateNotFound) {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	text, markup, err := h.renderDrafts(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeMarkdownV2
+	edit.ReplyMarkup = markup
+	_, err = h.bot.Send(edit)
+
+	return err
+}
+
+// handleDraftRename запрашивает новое имя черновика.
+func (h *Handler) handleDraftRename(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	draftID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse draft ID: %w", err)
+	}
+
+	if err := h.saveDialog(ctx, query.From.ID, domain.FlowDraftName, stepDraftName, draftNamePayload{DraftID: draftID}); err != nil {
+		return err
+	}
+
+	h.bot.Send(tgbotapi.NewMessage(query.Message.Chat.ID,
+		"✏️ Введите новое имя черновика\n/cancel — оставить прежнее"))
+
+	return nil
+}
+
+// handleDraftNameInput сохраняет имя черновика и показывает обновлённый список.
+func (h *Handler) handleDraftNameInput(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+	// пустое и слишком длинное имя отсеяны валидатором шага
+	name := strings.TrimSpace(msg.Text)
+
+	payload, err := decodePayload[draftNamePayload](state)
+	if err != nil {
+		return err
+	}
+
+	if err := h.fireDialog(ctx, msg.From.ID, state, fsm.Done, nil); err != nil {
+		return err
+	}
+
+	if err := h.stateRepo.RenameDraft(ctx, msg.From.ID, payload.DraftID, name); err != nil {
+		if errors.Is(err, repository.ErrStateNotFound) {
+			h.sendError(msg.Chat.ID, "Черновик не найден")
+			return nil
+		}
+		h.sendError(msg.Chat.ID, "Ошибка переименования черновика")
+		return fmt.Errorf("failed to rename draft: %w", err)
+	}
+
+	return h.handleDraftsCommand(ctx, update)
+}
+
+// renderDrafts ...
+func (h *Handler) renderDrafts(ctx context.Context, userID int64) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	drafts, err := h.stateRepo.ListDrafts(ctx, userID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	if len(drafts) == 0 {
+		return "📝 Черновиков нет\\. Создайте событие командой /new\\_event", nil, nil
+	}
+
+	var (
+		text strings.Builder
+		rows [][]tgbotapi.InlineKeyboardButton
+	)
+
+	text.WriteString("📝 *Ваши черновики:*\n\n")
+
+	for i, d := range drafts {
+		current := ""
+		if d.Active {
+			current = " \\(текущий\\)"
+		}
+
+		text.WriteString(fmt.Sprintf("%d\\. *%s*%s\n    шаг: %s, изменён %s\n",
+			i+1,
+			util.EscapeMarkdownV2(d.Name),
+			current,
+			stepNames[d.State.Step],
+			d.UpdatedAt.Format("02\\.01 15\\:04"),
+		))
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button(fmt.Sprintf("%s %d. Продолжить", EmNext, i+1), actDraftResume, d.ID),
+			h.callbacks.Button(fmt.Sprintf("✏️ %d. Имя", i+1), actDraftRename, d.ID),
+			h.callbacks.Button(fmt.Sprintf("🗑 %d. Удалить", i+1), actDraftDelete, d.ID),
+		))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return text.String(), &markup, nil
+}
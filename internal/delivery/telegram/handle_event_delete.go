@@ -4,12 +4,13 @@ import (
 	"context"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"strconv"
-	"strings"
+	"log/slog"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 )
 
 // handleEventDelete ...
-func (h *Handler) handleEventDelete(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+func (h *Handler) handleEventDelete(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
 	defer func() {
 		if r := recover(); r != nil {
 			h.bot.Send(tgbotapi.NewCallbackWithAlert(query.ID, "⚠️ Произошла ошибка"))
@@ -18,32 +19,40 @@ func (h *Handler) handleEventDelete(ctx context.Context, query *tgbotapi.Callbac
 
 	chatID := query.Message.Chat.ID
 
-	if !h.isAdmin(query.From.ID) {
-		h.sendError(chatID, "🚫 Доступ запрещен")
-		return nil
+	eventID, err := data.Int64(0)
+	if err != nil {
+		callback := tgbotapi.NewCallbackWithAlert(query.ID, "❌ Некорректный ID события")
+		h.bot.Send(callback)
+		return fmt.Errorf("failed to parse event ID: %w", err)
 	}
 
-	parts := strings.Split(query.Data, ":")
-	if len(parts) < 2 {
-		callback := tgbotapi.NewCallbackWithAlert(query.ID, "❌ Ошибка формата запроса")
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		callback := tgbotapi.NewCallbackWithAlert(query.ID, "❌ Событие не найдено")
 		h.bot.Send(callback)
+		return fmt.Errorf("failed to get event: %w", err)
 	}
 
-	eventID, err := strconv.ParseInt(parts[1], 10, 64)
+	if !h.canModerateEvent(query.Message.Chat, query.From.ID, event) {
+		h.sendError(chatID, "🚫 Доступ запрещен")
+		return nil
+	}
+
+	posts, err := h.channelUC.Posts(ctx, eventID)
 	if err != nil {
-		callback := tgbotapi.NewCallbackWithAlert(query.ID, "❌ Некорректный ID события")
-		h.bot.Send(callback)
-		return fmt.Errorf("failed to parse event ID: %w", err)
+		h.logger.Error("failed to get event posts", slog.String("[ERROR]", err.Error()))
 	}
 
-	// удаляем событие
-	if err := h.eventUC.DeleteEvent(ctx, eventID); err != nil {
+	// удаляем событие и сообщаем об отмене участникам
+	if err := h.registrationUC.CancelEvent(ctx, eventID); err != nil {
 		callback := tgbotapi.NewCallbackWithAlert(query.ID, "❌ Ошибка удаления события")
 		h.bot.Send(callback)
 		return err
 	}
 	h.sendCallback(query.ID, EmOk, "Событие успешно удалено")
 
+	h.closeEventPosts(event, posts)
+
 	// удаляем сообщение с событием
 	deleteMsg := tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID)
 	h.bot.Send(deleteMsg)
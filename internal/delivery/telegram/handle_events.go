@@ -10,16 +10,22 @@ import (
 	"github.com/binaryty/evbot/internal/util"
 )
 
-// startNewEvent ...
+// startNewEvent начинает новый черновик; незавершённый остаётся в /drafts.
 func (h *Handler) startNewEvent(ctx context.Context, update *tgbotapi.Update) error {
+	if state, err := h.stateRepo.GetState(ctx, update.Message.From.ID); err == nil && state.IsDraft() {
+		h.sendMsg(update.Message.Chat.ID, EmPin,
+			"Предыдущий черновик сохранён, вернуться к нему можно через */drafts*")
+	}
+
 	initialState := domain.EventState{
 		Step: domain.StepTitle,
 		TempEvent: domain.Event{
-			UserID: update.Message.Chat.ID,
+			UserID: update.Message.From.ID,
+			ChatID: spaceID(update.Message.Chat),
 		},
 	}
 
-	if err := h.stateRepo.SaveState(ctx, update.Message.From.ID, initialState); err != nil {
+	if _, err := h.stateRepo.CreateDraft(ctx, update.Message.From.ID, initialState); err != nil {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
 
@@ -34,7 +40,7 @@ func (h *Handler) listEvents(ctx context.Context, update *tgbotapi.Update) error
 	chatID := update.Message.Chat.ID
 	userID := update.Message.From.ID
 
-	events, err := h.eventUC.ListEvents(ctx)
+	events, err := h.eventUC.ListEvents(ctx, spaceID(update.Message.Chat))
 	if err != nil {
 		h.sendError(chatID, "Ошибка получения событий")
 		return fmt.Errorf("%s:list events error: %w", op, err)
@@ -46,17 +52,15 @@ func (h *Handler) listEvents(ctx context.Context, update *tgbotapi.Update) error
 		return nil
 	}
 
-	var messages []tgbotapi.Chattable
-	isAdmin := h.isAdmin(userID)
+	var (
+		messages []tgbotapi.Chattable
+		// organizer карточки с кнопками управления для личного чата
+		organizer []tgbotapi.Chattable
+	)
+	isAdmin := h.canModerate(update.Message.Chat, userID)
+	group := isGroupChat(update.Message.Chat)
 
 	for _, event := range events {
-		// Проверяем регистрацию пользователя
-		isRegistered, err := h.registrationUC.IsRegistered(ctx, event.ID, userID)
-		if err != nil {
-			log.Printf("failed to check if user is registered: %v", err)
-			continue
-		}
-
 		// Формируем сообщение для каждого события
 		eventOwner, _ := h.userUC.User(ctx, event.UserID)
 
@@ -71,13 +75,27 @@ func (h *Handler) listEvents(ctx context.Context, update *tgbotapi.Update) error
 			util.EscapeMarkdownV2(eventOwner.UserName),
 		)
 
-		buttons := createEventButtons(event.ID, isRegistered, isAdmin)
+		isOrganizer := isAdmin || event.UserID == userID
 
-		// Создаем сообщение с кнопками
-		msg := tgbotapi.NewMessage(chatID, text)
-		msg.ReplyMarkup = buttons
-		msg.ParseMode = tgbotapi.ModeMarkdownV2
-		messages = append(messages, msg)
+		if group {
+			// в группе карточку видят все, кнопки организатора уходят в личный чат
+			messages = append(messages, eventMessages(chatID, event, text, h.groupEventButtons(event.ID))...)
+			if !isOrganizer {
+				continue
+			}
+		}
+
+		card, err := h.personalCard(ctx, userID, event, text, isAdmin, isOrganizer)
+		if err != nil {
+			log.Printf("%s: %v", op, err)
+			continue
+		}
+
+		if group {
+			organizer = append(organizer, card...)
+		} else {
+			messages = append(messages, card...)
+		}
 	}
 
 	// Отправляем основное сообщение с инструкцией
@@ -94,38 +112,150 @@ func (h *Handler) listEvents(ctx context.Context, update *tgbotapi.Update) error
 		}
 	}
 
+	if len(organizer) > 0 {
+		h.sendOrganizerCards(update.Message.Chat, userID, organizer)
+	}
+
 	return nil
 }
 
+// personalCard карточка события для личного чата с userID: со статусом его
+// регистрации, а для организатора — со сводкой отзывов и кнопками управления.
+func (h *Handler) personalCard(
+	ctx context.Context,
+	userID int64,
+	event domain.Event,
+	text string,
+	isAdmin bool,
+	isOrganizer bool,
+) ([]tgbotapi.Chattable, error) {
+	status, err := h.registrationUC.Status(ctx, event.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration status: %w", err)
+	}
+
+	if isOrganizer {
+		text += h.feedbackLine(ctx, event.ID)
+	}
+	buttons := h.createEventButtons(event.ID, status, isAdmin, isOrganizer)
+
+	return eventMessages(userID, event, text, buttons), nil
+}
+
+// sendOrganizerCards отправляет карточки с кнопками управления событиями
+// группы в личный чат организатора. Если он ещё не писал боту, Telegram
+// не даст отправить сообщение, и в группе появляется подсказка.
+func (h *Handler) sendOrganizerCards(group *tgbotapi.Chat, userID int64, cards []tgbotapi.Chattable) {
+	header := tgbotapi.NewMessage(userID, fmt.Sprintf("🛠 Управление событиями чата «%s»", group.Title))
+	if _, err := h.bot.Send(header); err != nil {
+		h.sendMsg(group.ID, EmPin, "Кнопки управления событиями приходят в личные сообщения: "+
+			"напишите боту */start*, затем повторите */list_events* в группе")
+		return
+	}
+
+	for _, card := range cards {
+		if _, err := h.bot.Send(card); err != nil {
+			log.Printf("failed to send organizer card: %v", err)
+		}
+	}
+}
+
+// eventMessages формирует карточку события: фото с подписью, если есть обложка,
+// документ с подписью, если есть только вложение, иначе текстовое сообщение.
+// Документ при наличии обложки отправляется следом отдельным сообщением.
+func eventMessages(chatID int64, event domain.Event, text string, markup tgbotapi.InlineKeyboardMarkup) []tgbotapi.Chattable {
+	switch {
+	case event.PhotoFileID != "":
+		photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(event.PhotoFileID))
+		photo.Caption = text
+		photo.ParseMode = tgbotapi.ModeMarkdownV2
+		photo.ReplyMarkup = markup
+
+		messages := []tgbotapi.Chattable{photo}
+		if event.DocumentFileID != "" {
+			messages = append(messages, tgbotapi.NewDocument(chatID, tgbotapi.FileID(event.DocumentFileID)))
+		}
+
+		return messages
+
+	case event.DocumentFileID != "":
+		doc := tgbotapi.NewDocument(chatID, tgbotapi.FileID(event.DocumentFileID))
+		doc.Caption = text
+		doc.ParseMode = tgbotapi.ModeMarkdownV2
+		doc.ReplyMarkup = markup
+
+		return []tgbotapi.Chattable{doc}
+
+	default:
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = tgbotapi.ModeMarkdownV2
+		msg.ReplyMarkup = markup
+
+		return []tgbotapi.Chattable{msg}
+	}
+}
+
+// hasMedia карточка такого события — подпись к медиа, а не текст.
+func hasMedia(event domain.Event) bool {
+	return event.PhotoFileID != "" || event.DocumentFileID != ""
+}
+
 // createEventButtons ...
-func createEventButtons(eventID int64, isRegistered bool, isAdmin bool) tgbotapi.InlineKeyboardMarkup {
+func (h *Handler) createEventButtons(
+	eventID int64,
+	status domain.RegistrationStatus,
+	isAdmin bool,
+	isOrganizer bool,
+) tgbotapi.InlineKeyboardMarkup {
 	row := []tgbotapi.InlineKeyboardButton{
-		createRegButton(eventID, isRegistered),
-		tgbotapi.NewInlineKeyboardButtonData(
-			fmt.Sprintf("%s %s", EmPeople, "Участники"),
-			fmt.Sprintf("participants:%d", eventID),
-		),
+		h.createRegButton(eventID, status),
+		h.callbacks.PermanentButton(fmt.Sprintf("%s %s", EmPeople, "Участники"), actParticipants, eventID),
 	}
 
 	if isAdmin {
-		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
-			fmt.Sprintf("%s %s", EmCross, "Удалить"),
-			fmt.Sprintf("delete_confirm:%d", eventID),
+		row = append(row, h.callbacks.PermanentButton(fmt.Sprintf("%s %s", EmCross, "Удалить"), actDeleteAsk, eventID))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{row, h.createCopyRow(eventID)}
+
+	if isOrganizer {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.PermanentButton("📣 Написать участникам", actBroadcast, eventID),
+		), tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.PermanentButton("🔔 Уведомления", actNotifyMenu, eventID),
+			h.callbacks.PermanentButton("🔐 Заявки", actApprovalMenu, eventID),
+		), tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.PermanentButton("📊 Отзывы", actFeedbackSummary, eventID),
+			h.callbacks.PermanentButton("⬇️ Экспорт", actExport, eventID),
 		))
 	}
 
-	return tgbotapi.NewInlineKeyboardMarkup(row)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// groupEventButtons кнопки карточки в группе: её видят все участники,
+// поэтому личного статуса регистрации и кнопок организатора в ней нет.
+func (h *Handler) groupEventButtons(eventID int64) tgbotapi.InlineKeyboardMarkup {
+	return h.createEventButtons(eventID, domain.RegistrationNone, false, false)
+}
+
+// createCopyRow ...
+func (h *Handler) createCopyRow(eventID int64) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		h.callbacks.PermanentButton("📄 Копировать", actClone, eventID),
+		h.callbacks.PermanentButton("🗂 В шаблоны", actTemplateSave, eventID),
+	)
 }
 
 // createRegButton ...
-func createRegButton(eventID int64, isRegistered bool) tgbotapi.InlineKeyboardButton {
+func (h *Handler) createRegButton(eventID int64, status domain.RegistrationStatus) tgbotapi.InlineKeyboardButton {
 	text, icon := "Регистрация", EmReg
-	if isRegistered {
+	switch status {
+	case domain.RegistrationApproved:
 		text, icon = "Зарегистрирован", EmOk
+	case domain.RegistrationPending:
+		text, icon = "Заявка отправлена", "⏳"
 	}
 
-	return tgbotapi.NewInlineKeyboardButtonData(
-		fmt.Sprintf("%s %s", icon, text),
-		fmt.Sprintf("register:%d", eventID),
-	)
+	return h.callbacks.PermanentButton(fmt.Sprintf("%s %s", icon, text), actRegister, eventID)
 }
@@ -0,0 +1,141 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func TestListEventsInGroup(t *testing.T) {
+	const (
+		author int64 = 10
+		admin  int64 = 11
+		member int64 = 12
+	)
+
+	group := &tgbotapi.Chat{ID: -42, Type: "supergroup", Title: "Клуб"}
+
+	tests := []struct {
+		name    string
+		user    int64
+		blocked bool
+		// wantPrivate кнопки карточки в личном чате; пустой — карточки нет.
+		wantPrivate []string
+		wantHint    bool
+	}{
+		{name: "member", user: member},
+		{name: "author", user: author, wantPrivate: []string{"Написать участникам", "Отзывы"}},
+		{name: "group admin", user: admin, wantPrivate: []string{"Написать участникам", "Удалить"}},
+		{name: "author never wrote to bot", user: author, blocked: true, wantHint: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{
+				admins:  map[int64][]int64{group.ID: {admin}},
+				blocked: map[int64]bool{tt.user: tt.blocked},
+			}
+			h := newTestHandler(t, api)
+			ctx := context.Background()
+
+			// пользователей записывает handleUpdate при каждом сообщении
+			for _, id := range []int64{author, tt.user} {
+				if err := h.userUC.CreateOrUpdate(ctx, &domain.User{ID: id, UserName: "user"}); err != nil {
+					t.Fatalf("CreateOrUpdate: %v", err)
+				}
+			}
+
+			event := domain.Event{UserID: author, ChatID: group.ID, Title: "Митап", Date: time.Now().Add(24 * time.Hour)}
+			if _, err := h.eventUC.CreateEvent(ctx, author, event); err != nil {
+				t.Fatalf("CreateEvent: %v", err)
+			}
+			if _, err := h.registrationUC.ToggleRegistration(ctx, 1, &domain.User{ID: tt.user}); err != nil {
+				t.Fatalf("ToggleRegistration: %v", err)
+			}
+
+			if err := h.listEvents(ctx, command(group, tt.user, "/list_events")); err != nil {
+				t.Fatalf("listEvents: %v", err)
+			}
+
+			// карточку в группе видят все: ни личного статуса, ни кнопок организатора
+			var card, hint bool
+			for _, c := range api.sent(group.ID) {
+				if strings.Contains(c.params["text"], "личные сообщения") {
+					hint = true
+				}
+				markup := c.params["reply_markup"]
+				if markup == "" {
+					continue
+				}
+				card = true
+				for _, private := range []string{"Зарегистрирован", "Написать участникам", "Удалить", "Отзывы"} {
+					if strings.Contains(markup, private) {
+						t.Errorf("group card has %q: %s", private, markup)
+					}
+				}
+			}
+			if !card {
+				t.Error("no event card in group")
+			}
+			if hint != tt.wantHint {
+				t.Errorf("hint in group = %v, want %v", hint, tt.wantHint)
+			}
+
+			var markup string
+			for _, c := range api.sent(tt.user) {
+				markup += c.params["reply_markup"]
+			}
+			if tt.blocked || len(tt.wantPrivate) == 0 {
+				if markup != "" {
+					t.Errorf("private card sent: %s", markup)
+				}
+				return
+			}
+			for _, want := range append(tt.wantPrivate, "Зарегистрирован") {
+				if !strings.Contains(markup, want) {
+					t.Errorf("private card has no %q: %s", want, markup)
+				}
+			}
+		})
+	}
+}
+
+func TestCanModerateEvent(t *testing.T) {
+	const (
+		admin    int64 = 11
+		stranger int64 = 12
+	)
+
+	group := &tgbotapi.Chat{ID: -42, Type: "supergroup"}
+	other := &tgbotapi.Chat{ID: -43, Type: "group"}
+
+	tests := []struct {
+		name  string
+		chat  *tgbotapi.Chat
+		user  int64
+		event domain.Event
+		want  bool
+	}{
+		{"admin in group", group, admin, domain.Event{ChatID: group.ID}, true},
+		{"admin in private chat", &tgbotapi.Chat{ID: admin, Type: "private"}, admin, domain.Event{ChatID: group.ID}, true},
+		{"admin from other group", other, admin, domain.Event{ChatID: group.ID}, false},
+		{"stranger in private chat", &tgbotapi.Chat{ID: stranger, Type: "private"}, stranger, domain.Event{ChatID: group.ID}, false},
+		{"global event", &tgbotapi.Chat{ID: admin, Type: "private"}, admin, domain.Event{ChatID: domain.GlobalSpace}, false},
+	}
+
+	api := &fakeAPI{admins: map[int64][]int64{group.ID: {admin}, other.ID: {admin}}}
+	h := newTestHandler(t, api)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.canModerateEvent(tt.chat, tt.user, &tt.event); got != tt.want {
+				t.Errorf("canModerateEvent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
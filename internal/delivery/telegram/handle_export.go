@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/export"
+)
+
+// Форматы выгрузки, последний аргумент actExportFile.
+const (
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+)
+
+// exportTimeLayout формат дат в выгрузке: его понимают табличные редакторы.
+const exportTimeLayout = "02.01.2006 15:04"
+
+// handleExport предлагает организатору выбрать формат выгрузки участников.
+// Список содержит личные данные, поэтому и выбор, и файл приходят в личные сообщения.
+func (h *Handler) handleExport(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	userID := query.From.ID
+
+	if !h.isOrganizer(query.Message.Chat, userID, event) {
+		h.sendCallback(query.ID, EmCross, "Доступ запрещен")
+		return nil
+	}
+
+	// в кнопки зашит ID организатора: пересланное меню чужому не поможет
+	msg := tgbotapi.NewMessage(userID, fmt.Sprintf("⬇️ Выгрузка участников «%s»\n\nВыберите формат файла:", event.Title))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		h.callbacks.Button("📄 CSV", actExportFile, eventID, userID, formatCSV),
+		h.callbacks.Button("📊 Excel (XLSX)", actExportFile, eventID, userID, formatXLSX),
+	))
+
+	if _, err := h.bot.Send(msg); err != nil {
+		h.sendCallback(query.ID, EmCross, "Напишите боту в личные сообщения и нажмите кнопку ещё раз")
+		return nil
+	}
+
+	if query.Message.Chat.ID != userID {
+		h.sendCallback(query.ID, "⬇️", "Выберите формат в личных сообщениях с ботом")
+	}
+
+	return nil
+}
+
+// handleExportFile отправляет файл со списком участников.
+func (h *Handler) handleExportFile(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	userID, err := data.Int64(1)
+	if err != nil {
+		return fmt.Errorf("failed to parse user ID: %w", err)
+	}
+
+	format, err := data.String(2)
+	if err != nil {
+		return fmt.Errorf("failed to parse export format: %w", err)
+	}
+
+	if userID != query.From.ID {
+		h.sendCallback(query.ID, EmCross, "Доступ запрещен")
+		return nil
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	table, count, err := h.participantsTable(ctx, eventID)
+	if err != nil {
+		h.sendError(query.Message.Chat.ID, "Ошибка выгрузки участников")
+		return err
+	}
+
+	var file bytes.Buffer
+	switch format {
+	case formatCSV:
+		err = export.WriteCSV(&file, table)
+	case formatXLSX:
+		err = export.WriteXLSX(&file, table)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+	if err != nil {
+		h.sendError(query.Message.Chat.ID, "Ошибка выгрузки участников")
+		return fmt.Errorf("failed to export participants: %w", err)
+	}
+
+	doc := tgbotapi.NewDocument(query.Message.Chat.ID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("participants_%d.%s", eventID, format),
+		Bytes: file.Bytes(),
+	})
+	doc.Caption = fmt.Sprintf("%s Участники «%s»: %d", EmPeople, event.Title, count)
+
+	_, err = h.bot.Send(doc)
+
+	return err
+}
+
+// participantsTable таблица участников и заявок события с ответами на вопросы
+// и количество строк в ней.
+func (h *Handler) participantsTable(ctx context.Context, eventID int64) (export.Table, int, error) {
+	participants, err := h.registrationUC.GetParticipants(ctx, eventID)
+	if err != nil {
+		return export.Table{}, 0, fmt.Errorf("failed to get list of participants: %w", err)
+	}
+
+	pending, err := h.registrationUC.GetPending(ctx, eventID)
+	if err != nil {
+		return export.Table{}, 0, fmt.Errorf("failed to get pending registrations: %w", err)
+	}
+
+	questions, err := h.eventUC.Questions(ctx, eventID)
+	if err != nil {
+		return export.Table{}, 0, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	table := export.Table{
+		Header: []string{"ID", "Имя", "Username", "Регистрация", "Статус", "Отметка на входе"},
+	}
+	for _, q := range questions {
+		table.Header = append(table.Header, q.Text)
+	}
+
+	for _, p := range append(participants, pending...) {
+		username := ""
+		if p.UserName != "" {
+			username = "@" + p.UserName
+		}
+
+		checkedIn := ""
+		if !p.CheckedInAt.IsZero() {
+			checkedIn = p.CheckedInAt.In(h.loc).Format(exportTimeLayout)
+		}
+
+		row := []string{
+			fmt.Sprint(p.ID),
+			p.FirstName,
+			username,
+			p.RegisteredAt.In(h.loc).Format(exportTimeLayout),
+			statusText(p.Status),
+			checkedIn,
+		}
+
+		answers := make(map[int64]string, len(p.Answers))
+		for _, a := range p.Answers {
+			answers[a.QuestionID] = a.Value
+		}
+		for _, q := range questions {
+			row = append(row, answers[q.ID])
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, len(table.Rows), nil
+}
+
+// statusText ...
+func statusText(status domain.RegistrationStatus) string {
+	if status == domain.RegistrationPending {
+		return "ожидает подтверждения"
+	}
+
+	return "подтверждена"
+}
@@ -0,0 +1,268 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+	"github.com/binaryty/evbot/internal/util"
+)
+
+const (
+	// maxCommentLen ограничение длины комментария к оценке, в символах.
+	maxCommentLen = 1000
+	// summaryCommentsLimit сколько последних комментариев показывать в сводке.
+	summaryCommentsLimit = 20
+)
+
+// SendSurveys рассылает участникам прошедших событий просьбу оценить событие.
+// Опрос уходит через cfg.FeedbackDelay после начала события, один раз;
+// события, срок опроса которых прошёл больше cfg.FeedbackWindow назад, пропускаются.
+func (h *Handler) SendSurveys(ctx context.Context) error {
+	now := time.Now().In(h.loc)
+	// даты событий хранятся как время на часах пользователей
+	before := wallClock(now, now.Hour(), now.Minute()).Add(-h.cfg.FeedbackDelay)
+
+	events, err := h.feedbackUC.DueSurveys(ctx, before.Add(-h.cfg.FeedbackWindow), before)
+	if err != nil {
+		return fmt.Errorf("failed to get due surveys: %w", err)
+	}
+
+	for _, event := range events {
+		// отмечаем заранее: лучше потерять опрос при сбое, чем прислать его дважды
+		if err := h.feedbackUC.MarkSurveySent(ctx, event.ID); err != nil {
+			return fmt.Errorf("failed to mark survey sent: %w", err)
+		}
+
+		participants, err := h.registrationUC.GetParticipants(ctx, event.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get list of participants: %w", err)
+		}
+
+		for _, p := range participants {
+			if p.ID == event.UserID {
+				continue
+			}
+
+			msg := tgbotapi.NewMessage(p.ID, surveyText(event.Title))
+			msg.ReplyMarkup = h.createRatingButtons(event.ID, 0)
+
+			if _, err := h.bot.Send(msg); err != nil && !isBlocked(err) {
+				h.logger.Error("failed to send survey",
+					slog.Int64("event_id", event.ID),
+					slog.Int64("user_id", p.ID),
+					slog.String("[ERROR]", err.Error()))
+			}
+
+			time.Sleep(broadcastPause)
+		}
+	}
+
+	return nil
+}
+
+// handleFeedbackRate сохраняет оценку и предлагает оставить комментарий.
+func (h *Handler) handleFeedbackRate(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	rating, err := data.Int(1)
+	if err != nil {
+		return fmt.Errorf("failed to parse rating: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	user := domain.User{
+		ID:        query.From.ID,
+		FirstName: query.From.FirstName,
+		UserName:  query.From.UserName,
+	}
+
+	if err := h.feedbackUC.Rate(ctx, eventID, user, rating); err != nil {
+		if errors.Is(err, domain.ErrRegistrationNotFound) || errors.Is(err, domain.ErrRegistrationPending) {
+			h.sendCallback(query.ID, EmCross, "Оценить событие могут только его участники")
+			return nil
+		}
+		return fmt.Errorf("failed to save rating: %w", err)
+	}
+
+	h.bot.Send(tgbotapi.NewCallback(query.ID, "Спасибо за оценку!"))
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(
+		query.Message.Chat.ID,
+		query.Message.MessageID,
+		fmt.Sprintf("%s\n\nВаша оценка: %s", surveyText(event.Title), stars(rating)),
+		h.createRatingButtons(eventID, rating),
+	)
+	h.bot.Send(edit)
+
+	return nil
+}
+
+// handleFeedbackComment ждёт комментарий к поставленной оценке.
+func (h *Handler) handleFeedbackComment(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	if err := h.saveDialog(ctx, query.From.ID, domain.FlowFeedback, stepFeedbackComment, feedbackPayload{EventID: eventID}); err != nil {
+		return err
+	}
+
+	h.bot.Send(tgbotapi.NewMessage(query.Message.Chat.ID,
+		"💬 Напишите комментарий одним сообщением. Его увидит автор события.\n/cancel — оставить только оценку"))
+
+	return nil
+}
+
+// handleFeedbackInput сохраняет комментарий к оценке.
+func (h *Handler) handleFeedbackInput(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+	// пустой и слишком длинный комментарий отсеяны валидатором шага
+	text := strings.TrimSpace(msg.Text)
+
+	payload, err := decodePayload[feedbackPayload](state)
+	if err != nil {
+		return err
+	}
+
+	if err := h.fireDialog(ctx, msg.From.ID, state, fsm.Done, nil); err != nil {
+		return err
+	}
+
+	if err := h.feedbackUC.Comment(ctx, payload.EventID, msg.From.ID, text); err != nil {
+		if errors.Is(err, domain.ErrFeedbackNotFound) {
+			h.sendError(msg.Chat.ID, "Сначала поставьте оценку")
+			return nil
+		}
+		h.sendError(msg.Chat.ID, "Ошибка сохранения комментария")
+		return fmt.Errorf("failed to save comment: %w", err)
+	}
+
+	h.sendMsg(msg.Chat.ID, EmOk, "Спасибо за отзыв!")
+
+	return nil
+}
+
+// handleFeedbackSummary показывает организатору сводку отзывов о событии.
+func (h *Handler) handleFeedbackSummary(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !h.isOrganizer(query.Message.Chat, query.From.ID, event) {
+		h.sendCallback(query.ID, EmCross, "Доступ запрещен")
+		return nil
+	}
+
+	summary, err := h.feedbackUC.Summary(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get feedback summary: %w", err)
+	}
+
+	if summary.Count == 0 {
+		h.sendCallback(query.ID, "⭐", "Отзывов пока нет: опрос приходит участникам после события")
+		return nil
+	}
+
+	msg := tgbotapi.NewMessage(query.Message.Chat.ID, feedbackSummaryText(event, summary))
+	msg.ReplyToMessageID = query.Message.MessageID
+	h.bot.Send(msg)
+
+	return nil
+}
+
+// createRatingButtons оценки от 1 до 5; после оценки появляется кнопка комментария.
+func (h *Handler) createRatingButtons(eventID int64, rating int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for r := domain.MinRating; r <= domain.MaxRating; r++ {
+		label := fmt.Sprintf("%d ⭐", r)
+		if r == rating {
+			label = fmt.Sprintf("%s %d", EmOk, r)
+		}
+		row = append(row, h.callbacks.PermanentButton(label, actFeedbackRate, eventID, r))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{row}
+	if rating > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.PermanentButton("💬 Оставить комментарий", actFeedbackComment, eventID),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// surveyText ...
+func surveyText(title string) string {
+	return fmt.Sprintf("⭐ Как прошло событие «%s»? Оцените его от 1 до 5", title)
+}
+
+// feedbackSummaryText средняя оценка, распределение и последние комментарии.
+func feedbackSummaryText(event *domain.Event, summary *domain.FeedbackSummary) string {
+	var text strings.Builder
+
+	text.WriteString(fmt.Sprintf("📊 Отзывы о «%s»\n", event.Title))
+	text.WriteString(fmt.Sprintf("Оценок: %d, средняя: %.1f ⭐\n\n", summary.Count, summary.Average))
+
+	for r := domain.MaxRating; r >= domain.MinRating; r-- {
+		n := summary.Distribution[r-1]
+		text.WriteString(fmt.Sprintf("%d ⭐ %s %d\n", r, strings.Repeat("█", n*10/summary.Count), n))
+	}
+
+	if len(summary.Comments) > 0 {
+		text.WriteString("\n💬 Комментарии:\n")
+	}
+
+	for i, f := range summary.Comments {
+		if i == summaryCommentsLimit || text.Len() > 3000 {
+			text.WriteString(fmt.Sprintf("… и ещё %d", len(summary.Comments)-i))
+			break
+		}
+		text.WriteString(fmt.Sprintf("• %s, %s: %s\n", userLabel(f.User), stars(f.Rating), f.Comment))
+	}
+
+	return text.String()
+}
+
+// stars оценка звёздами: ⭐⭐⭐☆☆.
+func stars(rating int) string {
+	return strings.Repeat("⭐", rating) + strings.Repeat("☆", domain.MaxRating-rating)
+}
+
+// feedbackLine строка карточки события со средней оценкой, если отзывы есть.
+func (h *Handler) feedbackLine(ctx context.Context, eventID int64) string {
+	summary, err := h.feedbackUC.Summary(ctx, eventID)
+	if err != nil {
+		h.logger.Error("failed to get feedback summary", slog.String("[ERROR]", err.Error()))
+		return ""
+	}
+
+	if summary.Count == 0 {
+		return ""
+	}
+
+	return util.EscapeMarkdownV2(fmt.Sprintf("\n⭐ Отзывы: %.1f из 5 (%d)", summary.Average, summary.Count))
+}
@@ -8,7 +8,14 @@ func (h *Handler) handleHelpCommand(update *tgbotapi.Update) error {
 	helpText := `📖 *Справка по командам*
 
 */new_event* - начать создание нового события
+*/drafts* - черновики событий: продолжить, переименовать или удалить
+*/templates* - шаблоны событий: создать событие по шаблону
 */list_events* - показать список всех событий с кнопками управления
+*/checkin* - отмечать пришедших участников по билетам
+*/set_channel* - привязать канал для анонсов событий
+*/import* - импорт событий из файла .ics или .csv (администраторам)
+*/backup* - резервная копия базы (администраторам бота)
+*/back* - вернуться на предыдущий шаг создания события
 */cancel* - отменить текущую операцию
 */help* - показать эту справку
 
@@ -17,7 +24,28 @@ func (h *Handler) handleHelpCommand(update *tgbotapi.Update) error {
 2. В списке событий (*/list_events*) вы можете:
    - 🎫 Зарегистрироваться на событие
    - 👥 Посмотреть список участников
-3. Управляйте регистрациями через интерактивные кнопки`
+   - 📄 Скопировать событие или 🗂 сохранить его как шаблон
+   - 📣 Написать участникам (автору события и администраторам)
+   - 🔐 Включить подтверждение заявок: автор принимает или отклоняет каждую регистрацию (автору события)
+   - 🔔 Настроить уведомления о регистрациях: сразу или сводкой раз в день (автору события)
+   - ⬇️ Выгрузить участников в CSV или Excel с ответами на вопросы и отметками на входе (автору события и администраторам)
+   - 📊 Посмотреть отзывы: после события участники получают опрос с оценкой от 1 до 5
+3. Управляйте регистрациями через интерактивные кнопки
+
+*Билеты:*
+После регистрации бот присылает в личные сообщения билет с QR-кодом. На входе организатор сканирует QR-код камерой телефона или включает */checkin* и отправляет боту фото QR-кода, код билета текстом либо пересланное сообщение с билетом. Отметки видны в списке участников.
+
+*Вопросы участникам:*
+В предпросмотре события нажмите «❓ Вопросы участникам», чтобы спросить размер футболки, питание или название команды. Перед регистрацией участник отвечает на них в личных сообщениях с ботом, а автор события видит ответы в списке участников.
+
+*Дата и время:*
+Вместо календаря можно написать дату текстом: «завтра в 19:00», «в пятницу», «через 2 часа», «25.12 18:30», «tomorrow at 7pm».
+
+*Группы:*
+События, созданные в группе, видны только в этой группе. Администраторы группы могут удалять её события.
+
+*Каналы:*
+Привяжите канал командой */set_channel @канал* — новые события будут публиковаться в нём с кнопкой регистрации, а анонс будет обновляться при изменениях.`
 
 	msg := tgbotapi.NewMessage(update.Message.Chat.ID, helpText)
 	msg.ParseMode = "Markdown"
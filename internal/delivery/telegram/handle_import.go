@@ -0,0 +1,281 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/eventimport"
+	"github.com/binaryty/evbot/internal/fsm"
+)
+
+const (
+	// maxImportSize предел размера файла импорта.
+	maxImportSize = 1 << 20
+	// importPreviewLimit сколько событий показывать в предпросмотре импорта.
+	importPreviewLimit = 30
+)
+
+// Команды подтверждения импорта, первый аргумент actImport.
+const (
+	importCreate = "create"
+	importCancel = "cancel"
+)
+
+// handleImportCommand ждёт от модератора файл с событиями для пространства чата.
+func (h *Handler) handleImportCommand(ctx context.Context, update *tgbotapi.Update) error {
+	msg := update.Message
+
+	if !h.canModerate(msg.Chat, msg.From.ID) {
+		h.sendError(msg.Chat.ID, "Импорт событий доступен только администраторам")
+		return nil
+	}
+
+	payload := importPayload{ChatID: spaceID(msg.Chat)}
+	if err := h.saveDialog(ctx, msg.From.ID, domain.FlowImport, stepImportFile, payload); err != nil {
+		return err
+	}
+
+	h.bot.Send(tgbotapi.NewMessage(msg.Chat.ID, "📥 Отправьте файл с событиями документом:\n\n"+
+		"• .ics — календарь из Google Calendar, Outlook или Apple Calendar\n"+
+		"• .csv — таблица со столбцами «Название», «Дата», «Время» и «Описание» "+
+		"(или title, date, time, description); дата в формате 25.12.2026 или 2026-12-25\n\n"+
+		"Перед созданием покажу список событий.\n/cancel — отменить импорт"))
+
+	return nil
+}
+
+// handleImportInput разбирает присланный файл и показывает предпросмотр импорта.
+func (h *Handler) handleImportInput(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+	doc := msg.Document
+
+	if doc == nil || !eventimport.Supported(doc.FileName) {
+		h.sendError(msg.Chat.ID, "Отправьте файл .ics или .csv документом")
+		return nil
+	}
+
+	if doc.FileSize > maxImportSize {
+		h.sendError(msg.Chat.ID, "Файл слишком большой (макс. 1 МБ)")
+		return nil
+	}
+
+	payload, err := decodePayload[importPayload](state)
+	if err != nil {
+		return err
+	}
+
+	events, err := h.downloadEvents(ctx, doc)
+	if err != nil {
+		if reason := importErrorText(err); reason != "" {
+			h.sendError(msg.Chat.ID, reason)
+			return nil
+		}
+		h.sendError(msg.Chat.ID, "Не удалось прочитать файл")
+		return err
+	}
+
+	for i := range events {
+		events[i].ChatID = payload.ChatID
+
+		if err := validateImported(events[i]); err != nil {
+			var verr *fsm.ValidationError
+			if errors.As(err, &verr) {
+				h.sendError(msg.Chat.ID, fmt.Sprintf("Событие %d: %s", i+1, verr.Reason))
+				return nil
+			}
+			return err
+		}
+	}
+
+	duplicates, err := h.eventUC.Duplicates(ctx, payload.ChatID, events)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicates: %w", err)
+	}
+
+	fresh := 0
+	for _, d := range duplicates {
+		if !d {
+			fresh++
+		}
+	}
+
+	if fresh == 0 {
+		h.sendMsg(msg.Chat.ID, EmOk, "Все события из файла уже есть, создавать нечего")
+		return h.fireDialog(ctx, msg.From.ID, state, fsm.Cancel, nil)
+	}
+
+	payload.Events = events
+	if err := h.fireDialog(ctx, msg.From.ID, state, fsm.Next, payload); err != nil {
+		return err
+	}
+
+	preview := tgbotapi.NewMessage(msg.Chat.ID, importPreviewText(events, duplicates, fresh))
+	preview.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		h.callbacks.Button(fmt.Sprintf("%s Создать (%d)", EmOk, fresh), actImport, importCreate),
+		h.callbacks.Button(EmCross+" Отмена", actImport, importCancel),
+	))
+	h.bot.Send(preview)
+
+	return nil
+}
+
+// handleImportCallback создаёт события из файла или отменяет импорт.
+func (h *Handler) handleImportCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	command, err := data.String(0)
+	if err != nil {
+		return fmt.Errorf("invalid import callback: %w", err)
+	}
+
+	state, err := h.loadCallbackState(ctx, query)
+	if err != nil {
+		return ignoreStateGone(err)
+	}
+
+	if state.Flow != domain.FlowImport || state.Step != string(stepImportPreview) {
+		return nil
+	}
+
+	payload, err := decodePayload[importPayload](*state)
+	if err != nil {
+		return err
+	}
+
+	userID := query.From.ID
+	chatID := query.Message.Chat.ID
+
+	if command == importCancel {
+		if err := h.fireDialog(ctx, userID, *state, fsm.Cancel, nil); err != nil {
+			return err
+		}
+		h.bot.Send(tgbotapi.NewEditMessageText(chatID, query.Message.MessageID, "📥 Импорт отменён"))
+
+		return nil
+	}
+
+	// за время предпросмотра такие же события могли создать вручную
+	duplicates, err := h.eventUC.Duplicates(ctx, payload.ChatID, payload.Events)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicates: %w", err)
+	}
+
+	var events []domain.Event
+	for i, e := range payload.Events {
+		if !duplicates[i] {
+			events = append(events, e)
+		}
+	}
+
+	ids, err := h.eventUC.ImportEvents(ctx, userID, events)
+	if err != nil {
+		h.sendError(chatID, "Ошибка импорта: ни одно событие не создано")
+		return fmt.Errorf("failed to import events: %w", err)
+	}
+
+	if err := h.fireDialog(ctx, userID, *state, fsm.Done, nil); err != nil {
+		return err
+	}
+
+	h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+	h.bot.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("🎉 Создано событий: %d. Они уже в /list_events", len(ids))))
+
+	return nil
+}
+
+// downloadEvents скачивает файл из Telegram и разбирает события.
+func (h *Handler) downloadEvents(ctx context.Context, doc *tgbotapi.Document) ([]domain.Event, error) {
+	body, err := h.openFile(ctx, doc.FileID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return eventimport.Parse(doc.FileName, io.LimitReader(body, maxImportSize), h.loc)
+}
+
+// openFile открывает на чтение файл, присланный боту.
+func (h *Handler) openFile(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	url, err := h.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := h.bot.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// validateImported применяет к событию из файла те же правила, что и мастер.
+func validateImported(event domain.Event) error {
+	if err := validateTitle(event.Title); err != nil {
+		return err
+	}
+
+	return validateDescription(event.Description)
+}
+
+// importErrorText объяснение ошибки в файле; пусто — ошибка не в файле.
+func importErrorText(err error) string {
+	var lineErr *eventimport.LineError
+	if errors.As(err, &lineErr) {
+		if errors.Is(err, eventimport.ErrInvalidDate) {
+			return fmt.Sprintf("Строка %d: не удалось распознать дату или время", lineErr.Line)
+		}
+		return fmt.Sprintf("Строка %d: ошибка формата файла", lineErr.Line)
+	}
+
+	switch {
+	case errors.Is(err, eventimport.ErrNoEvents):
+		return "В файле нет событий"
+	case errors.Is(err, eventimport.ErrTooManyEvents):
+		return fmt.Sprintf("Слишком много событий (макс. %d за раз)", eventimport.MaxEvents)
+	case errors.Is(err, eventimport.ErrMissingColumn):
+		return "В таблице нужны столбцы «Название» и «Дата» (или title и date)"
+	case errors.Is(err, eventimport.ErrUnsupportedFormat):
+		return "Поддерживаются только файлы .ics и .csv"
+	}
+
+	return ""
+}
+
+// importPreviewText ...
+func importPreviewText(events []domain.Event, duplicates []bool, fresh int) string {
+	var text strings.Builder
+
+	text.WriteString(fmt.Sprintf("📥 Событий в файле: %d, будет создано: %d\n\n", len(events), fresh))
+
+	for i, e := range events {
+		if i == importPreviewLimit {
+			text.WriteString(fmt.Sprintf("… и ещё %d\n", len(events)-i))
+			break
+		}
+
+		text.WriteString(fmt.Sprintf("%d. %s — %s", i+1, e.Date.Format("02.01.2006 15:04"), e.Title))
+		if duplicates[i] {
+			text.WriteString(" ⚠️ уже есть, пропускаю")
+		}
+		text.WriteString("\n")
+	}
+
+	return text.String()
+}
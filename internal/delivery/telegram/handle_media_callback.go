@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"context"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+)
+
+// handleMediaCallback ...
+func (h *Handler) handleMediaCallback(ctx context.Context, query *tgbotapi.CallbackQuery, _ callback.Data) error {
+	state, err := h.loadCallbackState(ctx, query)
+	if err != nil {
+		return ignoreStateGone(err)
+	}
+
+	if state.Step != domain.StepMedia {
+		return nil
+	}
+
+	// убираем кнопку «Пропустить»
+	h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(
+		query.Message.Chat.ID,
+		query.Message.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}},
+	))
+
+	return h.advance(ctx, query.From.ID, query.Message.Chat.ID, *state, fsm.Skip)
+}
@@ -0,0 +1,118 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// notifyModes варианты уведомлений в порядке кнопок меню.
+var notifyModes = []struct {
+	mode  domain.NotifyMode
+	label string
+}{
+	{domain.NotifyOff, "🔕 Выкл"},
+	{domain.NotifyInstant, "⚡ Сразу"},
+	{domain.NotifyDigest, "🗓 Раз в день"},
+}
+
+// handleNotifyMenu показывает автору события настройку уведомлений о регистрациях.
+func (h *Handler) handleNotifyMenu(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.UserID != query.From.ID {
+		h.sendCallback(query.ID, EmCross, "Уведомления настраивает автор события")
+		return nil
+	}
+
+	msg := tgbotapi.NewMessage(query.Message.Chat.ID, notifyMenuText(event))
+	msg.ReplyMarkup = h.createNotifyButtons(event)
+	h.bot.Send(msg)
+
+	return nil
+}
+
+// handleNotifySet сохраняет выбранный режим уведомлений и обновляет меню.
+func (h *Handler) handleNotifySet(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	mode, err := data.String(1)
+	if err != nil {
+		return fmt.Errorf("failed to parse notify mode: %w", err)
+	}
+
+	if err := h.eventUC.SetNotifyMode(ctx, query.From.ID, eventID, domain.NotifyMode(mode)); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAccessDenied):
+			h.sendCallback(query.ID, EmCross, "Уведомления настраивает автор события")
+			return nil
+		case errors.Is(err, domain.ErrEventNotFound):
+			h.sendCallback(query.ID, EmCross, "Событие не найдено")
+			return nil
+		}
+		return fmt.Errorf("failed to set notify mode: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	h.sendCallback(query.ID, EmOk, "Настройка сохранена")
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(
+		query.Message.Chat.ID,
+		query.Message.MessageID,
+		notifyMenuText(event),
+		h.createNotifyButtons(event),
+	)
+	h.bot.Send(edit)
+
+	return nil
+}
+
+// createNotifyButtons ...
+func (h *Handler) createNotifyButtons(event *domain.Event) tgbotapi.InlineKeyboardMarkup {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(notifyModes))
+	for _, m := range notifyModes {
+		label := m.label
+		if m.mode == currentNotifyMode(event) {
+			label = EmOk + " " + label
+		}
+		row = append(row, h.callbacks.Button(label, actNotifySet, event.ID, string(m.mode)))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// notifyMenuText ...
+func notifyMenuText(event *domain.Event) string {
+	return fmt.Sprintf("🔔 Уведомления о регистрациях на «%s»\n\n"+
+		"⚡ Сразу — сообщение о каждой регистрации и отмене\n"+
+		"🗓 Раз в день — сводка изменений за сутки", event.Title)
+}
+
+// currentNotifyMode режим события; у событий до появления настройки он пуст.
+func currentNotifyMode(event *domain.Event) domain.NotifyMode {
+	if event.NotifyMode == "" {
+		return domain.NotifyOff
+	}
+
+	return event.NotifyMode
+}
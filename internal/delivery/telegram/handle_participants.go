@@ -5,22 +5,17 @@ import (
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"log"
-	"strconv"
 	"strings"
 
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
 	"github.com/binaryty/evbot/internal/util"
 )
 
-func (h *Handler) handleParticipants(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+func (h *Handler) handleParticipants(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
 	chatID := query.Message.Chat.ID
 
-	parts := strings.Split(query.Data, ":")
-	if len(parts) < 2 {
-		h.sendError(chatID, "Ошибка обработки события")
-		return fmt.Errorf("invalid callback format: %s", query.Data)
-	}
-
-	eventID, err := strconv.ParseInt(parts[1], 10, 64)
+	eventID, err := data.Int64(0)
 	if err != nil {
 		h.sendError(chatID, "Ошибка обработки запроса")
 		return fmt.Errorf("failed to parse event ID: %w", err)
@@ -33,30 +28,95 @@ func (h *Handler) handleParticipants(ctx context.Context, query *tgbotapi.Callba
 		return fmt.Errorf("failed to get list of participants: %w", err)
 	}
 
-	if len(participants) == 0 {
+	pending, err := h.registrationUC.GetPending(ctx, eventID)
+	if err != nil {
+		h.sendError(chatID, "Ошибка получения участников")
+		return fmt.Errorf("failed to get pending registrations: %w", err)
+	}
+
+	if len(participants) == 0 && len(pending) == 0 {
 		callback := tgbotapi.NewCallbackWithAlert(query.ID, "На событие еще никто не зарегистрирован 🙁")
 		h.bot.Send(callback)
 		return err
 	}
 
+	// ответы на вопросы видит только автор события
+	var questions []domain.Question
+	if event, err := h.eventUC.GetEvent(ctx, eventID); err == nil && event.UserID == query.From.ID {
+		if questions, err = h.eventUC.Questions(ctx, eventID); err != nil {
+			return fmt.Errorf("failed to get questions: %w", err)
+		}
+	}
+
+	questionTexts := make(map[int64]string, len(questions))
+	for _, q := range questions {
+		questionTexts[q.ID] = q.Text
+	}
+
 	// Формируем список с экранированием
 	var list strings.Builder
-	list.WriteString("👥 *Участники события:*\n\n")
+	list.WriteString("👥 *Участники события:*\n")
 
+	checkedIn := 0
 	for _, p := range participants {
+		if !p.CheckedInAt.IsZero() {
+			checkedIn++
+		}
+	}
+	if checkedIn > 0 {
+		list.WriteString(fmt.Sprintf("Пришли: %d из %d\n", checkedIn, len(participants)))
+	}
+	list.WriteString("\n")
+
+	if len(participants) == 0 {
+		list.WriteString("Подтверждённых участников пока нет\n")
+	}
+
+	truncated := false
+	writeParticipant := func(mark string, p domain.Participant) {
 		// Экранируем спецсимволы
 		firstName := util.EscapeMarkdownV2(p.FirstName)
 		userName := util.EscapeMarkdownV2(p.UserName)
 
-		list.WriteString(fmt.Sprintf("• %s \\(@%s\\)\n", firstName, userName))
+		list.WriteString(fmt.Sprintf("%s %s \\(@%s\\)\n", mark, firstName, userName))
+
+		for _, a := range p.Answers {
+			if text, ok := questionTexts[a.QuestionID]; ok {
+				list.WriteString(fmt.Sprintf("    ▫️ %s: %s\n",
+					util.EscapeMarkdownV2(text), util.EscapeMarkdownV2(a.Value)))
+			}
+		}
 
 		// проверяем длину сообщения
 		if list.Len() > 3000 {
 			list.WriteString("\n⚠️ Список сокращен из-за ограничений Telegram")
+			truncated = true
+		}
+	}
+
+	for _, p := range participants {
+		mark := "•"
+		if !p.CheckedInAt.IsZero() {
+			mark = EmOk
+		}
+
+		writeParticipant(mark, p)
+		if truncated {
 			break
 		}
 	}
 
+	if len(pending) > 0 && !truncated {
+		list.WriteString(fmt.Sprintf("\n⏳ *Ожидают подтверждения \\(%d\\):*\n", len(pending)))
+
+		for _, p := range pending {
+			writeParticipant("•", p)
+			if truncated {
+				break
+			}
+		}
+	}
+
 	// Отправляем список
 	msg := tgbotapi.NewMessage(chatID, list.String())
 	msg.ParseMode = tgbotapi.ModeMarkdownV2
@@ -0,0 +1,555 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
+)
+
+const (
+	maxQuestions      = 10
+	maxQuestionLen    = 200
+	maxOptions        = 10
+	maxOptionLen      = 50
+	maxTextAnswerLen  = 300
+	questionsHelpText = "❓ Вопросы участникам при регистрации\n\n" +
+		"Отправьте вопрос сообщением. Варианты ответа — отдельными строками: " +
+		"«- вариант» — выбор одного, «+ вариант» — выбор нескольких. " +
+		"Без вариантов участник отвечает текстом.\n\n" +
+		"Например:\n" +
+		"Размер футболки?\n" +
+		"- S\n" +
+		"- M\n" +
+		"- L"
+)
+
+// Команды редактора вопросов, первый аргумент actQuestions.
+const (
+	questionsDone = "done"
+	questionsUndo = "undo"
+)
+
+// Команды ответа на вопрос, первый аргумент actAnswer.
+const (
+	answerPick   = "o"
+	answerToggle = "t"
+	answerDone   = "d"
+	answerCancel = "x"
+)
+
+// parseQuestion разбирает вопрос: первая строка — текст, остальные — варианты.
+func parseQuestion(input string) (domain.Question, error) {
+	var lines []string
+	for _, line := range strings.Split(input, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return domain.Question{}, fsm.Invalid("Вопрос не может быть пустым")
+	}
+
+	q := domain.Question{Kind: domain.QuestionText, Text: lines[0]}
+	if utf8.RuneCountInString(q.Text) > maxQuestionLen {
+		return q, fsm.Invalid(fmt.Sprintf("Слишком длинный вопрос (макс. %d символов)", maxQuestionLen))
+	}
+
+	for _, line := range lines[1:] {
+		kind := domain.QuestionSingle
+		switch line[0] {
+		case '-':
+		case '+':
+			kind = domain.QuestionMulti
+		default:
+			return q, fsm.Invalid("Варианты ответа начинайте с «-» (выбор одного) или «+» (выбор нескольких)")
+		}
+
+		if len(q.Options) > 0 && kind != q.Kind {
+			return q, fsm.Invalid("Все варианты одного вопроса начинаются с одного знака: «-» или «+»")
+		}
+		q.Kind = kind
+
+		option := strings.TrimSpace(line[1:])
+		switch {
+		case option == "":
+			return q, fsm.Invalid("Вариант ответа не может быть пустым")
+		case utf8.RuneCountInString(option) > maxOptionLen:
+			return q, fsm.Invalid(fmt.Sprintf("Слишком длинный вариант (макс. %d символов)", maxOptionLen))
+		case strings.Contains(option, strings.TrimSpace(domain.AnswerSeparator)):
+			return q, fsm.Invalid("Вариант ответа не может содержать «;»")
+		}
+
+		for _, o := range q.Options {
+			if o == option {
+				return q, fsm.Invalid("Варианты ответа повторяются")
+			}
+		}
+
+		q.Options = append(q.Options, option)
+	}
+
+	if len(q.Options) == 1 {
+		return q, fsm.Invalid("Добавьте хотя бы два варианта ответа")
+	}
+	if len(q.Options) > maxOptions {
+		return q, fsm.Invalid(fmt.Sprintf("Слишком много вариантов (макс. %d)", maxOptions))
+	}
+
+	return q, nil
+}
+
+// sendQuestionsPrompt показывает добавленные вопросы и подсказку по формату.
+func (h *Handler) sendQuestionsPrompt(chatID int64, state domain.EventState) error {
+	msg := tgbotapi.NewMessage(chatID, questionsPromptText(state.TempEvent.Questions))
+	msg.ReplyMarkup = h.createQuestionsButtons(state.TempEvent.Questions)
+	h.bot.Send(msg)
+
+	return nil
+}
+
+// handleQuestionInput добавляет вопрос к черновику события.
+func (h *Handler) handleQuestionInput(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
+	chatID := update.Message.Chat.ID
+
+	if len(state.TempEvent.Questions) >= maxQuestions {
+		h.sendError(chatID, fmt.Sprintf("Можно задать не больше %d вопросов", maxQuestions))
+		return nil
+	}
+
+	// ошибки формата уже отсеяны валидатором шага
+	q, _ := parseQuestion(text)
+	state.TempEvent.Questions = append(state.TempEvent.Questions, q)
+
+	if err := h.stateRepo.SaveState(ctx, update.Message.From.ID, state); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	return h.sendQuestionsPrompt(chatID, state)
+}
+
+// handleQuestionsCallback удаляет последний вопрос или завершает редактирование.
+func (h *Handler) handleQuestionsCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	command, err := data.String(0)
+	if err != nil {
+		return fmt.Errorf("invalid questions callback: %w", err)
+	}
+
+	state, err := h.loadCallbackState(ctx, query)
+	if err != nil {
+		return ignoreStateGone(err)
+	}
+
+	if state.Step != domain.StepQuestions {
+		return nil
+	}
+
+	chatID := query.Message.Chat.ID
+
+	switch command {
+	case questionsUndo:
+		if n := len(state.TempEvent.Questions); n > 0 {
+			state.TempEvent.Questions = state.TempEvent.Questions[:n-1]
+		}
+
+		if err := h.stateRepo.SaveState(ctx, query.From.ID, *state); err != nil {
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, query.Message.MessageID,
+			questionsPromptText(state.TempEvent.Questions),
+			h.createQuestionsButtons(state.TempEvent.Questions))
+		h.bot.Send(edit)
+
+		return nil
+
+	case questionsDone:
+		h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
+
+		return h.advance(ctx, query.From.ID, chatID, *state, fsm.Next)
+	}
+
+	return nil
+}
+
+// createQuestionsButtons ...
+func (h *Handler) createQuestionsButtons(questions []domain.Question) tgbotapi.InlineKeyboardMarkup {
+	row := tgbotapi.NewInlineKeyboardRow(h.callbacks.Button(EmOk+" Готово", actQuestions, questionsDone))
+	if len(questions) > 0 {
+		row = append([]tgbotapi.InlineKeyboardButton{
+			h.callbacks.Button("🗑 Удалить последний", actQuestions, questionsUndo),
+		}, row...)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// questionsPromptText ...
+func questionsPromptText(questions []domain.Question) string {
+	if len(questions) == 0 {
+		return questionsHelpText
+	}
+
+	var text strings.Builder
+	text.WriteString(questionsHelpText)
+	text.WriteString("\n\nДобавлено:\n")
+
+	for i, q := range questions {
+		text.WriteString(fmt.Sprintf("%d. %s%s\n", i+1, q.Text, optionsHint(q)))
+	}
+
+	return text.String()
+}
+
+// optionsHint ...
+func optionsHint(q domain.Question) string {
+	switch q.Kind {
+	case domain.QuestionSingle:
+		return " (один из: " + strings.Join(q.Options, ", ") + ")"
+	case domain.QuestionMulti:
+		return " (несколько из: " + strings.Join(q.Options, ", ") + ")"
+	}
+
+	return " (текст)"
+}
+
+// startRegistrationQuestions начинает опрос перед регистрацией в личных сообщениях.
+func (h *Handler) startRegistrationQuestions(ctx context.Context, query *tgbotapi.CallbackQuery, eventID int64) error {
+	userID := query.From.ID
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	questions, err := h.registrationUC.Questions(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	payload := registrationPayload{EventID: eventID}
+	if err := h.saveDialog(ctx, userID, domain.FlowRegistration, answerStep(questions[0]), payload); err != nil {
+		return err
+	}
+
+	intro := tgbotapi.NewMessage(userID, fmt.Sprintf(
+		"📝 Чтобы зарегистрироваться на «%s», ответьте на вопросы организатора (%d)", event.Title, len(questions)))
+	if _, err := h.bot.Send(intro); err != nil {
+		// пользователь ещё не писал боту — в личные сообщения не отправить
+		_ = h.stateRepo.DeleteState(ctx, userID)
+		h.sendCallback(query.ID, "📝", "Для регистрации нужно ответить на вопросы: "+
+			"напишите боту в личные сообщения и нажмите кнопку ещё раз")
+		return nil
+	}
+
+	if query.Message.Chat.ID != userID {
+		h.sendCallback(query.ID, "📝", "Ответьте на вопросы регистрации в личных сообщениях с ботом")
+	}
+
+	return h.askQuestion(userID, payload, questions)
+}
+
+// askQuestion отправляет очередной вопрос регистрации.
+func (h *Handler) askQuestion(chatID int64, payload registrationPayload, questions []domain.Question) error {
+	index := len(payload.Answers)
+	q := questions[index]
+
+	text := fmt.Sprintf("Вопрос %d/%d: %s", index+1, len(questions), q.Text)
+	switch q.Kind {
+	case domain.QuestionText:
+		text += "\n\nОтветьте сообщением."
+	case domain.QuestionMulti:
+		text += "\n\nОтметьте подходящие варианты и нажмите «Готово»."
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = h.createAnswerButtons(payload, q)
+	_, err := h.bot.Send(msg)
+
+	return err
+}
+
+// createAnswerButtons варианты ответа текущего вопроса и кнопка отмены регистрации.
+func (h *Handler) createAnswerButtons(payload registrationPayload, q domain.Question) tgbotapi.InlineKeyboardMarkup {
+	index := len(payload.Answers)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, option := range q.Options {
+		switch q.Kind {
+		case domain.QuestionSingle:
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				h.callbacks.Button(option, actAnswer, answerPick, payload.EventID, index, i),
+			))
+		case domain.QuestionMulti:
+			mark := "⬜"
+			if hasChoice(payload.Choices, i) {
+				mark = "☑️"
+			}
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				h.callbacks.Button(mark+" "+option, actAnswer, answerToggle, payload.EventID, index, i),
+			))
+		}
+	}
+
+	if q.Kind == domain.QuestionMulti {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button(EmOk+" Готово", actAnswer, answerDone, payload.EventID, index),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		h.callbacks.Button(EmCross+" Отменить регистрацию", actAnswer, answerCancel, payload.EventID, index),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleAnswerCallback обрабатывает выбор варианта ответа и отмену регистрации.
+func (h *Handler) handleAnswerCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	command, err := data.String(0)
+	if err != nil {
+		return fmt.Errorf("invalid answer callback: %w", err)
+	}
+
+	eventID, err := data.Int64(1)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	index, err := data.Int(2)
+	if err != nil {
+		return fmt.Errorf("failed to parse question index: %w", err)
+	}
+
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	state, err := h.loadCallbackState(ctx, query)
+	if err != nil {
+		return ignoreStateGone(err)
+	}
+
+	if state.Flow != domain.FlowRegistration {
+		h.sendCallback(query.ID, "⌛", "Кнопка устарела")
+		return nil
+	}
+
+	payload, err := decodePayload[registrationPayload](*state)
+	if err != nil {
+		return err
+	}
+
+	// кнопка от другого опроса или уже отвеченного вопроса
+	if payload.EventID != eventID || len(payload.Answers) != index {
+		h.sendCallback(query.ID, "⌛", "Кнопка устарела")
+		return nil
+	}
+
+	if command == answerCancel {
+		if err := h.fireDialog(ctx, query.From.ID, *state, fsm.Cancel, nil); err != nil {
+			return err
+		}
+		h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID,
+			tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+		h.sendMsg(chatID, EmOk, "Регистрация отменена")
+
+		return nil
+	}
+
+	questions, err := h.registrationUC.Questions(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	if index >= len(questions) {
+		h.sendCallback(query.ID, "⌛", "Вопросы события изменились, начните регистрацию заново")
+		return h.fireDialog(ctx, query.From.ID, *state, fsm.Cancel, nil)
+	}
+	q := questions[index]
+
+	var value string
+
+	switch command {
+	case answerPick:
+		option, err := data.Int(3)
+		if err != nil || option < 0 || option >= len(q.Options) {
+			return fmt.Errorf("invalid answer option: %v", err)
+		}
+		value = q.Options[option]
+
+	case answerToggle:
+		option, err := data.Int(3)
+		if err != nil || option < 0 || option >= len(q.Options) {
+			return fmt.Errorf("invalid answer option: %v", err)
+		}
+		payload.Choices = toggleChoice(payload.Choices, option)
+
+		if err := h.saveDialog(ctx, query.From.ID, state.Flow, fsm.State(state.Step), payload); err != nil {
+			return err
+		}
+		h.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, h.createAnswerButtons(payload, q)))
+
+		return nil
+
+	case answerDone:
+		if len(payload.Choices) == 0 {
+			h.sendCallback(query.ID, EmCross, "Выберите хотя бы один вариант")
+			return nil
+		}
+
+		choices := append([]int(nil), payload.Choices...)
+		sort.Ints(choices)
+
+		var selected []string
+		for _, i := range choices {
+			selected = append(selected, q.Options[i])
+		}
+		value = strings.Join(selected, domain.AnswerSeparator)
+
+	default:
+		return nil
+	}
+
+	// фиксируем ответ в тексте вопроса и убираем кнопки
+	h.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID,
+		fmt.Sprintf("Вопрос %d/%d: %s\n%s %s", index+1, len(questions), q.Text, EmNext, value)))
+
+	user := domain.User{
+		ID:        query.From.ID,
+		FirstName: query.From.FirstName,
+		UserName:  query.From.UserName,
+	}
+
+	return h.saveAnswer(ctx, chatID, user, *state, payload, questions, value)
+}
+
+// handleRegistrationInput принимает текстовый ответ на вопрос регистрации.
+func (h *Handler) handleRegistrationInput(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+
+	payload, err := decodePayload[registrationPayload](state)
+	if err != nil {
+		return err
+	}
+
+	questions, err := h.registrationUC.Questions(ctx, payload.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	// шаг выбран по виду вопроса при его отправке; организатор мог изменить вопросы
+	index := len(payload.Answers)
+	if index >= len(questions) || answerStep(questions[index]) != fsm.State(state.Step) {
+		h.sendError(msg.Chat.ID, "Вопросы события изменились, начните регистрацию заново")
+		return h.fireDialog(ctx, msg.From.ID, state, fsm.Cancel, nil)
+	}
+
+	// пустой и слишком длинный ответ отсеяны валидатором шага
+	text := strings.TrimSpace(msg.Text)
+
+	user := domain.User{
+		ID:        msg.From.ID,
+		FirstName: msg.From.FirstName,
+		UserName:  msg.From.UserName,
+	}
+
+	return h.saveAnswer(ctx, msg.Chat.ID, user, state, payload, questions, text)
+}
+
+// saveAnswer добавляет ответ и задаёт следующий вопрос, а после последнего
+// выполняет регистрацию.
+func (h *Handler) saveAnswer(
+	ctx context.Context,
+	chatID int64,
+	user domain.User,
+	state domain.EventState,
+	payload registrationPayload,
+	questions []domain.Question,
+	value string,
+) error {
+	payload.Answers = append(payload.Answers, domain.Answer{
+		QuestionID: questions[len(payload.Answers)].ID,
+		Value:      value,
+	})
+	payload.Choices = nil
+
+	if next := len(payload.Answers); next < len(questions) {
+		if err := h.fireDialog(ctx, user.ID, state, askEvent(questions[next]), payload); err != nil {
+			return err
+		}
+
+		return h.askQuestion(chatID, payload, questions)
+	}
+
+	if err := h.fireDialog(ctx, user.ID, state, fsm.Done, nil); err != nil {
+		return err
+	}
+
+	status, err := h.registrationUC.Register(ctx, payload.EventID, &user, payload.Answers)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAlreadyRegistered):
+			h.sendMsg(chatID, EmOk, "Вы уже зарегистрированы на это событие")
+			return nil
+		case errors.Is(err, domain.ErrInvalidAnswer):
+			h.sendError(chatID, "Вопросы события изменились, начните регистрацию заново")
+			return nil
+		}
+		h.sendError(chatID, "Ошибка регистрации")
+		return fmt.Errorf("failed to register: %w", err)
+	}
+
+	if err := h.refreshEventPosts(ctx, payload.EventID); err != nil {
+		h.logger.Error("failed to refresh event posts", slog.String("[ERROR]", err.Error()))
+	}
+
+	if status == domain.RegistrationPending {
+		event, err := h.eventUC.GetEvent(ctx, payload.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to get event: %w", err)
+		}
+
+		h.sendMsg(chatID, "⏳", "Заявка отправлена, автор события рассмотрит её")
+		h.requestApproval(ctx, event, user)
+
+		return nil
+	}
+
+	h.sendMsg(chatID, EmOk, "Вы зарегистрированы на событие")
+
+	if err := h.sendTicket(ctx, payload.EventID, user.ID); err != nil {
+		h.logger.Error("failed to send ticket", slog.String("[ERROR]", err.Error()))
+	}
+
+	return nil
+}
+
+func hasChoice(choices []int, option int) bool {
+	for _, c := range choices {
+		if c == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toggleChoice отмечает вариант или снимает отметку.
+func toggleChoice(choices []int, option int) []int {
+	for i, c := range choices {
+		if c == option {
+			return append(choices[:i], choices[i+1:]...)
+		}
+	}
+
+	return append(choices, option)
+}
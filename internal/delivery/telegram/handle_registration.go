@@ -2,32 +2,90 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"strconv"
-	"strings"
+	"log/slog"
 
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 	domain "github.com/binaryty/evbot/internal/domain/entities"
 )
 
 // handleRegistration ...
-func (h *Handler) handleRegistration(ctx context.Context, query *tgbotapi.CallbackQuery) error {
-	eventID, _ := strconv.ParseInt(strings.Split(query.Data, ":")[1], 10, 64)
+func (h *Handler) handleRegistration(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
 	user := domain.User{
 		ID:        query.From.ID,
 		FirstName: query.From.FirstName,
 		UserName:  query.From.UserName,
 	}
 
-	isRegistered, err := h.registrationUC.ToggleRegistration(ctx, eventID, &user)
+	// из канала регистрируются пользователи, ещё не писавшие боту
+	if err := h.userUC.CreateOrUpdate(ctx, &user); err != nil {
+		h.logger.Error("failed to update user", slog.String("[ERROR]", err.Error()))
+	}
+
+	// карточку в канале и группе видят все: результат показываем
+	// всплывающим уведомлением, а не на кнопке
+	shared := query.Message.Chat.IsChannel() || isGroupChat(query.Message.Chat)
+
+	status, err := h.registrationUC.ToggleRegistration(ctx, eventID, &user)
+	if errors.Is(err, domain.ErrAnswersRequired) {
+		return h.startRegistrationQuestions(ctx, query, eventID)
+	}
 	if err != nil {
-		h.sendError(query.Message.Chat.ID, "Ошибка регистрации")
+		if shared {
+			h.sendCallback(query.ID, EmCross, "Ошибка регистрации")
+		} else {
+			h.sendError(query.Message.Chat.ID, "Ошибка регистрации")
+		}
 		return fmt.Errorf("failed to register: %w", err)
 	}
 
-	isAdmin := h.isAdmin(query.From.ID)
+	if err := h.refreshEventPosts(ctx, eventID); err != nil {
+		h.logger.Error("failed to refresh event posts", slog.String("[ERROR]", err.Error()))
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	switch status {
+	case domain.RegistrationApproved:
+		// билет приходит в личные сообщения; если пользователь не писал боту,
+		// Telegram не даст его отправить
+		if err := h.sendTicket(ctx, eventID, user.ID); err != nil {
+			h.logger.Error("failed to send ticket", slog.String("[ERROR]", err.Error()))
+		}
+	case domain.RegistrationPending:
+		h.requestApproval(ctx, event, user)
+	}
+
+	if shared {
+		icon, text := EmOk, "Регистрация отменена"
+		switch status {
+		case domain.RegistrationApproved:
+			text = "Вы зарегистрированы на событие"
+		case domain.RegistrationPending:
+			icon, text = "⏳", "Заявка отправлена, автор события рассмотрит её"
+		}
+		h.sendCallback(query.ID, icon, text)
+
+		return nil
+	}
+
+	if status == domain.RegistrationPending {
+		h.sendCallback(query.ID, "⏳", "Заявка отправлена, автор события рассмотрит её")
+	}
+
+	isAdmin := h.canModerateEvent(query.Message.Chat, query.From.ID, event)
 
-	buttons := createEventButtons(eventID, isRegistered, isAdmin)
+	buttons := h.createEventButtons(eventID, status, isAdmin, h.isOrganizer(query.Message.Chat, query.From.ID, event))
 
 	editMarkup := tgbotapi.NewEditMessageReplyMarkup(
 		query.Message.Chat.ID,
@@ -4,9 +4,15 @@ import (
 	"context"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"strings"
 )
 
 func (h *Handler) handleStartCommand(ctx context.Context, update *tgbotapi.Update) error {
+	// переход по ссылке из QR-кода билета
+	if payload := update.Message.CommandArguments(); strings.HasPrefix(payload, checkInPrefix) {
+		return h.checkInTicket(ctx, update.Message.Chat, update.Message.From.ID, strings.TrimPrefix(payload, checkInPrefix))
+	}
+
 	welcomeText := fmt.Sprintf(
 		`👋 Привет, %s! Я бот для управления событиями.
 
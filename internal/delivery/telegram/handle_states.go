@@ -5,151 +5,423 @@ import (
 	"errors"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"log"
 	"log/slog"
 	"time"
 
-	"github.com/binaryty/evbot/internal/delivery/telegram/timepicker"
+	"github.com/binaryty/evbot/internal/dateparse"
 	domain "github.com/binaryty/evbot/internal/domain/entities"
-	"github.com/binaryty/evbot/internal/util"
+	"github.com/binaryty/evbot/internal/fsm"
+	"github.com/binaryty/evbot/internal/repository"
 )
 
-// handleTitleStep ...
-func (h *Handler) handleTitleStep(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
-	if len(text) > 100 {
-		h.sendError(update.Message.Chat.ID, "Слишком длинное название (макс. 100 символов)")
-		return nil
+// advance выполняет переход мастера создания события, сохраняет черновик
+// и показывает приглашение нового шага. При редактировании поля из
+// предпросмотра и «далее», и «назад» возвращают к предпросмотру.
+func (h *Handler) advance(ctx context.Context, userID int64, chatID int64, state domain.EventState, event fsm.Event) error {
+	if state.Editing && (event == fsm.Next || event == fsm.Back || event == fsm.Skip) {
+		event = fsm.Done
 	}
 
-	state.TempEvent.Title = text
-	state.Step = domain.StepDescription
+	next, err := h.eventFlow.Fire(fsm.State(state.Step), event)
+	if err != nil {
+		return fmt.Errorf("failed to advance flow: %w", err)
+	}
+
+	if next == fsm.Final {
+		return h.stateRepo.DeleteState(ctx, userID)
+	}
+
+	state.Step = string(next)
 
-	if err := h.stateRepo.SaveState(ctx, update.Message.From.ID, state); err != nil {
+	switch state.Step {
+	case domain.StepConfirm:
+		state.Editing = false
+		state.KeepTime = false
+	case domain.StepTime:
+		state.TimePicker = h.timePicker.Reset()
+	}
+
+	if err := h.stateRepo.SaveState(ctx, userID, state); err != nil {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
 
-	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "Введите описание события:")
-	h.bot.Send(msg)
+	return h.promptStep(ctx, chatID, state)
+}
+
+// saveDialog сохраняет служебный диалог flow на шаге step с данными payload.
+func (h *Handler) saveDialog(ctx context.Context, userID int64, flow string, step fsm.State, payload any) error {
+	if m, ok := h.serviceFlows[flow]; !ok || !m.Has(step) {
+		return fmt.Errorf("unknown dialog step %s/%s", flow, step)
+	}
+
+	data, err := encodePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	state := domain.EventState{Flow: flow, Step: string(step), Payload: data}
+	if err := h.stateRepo.SaveState(ctx, userID, state); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
 
 	return nil
 }
 
-// handleDescriptionStep ...
-func (h *Handler) handleDescriptionStep(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
-	if len(text) > 500 {
-		h.sendError(update.Message.Chat.ID, "Слишком длинное описание (макс. 500 символов)")
+// fireDialog выполняет переход служебного диалога и сохраняет его новые
+// данные; завершённый диалог удаляется.
+func (h *Handler) fireDialog(ctx context.Context, userID int64, state domain.EventState, event fsm.Event, payload any) error {
+	m, ok := h.serviceFlows[state.Flow]
+	if !ok {
+		return fmt.Errorf("unknown dialog %q", state.Flow)
+	}
+
+	next, err := m.Fire(fsm.State(state.Step), event)
+	if err != nil {
+		return fmt.Errorf("failed to advance dialog: %w", err)
+	}
+
+	if next == fsm.Final {
+		if err := h.stateRepo.DeleteState(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete state: %w", err)
+		}
 		return nil
 	}
 
-	state.TempEvent.Description = text
-	state.Step = domain.StepDate
+	return h.saveDialog(ctx, userID, state.Flow, next, payload)
+}
+
+// editField открывает шаг мастера из предпросмотра.
+func (h *Handler) editField(ctx context.Context, userID int64, chatID int64, state domain.EventState, field string) error {
+	next, err := h.eventFlow.Fire(fsm.State(state.Step), editFieldEvent(field))
+	if err != nil {
+		return fmt.Errorf("failed to edit field: %w", err)
+	}
 
-	if err := h.stateRepo.SaveState(ctx, update.Message.From.ID, state); err != nil {
+	state.Step = string(next)
+	state.Editing = true
+	if state.Step == domain.StepTime {
+		state.TimePicker = h.timePicker.Reset()
+	}
+
+	if err := h.stateRepo.SaveState(ctx, userID, state); err != nil {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
 
-	return h.sendDateCalendar(update.Message.Chat.ID)
+	return h.promptStep(ctx, chatID, state)
 }
 
-// sendDateCalendar ...
-func (h *Handler) sendDateCalendar(chatID int64) error {
-	calendar := domain.NewCalendar()
-	msg := tgbotapi.NewMessage(chatID, "Выберите дату события:")
-	msg.ReplyMarkup = generateCalendar(calendar.CurrentDate, calendar.CurrentDate)
-	h.bot.Send(msg)
+// promptStep отправляет приглашение для текущего шага.
+func (h *Handler) promptStep(ctx context.Context, chatID int64, state domain.EventState) error {
+	switch state.Step {
+	case domain.StepTitle:
+		text := "Введите название события:"
+		if state.Editing {
+			text = "Введите новое название события:"
+		}
+		h.bot.Send(tgbotapi.NewMessage(chatID, text))
+	case domain.StepDescription:
+		text := "Введите описание события:"
+		if state.Editing {
+			text = "Введите новое описание события:"
+		}
+		h.bot.Send(tgbotapi.NewMessage(chatID, text))
+	case domain.StepMedia:
+		return h.sendMediaPrompt(chatID)
+	case domain.StepDate:
+		return h.sendDateCalendar(ctx, chatID, state)
+	case domain.StepTime:
+		return h.sendTimePicker(chatID, state)
+	case domain.StepQuestions:
+		return h.sendQuestionsPrompt(chatID, state)
+	case domain.StepConfirm:
+		return h.sendConfirmation(chatID, state.TempEvent)
+	}
 
 	return nil
 }
 
-// handleTimeStep ...
-func (h *Handler) handleTimeStep(ctx context.Context, userID int64, chatID int64) error {
+// handleTitleStep ...
+func (h *Handler) handleTitleStep(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
+	state.TempEvent.Title = text
+
+	return h.advance(ctx, update.Message.From.ID, update.Message.Chat.ID, state, fsm.Next)
+}
+
+// handleDescriptionStep ...
+func (h *Handler) handleDescriptionStep(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
+	state.TempEvent.Description = text
 
-	state, err := h.stateRepo.GetState(ctx, userID)
-	if err != nil {
-		h.logger.Error("failed to get state:", slog.String("[ERROR]", err.Error()))
-		return fmt.Errorf("failed to get state: %w", err)
+	return h.advance(ctx, update.Message.From.ID, update.Message.Chat.ID, state, fsm.Next)
+}
+
+// sendMediaPrompt ...
+func (h *Handler) sendMediaPrompt(chatID int64) error {
+	msg := tgbotapi.NewMessage(chatID,
+		"Отправьте обложку события (фото) или документ, например программу в PDF.\n"+
+			"Этот шаг можно пропустить.")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button(EmNext+" Пропустить", actMediaSkip),
+		),
+	)
+	h.bot.Send(msg)
+
+	return nil
+}
+
+// handleMediaStep ...
+func (h *Handler) handleMediaStep(ctx context.Context, update *tgbotapi.Update, state domain.EventState) error {
+	msg := update.Message
+
+	switch {
+	case len(msg.Photo) > 0:
+		// последний размер — самый большой
+		state.TempEvent.PhotoFileID = msg.Photo[len(msg.Photo)-1].FileID
+	case msg.Document != nil:
+		state.TempEvent.DocumentFileID = msg.Document.FileID
+	default:
+		h.sendError(msg.Chat.ID, "Отправьте фото или документ либо нажмите «Пропустить»")
+		return nil
 	}
 
-	tp := domain.TimePicker{
-		SelectedTime: state.TempEvent.Date,
-		Step:         "hours",
+	return h.advance(ctx, msg.From.ID, msg.Chat.ID, state, fsm.Next)
+}
+
+// sendDateCalendar ...
+func (h *Handler) sendDateCalendar(ctx context.Context, chatID int64, state domain.EventState) error {
+	month := state.SelectedDate
+	if month.IsZero() {
+		month = time.Now().In(h.loc)
 	}
 
-	msg := tgbotapi.NewMessage(chatID, "Выбурите время:")
-	msg.ReplyMarkup = timepicker.GenerateTimePicker(&tp)
+	msg := tgbotapi.NewMessage(chatID, "Выберите дату события или напишите её, например «завтра», «в пятницу» или «25.12 18:30»:")
+	msg.ReplyMarkup = h.calendar.MonthMarkup(h.calendarView(ctx, state, month))
 	h.bot.Send(msg)
 
-	state.TimePicker = tp
+	return nil
+}
+
+// sendTimePicker ...
+func (h *Handler) sendTimePicker(chatID int64, state domain.EventState) error {
+	msg := tgbotapi.NewMessage(chatID, h.timePickerText(state.TimePicker))
+	msg.ReplyMarkup = h.timePicker.Markup(state.TimePicker)
+	h.bot.Send(msg)
 
-	return h.stateRepo.SaveState(ctx, userID, *state)
+	return nil
 }
 
-// handleFinishEventCreation ...
-func (h *Handler) handleFinishEventCreation(ctx context.Context, update *tgbotapi.Update, text string) error {
-	state, err := h.stateRepo.GetState(ctx, update.Message.From.ID)
-	if err != nil {
-		h.sendError(update.Message.Chat.ID, "Ошибка создания события")
-		return fmt.Errorf("get state error: %w", err)
+// handleDateInputStep дату можно написать текстом вместо выбора в календаре.
+// Если в тексте есть и время («завтра в 19:00»), шаг времени пропускается.
+func (h *Handler) handleDateInputStep(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
+	msg := update.Message
+	now := time.Now().In(h.loc)
+
+	res, err := dateparse.Parse(text, now)
+	if err != nil || !res.HasDate {
+		h.sendError(msg.Chat.ID, "Не удалось распознать дату. Например: «завтра», «в пятницу», «25.12» или «завтра в 19:00»")
+		return nil
+	}
+
+	if (res.HasTime && res.Time.Before(now)) || res.Time.Before(startOfDay(now)) {
+		h.sendError(msg.Chat.ID, "Эта дата уже прошла")
+		return nil
+	}
+
+	if _, max := h.dateBounds(); res.Time.After(max) {
+		h.sendError(msg.Chat.ID, fmt.Sprintf("Событие можно запланировать не позже %s", max.Format("02.01.2006")))
+		return nil
+	}
+
+	t := res.Time
+	event := fsm.Next
+
+	switch {
+	case res.HasTime:
+		state.TempEvent.Date = wallClock(t, t.Hour(), t.Minute())
+		event = flowKeepTime
+	case state.Editing || state.KeepTime:
+		// время события уже известно — меняется только дата
+		d := state.TempEvent.Date
+		state.TempEvent.Date = wallClock(t, d.Hour(), d.Minute())
+		if !state.Editing {
+			event = flowKeepTime
+		}
+	default:
+		state.TempEvent.Date = wallClock(t, 0, 0)
 	}
+	state.SelectedDate = wallClock(t, 0, 0)
 
-	t, err := time.Parse("15:04", text)
+	h.echoMoment(msg.Chat.ID, state.TempEvent.Date, res.HasTime || state.Editing || state.KeepTime)
+
+	return h.advance(ctx, msg.From.ID, msg.Chat.ID, state, event)
+}
+
+// handleTimeInputStep время можно написать текстом вместо выбора в пикере;
+// если в тексте указана и дата, она заменяет выбранную ранее.
+func (h *Handler) handleTimeInputStep(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
+	res, err := dateparse.Parse(text, time.Now().In(h.loc))
 	if err != nil {
 		return fmt.Errorf("failed to parse time: %w", err)
 	}
 
 	d := state.TempEvent.Date
+	if res.HasDate {
+		d = res.Time
+	}
+	date := wallClock(d, res.Time.Hour(), res.Time.Minute())
+
+	if problem := h.momentProblem(date); problem != "" {
+		h.sendError(update.Message.Chat.ID, problem)
+		return nil
+	}
+	state.TempEvent.Date = date
+
+	h.echoMoment(update.Message.Chat.ID, state.TempEvent.Date, true)
+
+	return h.advance(ctx, update.Message.From.ID, update.Message.Chat.ID, state, fsm.Next)
+}
+
+// momentProblem проверяет момент события на тех же границах, что и выбор даты.
+func (h *Handler) momentProblem(date time.Time) string {
+	_, max := h.dateBounds()
+
+	return checkMoment(date, time.Now().In(h.loc), max)
+}
+
+// checkMoment момент события date (см. wallClock) не прошёл к моменту now
+// и приходится не позже дня max. Возвращает объяснение для пользователя,
+// пустое — если момент подходит.
+func checkMoment(date time.Time, now time.Time, max time.Time) string {
+	local := time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), 0, 0, now.Location())
+
+	if local.Before(now) {
+		return "Это время уже прошло: выберите более позднее"
+	}
+
+	if startOfDay(local).After(max) {
+		return fmt.Sprintf("Событие можно запланировать не позже %s", max.Format("02.01.2006"))
+	}
+
+	return ""
+}
 
-	state.TempEvent.Date = time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+// echoMoment показывает, как бот понял введённые дату и время.
+func (h *Handler) echoMoment(chatID int64, t time.Time, withTime bool) {
+	layout := "02.01.2006"
+	if withTime {
+		layout = "02.01.2006 15:04"
+	}
+
+	text := fmt.Sprintf("🗓 Понял: %s, %s (%s)", weekdayNames[t.Weekday()], t.Format(layout), h.loc)
+	h.bot.Send(tgbotapi.NewMessage(chatID, text))
+}
+
+// weekdayNames ...
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "понедельник",
+	time.Tuesday:   "вторник",
+	time.Wednesday: "среда",
+	time.Thursday:  "четверг",
+	time.Friday:    "пятница",
+	time.Saturday:  "суббота",
+	time.Sunday:    "воскресенье",
+}
+
+// wallClock переводит дату в принятое в боте представление: местное
+// время, записанное как UTC.
+func wallClock(date time.Time, hour, minute int) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, time.UTC)
+}
+
+// startOfDay ...
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// handleBackCommand возвращает мастер на предыдущий шаг.
+func (h *Handler) handleBackCommand(ctx context.Context, update *tgbotapi.Update) error {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
 
-	// Валидация данных
-	if state.TempEvent.Title == "" || state.TempEvent.Date.IsZero() || state.TempEvent.Date.Hour() == 0 {
-		h.sendError(update.Message.Chat.ID, "Не все данные заполнены")
-		return errors.New("incomplete event data")
+	state, err := h.loadState(ctx, userID, chatID)
+	if err != nil {
+		if errors.Is(err, repository.ErrStateNotFound) {
+			h.sendError(chatID, "Нет активного действия")
+		}
+		return ignoreStateGone(err)
 	}
 
-	// создаем полный объект события
-	event := domain.Event{
-		UserID:      update.Message.From.ID,
-		Title:       state.TempEvent.Title,
-		Description: state.TempEvent.Description,
-		Date:        state.TempEvent.Date,
-		CreatedAt:   time.Now().UTC(),
+	if !state.Editing && !h.eventFlow.Can(fsm.State(state.Step), fsm.Back) {
+		h.sendError(chatID, "Это первый шаг, возвращаться некуда")
+		return nil
 	}
 
-	// Сохраняем в БД
-	event.ID, err = h.eventUC.CreateEvent(ctx, update.Message.From.ID, event)
+	return h.advance(ctx, userID, chatID, *state, fsm.Back)
+}
+
+// loadState возвращает текущее состояние диалога пользователя. Состояние,
+// не изменявшееся дольше StateTTL, удаляется, а пользователь получает уведомление.
+func (h *Handler) loadState(ctx context.Context, userID int64, chatID int64) (*domain.EventState, error) {
+	state, err := h.stateRepo.GetState(ctx, userID)
 	if err != nil {
-		h.sendError(update.Message.Chat.ID, "Ошибка сохранения события")
-		return fmt.Errorf("failed to create event: %w", err)
-	}
-
-	// Отправляем подтверждение
-	msgText := fmt.Sprintf(
-		"🎉 *Событие успешно создано\\!*\n\n"+
-			"📌 *Название:* %s\n"+
-			"📝 *Описание:* %s\n"+
-			"⏰ *Дата и время:* %s",
-		util.EscapeMarkdownV2(event.Title),
-		util.EscapeMarkdownV2(event.Description),
-		event.Date.Format("02\\.01\\.2006 15\\:04"),
-	)
+		return nil, err
+	}
+
+	if h.cfg.StateTTL <= 0 || time.Since(state.CreatedAt) <= h.cfg.StateTTL {
+		return state, nil
+	}
+
+	if err := h.stateRepo.DeleteState(ctx, userID); err != nil {
+		h.logger.Error("failed to delete expired state", slog.String("[ERROR]", err.Error()))
+	}
+
+	h.sendMsg(chatID, "⌛", expiredNotice(state.Flow))
+
+	return nil, domain.ErrStateExpired
+}
 
-	// Создаем кнопки управления
-	isAdmin := h.isAdmin(update.Message.From.ID)
-	markup := createEventButtons(event.ID, false, isAdmin)
+// expiredNotices сообщения об истёкшем диалоге: что прервано и как начать заново.
+var expiredNotices = map[string]string{
+	domain.FlowEvent: "Черновик истёк: он слишком долго не изменялся.\n" +
+		"Начните заново командой */new_event*",
+	domain.FlowBroadcast: "Рассылка отменена: сообщение для участников так и не пришло.\n" +
+		"Откройте её снова кнопкой в карточке события",
+	domain.FlowCheckIn: "Режим отметки завершён: билеты давно не приходили.\n" +
+		"Включите его снова командой */checkin*",
+	domain.FlowFeedback: "Комментарий к оценке больше не ждём, оценка сохранена без него",
+	domain.FlowRegistration: "Регистрация не завершена: ответы на вопросы так и не пришли.\n" +
+		"Запишитесь снова из карточки события",
+	domain.FlowImport: "Импорт отменён: файл так и не подтвердили.\n" +
+		"Начните заново командой */import*",
+	domain.FlowDraftName: "Переименование черновика отменено: имя так и не пришло.\n" +
+		"Откройте список черновиков командой */drafts*",
+}
+
+// expiredNotice сообщение об истечении диалога flow.
+func expiredNotice(flow string) string {
+	if text, ok := expiredNotices[flow]; ok {
+		return text
+	}
 
-	msg := tgbotapi.NewMessage(update.Message.Chat.ID, msgText)
-	msg.ParseMode = tgbotapi.ModeMarkdownV2
-	msg.ReplyMarkup = markup
+	return "Действие истекло: оно слишком долго не продолжалось"
+}
 
-	if _, err := h.bot.Send(msg); err != nil {
-		return fmt.Errorf("failed to send confirmation: %w", err)
+// loadCallbackState то же для нажатий кнопок: о пропавшем диалоге
+// сообщаем всплывающим уведомлением.
+func (h *Handler) loadCallbackState(ctx context.Context, query *tgbotapi.CallbackQuery) (*domain.EventState, error) {
+	state, err := h.loadState(ctx, query.From.ID, query.Message.Chat.ID)
+	if errors.Is(err, repository.ErrStateNotFound) {
+		h.sendCallback(query.ID, "⌛", "Действие истекло или уже завершено")
 	}
 
-	// Очищаем состояние
-	if err := h.stateRepo.DeleteState(ctx, update.Message.From.ID); err != nil {
-		log.Printf("Failed to clear user state: %v", err)
+	return state, err
+}
+
+// ignoreStateGone отсутствие или истечение диалога — штатная ситуация, а не ошибка.
+func ignoreStateGone(err error) error {
+	if errors.Is(err, repository.ErrStateNotFound) || errors.Is(err, domain.ErrStateExpired) {
+		return nil
 	}
 
-	return nil
+	return err
 }
@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func TestCheckMoment(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// среда, 14 октября 2026, 18:30 по Москве
+	now := time.Date(2026, time.October, 14, 18, 30, 0, 0, loc)
+	max := time.Date(2027, time.October, 14, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		// date момент в представлении wallClock.
+		date time.Time
+		want string
+	}{
+		{"later today", time.Date(2026, time.October, 14, 19, 0, 0, 0, time.UTC), ""},
+		{"now", time.Date(2026, time.October, 14, 18, 30, 0, 0, time.UTC), ""},
+		{"earlier today", time.Date(2026, time.October, 14, 8, 0, 0, 0, time.UTC), "уже прошло"},
+		// 18:00 по Москве прошло, хотя как UTC-момент ещё впереди
+		{"passed in local time", time.Date(2026, time.October, 14, 18, 0, 0, 0, time.UTC), "уже прошло"},
+		{"past date", time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC), "уже прошло"},
+		{"last day", time.Date(2027, time.October, 14, 23, 30, 0, 0, time.UTC), ""},
+		{"after last day", time.Date(2027, time.October, 15, 0, 0, 0, 0, time.UTC), "не позже 14.10.2027"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkMoment(tt.date, now, max)
+			if (tt.want == "") != (got == "") || !strings.Contains(got, tt.want) {
+				t.Errorf("checkMoment(%v) = %q, want %q", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadStateExpired(t *testing.T) {
+	const user int64 = 10
+
+	tests := []struct {
+		name     string
+		state    domain.EventState
+		wantText string
+	}{
+		{"event draft", domain.EventState{Step: domain.StepTitle}, "/new_event"},
+		{"broadcast", domain.EventState{Flow: domain.FlowBroadcast, Step: string(stepBroadcastMessage)}, "Рассылка отменена"},
+		{"check-in", domain.EventState{Flow: domain.FlowCheckIn, Step: string(stepCheckInTicket)}, "/checkin"},
+		{"feedback", domain.EventState{Flow: domain.FlowFeedback, Step: string(stepFeedbackComment)}, "оценка сохранена"},
+		{"registration", domain.EventState{Flow: domain.FlowRegistration, Step: string(stepAnswerText)}, "Регистрация не завершена"},
+		{"import", domain.EventState{Flow: domain.FlowImport, Step: string(stepImportPreview)}, "/import"},
+		{"draft name", domain.EventState{Flow: domain.FlowDraftName, Step: string(stepDraftName)}, "/drafts"},
+		{"unknown flow", domain.EventState{Flow: "poll", Step: "vote"}, "Действие истекло"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{}
+			h := newTestHandler(t, api)
+			h.cfg.StateTTL = time.Nanosecond
+			ctx := context.Background()
+
+			if err := h.stateRepo.SaveState(ctx, user, tt.state); err != nil {
+				t.Fatalf("SaveState: %v", err)
+			}
+			time.Sleep(time.Millisecond)
+
+			if _, err := h.loadState(ctx, user, user); !errors.Is(err, domain.ErrStateExpired) {
+				t.Fatalf("loadState error = %v, want ErrStateExpired", err)
+			}
+
+			sent := api.sent(user)
+			if len(sent) != 1 {
+				t.Fatalf("sent %d messages, want 1", len(sent))
+			}
+			text := sent[0].params["text"]
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("notice = %q, want %q", text, tt.wantText)
+			}
+			if !tt.state.IsDraft() && strings.Contains(text, "/new_event") {
+				t.Errorf("notice for %s suggests /new_event: %q", tt.state.Flow, text)
+			}
+		})
+	}
+
+	// у каждого служебного диалога своё сообщение
+	for flow := range newServiceFlows() {
+		if _, ok := expiredNotices[flow]; !ok {
+			t.Errorf("no expired notice for flow %q", flow)
+		}
+	}
+}
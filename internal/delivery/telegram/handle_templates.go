@@ -0,0 +1,195 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"strings"
+	"time"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/util"
+)
+
+// handleCloneCallback начинает создание события по образцу существующего.
+func (h *Handler) handleCloneCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	event, err := h.eventUC.GetEvent(ctx, eventID)
+	if err != nil {
+		h.sendCallback(query.ID, EmCross, "Событие не найдено")
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	questions, err := h.eventUC.Questions(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	// у копии свои вопросы: они сохранятся вместе с новым событием
+	for i := range questions {
+		questions[i].ID, questions[i].EventID = 0, 0
+	}
+
+	return h.startPrefilledDraft(ctx, query.From.ID, query.Message.Chat, domain.Event{
+		Title:          event.Title,
+		Description:    event.Description,
+		PhotoFileID:    event.PhotoFileID,
+		DocumentFileID: event.DocumentFileID,
+		Date:           event.Date,
+		Questions:      questions,
+	})
+}
+
+// handleTemplatesCommand ...
+func (h *Handler) handleTemplatesCommand(ctx context.Context, update *tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	text, markup, err := h.renderTemplates(ctx, update.Message.From.ID)
+	if err != nil {
+		h.sendError(chatID, "Ошибка получения шаблонов")
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeMarkdownV2
+	if markup != nil {
+		msg.ReplyMarkup = *markup
+	}
+
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// handleTemplateSave сохраняет событие как шаблон.
+func (h *Handler) handleTemplateSave(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	eventID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	if _, err := h.templateUC.CreateFromEvent(ctx, query.From.ID, eventID); err != nil {
+		h.sendCallback(query.ID, EmCross, "Не удалось сохранить шаблон")
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+	h.sendCallback(query.ID, EmOk, "Шаблон сохранён, он доступен в /templates")
+
+	return nil
+}
+
+// handleTemplateUse начинает создание события по шаблону.
+func (h *Handler) handleTemplateUse(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	templateID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse template ID: %w", err)
+	}
+
+	userID := query.From.ID
+
+	t, err := h.templateUC.Template(ctx, userID, templateID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTemplateNotFound) {
+			h.sendCallback(query.ID, EmCross, "Шаблон не найден")
+			return nil
+		}
+		return fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return h.startPrefilledDraft(ctx, userID, query.Message.Chat, domain.Event{
+		Title:          t.Title,
+		Description:    t.Description,
+		PhotoFileID:    t.PhotoFileID,
+		DocumentFileID: t.DocumentFileID,
+		Date:           time.Date(1, time.January, 1, t.Hour, t.Minute, 0, 0, time.UTC),
+	})
+}
+
+// handleTemplateDelete удаляет шаблон и обновляет список.
+func (h *Handler) handleTemplateDelete(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	templateID, err := data.Int64(0)
+	if err != nil {
+		return fmt.Errorf("failed to parse template ID: %w", err)
+	}
+
+	userID := query.From.ID
+
+	if err := h.templateUC.DeleteTemplate(ctx, userID, templateID); err != nil && !errors.Is(err, domain.ErrTemplateNotFound) {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	text, markup, err := h.renderTemplates(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text)
+	edit.ParseMode = tgbotapi.ModeMarkdownV2
+	edit.ReplyMarkup = markup
+	_, err = h.bot.Send(edit)
+
+	return err
+}
+
+// startPrefilledDraft начинает мастер с заполненными полями и известным временем:
+// остаётся выбрать дату, затем пользователь попадает в предпросмотр.
+func (h *Handler) startPrefilledDraft(ctx context.Context, userID int64, chat *tgbotapi.Chat, event domain.Event) error {
+	event.UserID = userID
+	event.ChatID = spaceID(chat)
+
+	state := domain.EventState{
+		Step:      domain.StepDate,
+		TempEvent: event,
+		KeepTime:  true,
+	}
+
+	if _, err := h.stateRepo.CreateDraft(ctx, userID, state); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	h.sendMsg(chat.ID, "📄", fmt.Sprintf("Создаём событие по образцу, время — %s",
+		event.Date.Format("15:04")))
+
+	return h.promptStep(ctx, chat.ID, state)
+}
+
+// renderTemplates ...
+func (h *Handler) renderTemplates(ctx context.Context, userID int64) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	templates, err := h.templateUC.ListTemplates(ctx, userID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	if len(templates) == 0 {
+		return "🗂 Шаблонов нет\\. Сохраните событие как шаблон кнопкой «🗂 В шаблоны» в его карточке", nil, nil
+	}
+
+	var (
+		text strings.Builder
+		rows [][]tgbotapi.InlineKeyboardButton
+	)
+
+	text.WriteString("🗂 *Ваши шаблоны:*\n\n")
+
+	for i, t := range templates {
+		text.WriteString(fmt.Sprintf("%d\\. *%s*, %02d\\:%02d\n",
+			i+1,
+			util.EscapeMarkdownV2(t.Title),
+			t.Hour,
+			t.Minute,
+		))
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			h.callbacks.Button(fmt.Sprintf("%s %d. Создать", EmNext, i+1), actTemplateUse, t.ID),
+			h.callbacks.Button(fmt.Sprintf("🗑 %d. Удалить", i+1), actTemplateDel, t.ID),
+		))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return text.String(), &markup, nil
+}
@@ -4,116 +4,84 @@ import (
 	"context"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"strings"
-	"time"
 
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 	"github.com/binaryty/evbot/internal/delivery/telegram/timepicker"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/fsm"
 )
 
-func (h *Handler) handleTimeCallback(ctx context.Context, query *tgbotapi.CallbackQuery) error {
-	parts := strings.Split(query.Data, ":")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid time callback")
-	}
-
-	switch parts[0] {
-	case "time_h":
-		return h.handleHoursSelection(ctx, query, parts[1])
-	case "time_m":
-		return h.handleMinuteSelection(ctx, query, parts[1])
-	case "time_confirm":
-		return h.confirmTimeSelection(ctx, query)
-	case "time_cancel":
-		return h.cancelTimeSelection(ctx, query)
-	}
-	return nil
-}
-
-func (h *Handler) handleHoursSelection(ctx context.Context, query *tgbotapi.CallbackQuery, hour string) error {
-	state, err := h.stateRepo.GetState(ctx, query.From.ID)
+// handleTimeCallback обрабатывает нажатия в клавиатуре выбора времени.
+func (h *Handler) handleTimeCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callback.Data) error {
+	cb, err := timepicker.Parse(data)
 	if err != nil {
 		return err
 	}
 
-	hh, err := time.Parse("15", hour)
+	state, err := h.loadCallbackState(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to parse time: %w", err)
+		return ignoreStateGone(err)
 	}
 
-	state.TimePicker.TempHours = hh.Hour()
-	state.TimePicker.Step = "minutes"
-
-	editMarkup := tgbotapi.NewEditMessageReplyMarkup(
-		query.Message.Chat.ID,
-		query.Message.MessageID,
-		timepicker.GenerateTimePicker(&state.TimePicker),
-	)
-
-	h.bot.Send(editMarkup)
-
-	return h.stateRepo.SaveState(ctx, query.From.ID, *state)
-}
-
-func (h *Handler) handleMinuteSelection(ctx context.Context, query *tgbotapi.CallbackQuery, minute string) error {
-	state, err := h.stateRepo.GetState(ctx, query.From.ID)
-	if err != nil {
-		return err
+	// пикер из уже пройденного шага
+	if state.Step != domain.StepTime {
+		return nil
 	}
 
-	m, err := time.Parse("04", minute)
+	outcome, err := h.timePicker.Apply(&state.TimePicker, cb)
 	if err != nil {
 		return err
 	}
-	state.TimePicker.TempMinutes = m.Minute()
 
-	newTime := time.Date(
-		state.TempEvent.Date.Year(),
-		state.TempEvent.Date.Month(),
-		state.TempEvent.Date.Day(),
-		state.TimePicker.TempHours,
-		state.TimePicker.TempMinutes,
-		0, 0, time.UTC,
-	)
+	chatID := query.Message.Chat.ID
 
-	state.TimePicker.SelectedTime = newTime
-	state.TimePicker.Step = "hours"
+	switch outcome {
+	case timepicker.Incomplete:
+		h.sendCallback(query.ID, EmCross, "Сначала выберите час")
+		return nil
 
-	edit := tgbotapi.NewEditMessageText(
-		query.Message.Chat.ID,
-		query.Message.MessageID,
-		fmt.Sprintf("Выбрано время: %s", newTime.Format("15:04")),
-	)
-	timePicker := timepicker.GenerateTimePicker(&state.TimePicker)
+	case timepicker.Confirmed:
+		d := state.TempEvent.Date
+		date := wallClock(d, state.TimePicker.TempHours, state.TimePicker.TempMinutes)
 
-	edit.ReplyMarkup = &timePicker
+		if problem := h.momentProblem(date); problem != "" {
+			h.sendCallback(query.ID, EmCross, problem)
+			return nil
+		}
+		state.TempEvent.Date = date
 
-	h.bot.Send(edit)
+		h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
 
-	return h.stateRepo.SaveState(ctx, query.From.ID, *state)
-}
+		return h.advance(ctx, query.From.ID, chatID, *state, fsm.Next)
 
-func (h *Handler) confirmTimeSelection(ctx context.Context, query *tgbotapi.CallbackQuery) error {
-	state, err := h.stateRepo.GetState(ctx, query.From.ID)
-	if err != nil {
-		return fmt.Errorf("failed to get state: %w", err)
+	case timepicker.Cancelled:
+		// возврат к выбору даты, а при редактировании — к предпросмотру
+		h.bot.Send(tgbotapi.NewDeleteMessage(chatID, query.Message.MessageID))
+
+		return h.advance(ctx, query.From.ID, chatID, *state, fsm.Back)
 	}
 
-	state.TempEvent.Date = state.TimePicker.SelectedTime
-	state.Step = "confirm"
+	if err := h.stateRepo.SaveState(ctx, query.From.ID, *state); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
 
-	delMsg := tgbotapi.NewDeleteMessage(query.Message.Chat.ID, query.Message.MessageID)
-	h.bot.Send(delMsg)
+	markup := h.timePicker.Markup(state.TimePicker)
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, query.Message.MessageID, h.timePickerText(state.TimePicker), markup)
+	h.bot.Send(edit)
 
-	// h.sendConfirmation(query.Message.Chat.ID, state.TempEvent)
 	return nil
 }
 
-func (h *Handler) cancelTimeSelection(ctx context.Context, query *tgbotapi.CallbackQuery) error {
-	if err := h.stateRepo.DeleteState(ctx, query.From.ID); err != nil {
-		return fmt.Errorf("failed to delete state: %w", err)
+// timePickerText подпись над клавиатурой выбора времени.
+func (h *Handler) timePickerText(tp domain.TimePicker) string {
+	if !tp.Selected {
+		return "Выберите время или напишите его, например 18:30 или «в 7 вечера»:"
+	}
+
+	text := fmt.Sprintf("Выбрано время: %s", h.timePicker.Format(tp.TempHours, tp.TempMinutes))
+	if tp.Step == domain.TimePickerMinutes {
+		text += "\nУточните минуты или нажмите «Готово»"
 	}
-	delMsg := tgbotapi.NewDeleteMessage(query.Message.Chat.ID, query.Message.MessageID)
-	h.bot.Send(delMsg)
 
-	return h.sendDateCalendar(query.Message.Chat.ID)
+	return text
 }
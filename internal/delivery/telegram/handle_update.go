@@ -36,8 +36,22 @@ func (h *Handler) HandleUpdate(ctx context.Context, update *tgbotapi.Update) err
 		return h.handleHelpCommand(update)
 	case "new_event":
 		return h.startNewEvent(ctx, update)
+	case "templates":
+		return h.handleTemplatesCommand(ctx, update)
+	case "drafts":
+		return h.handleDraftsCommand(ctx, update)
 	case "list_events":
 		return h.listEvents(ctx, update)
+	case "checkin":
+		return h.handleCheckInCommand(ctx, update)
+	case "import":
+		return h.handleImportCommand(ctx, update)
+	case "backup":
+		return h.handleBackupCommand(ctx, update)
+	case "set_channel":
+		return h.handleSetChannelCommand(ctx, update)
+	case "back":
+		return h.handleBackCommand(ctx, update)
 	case "cancel":
 		return h.handleCancelCommand(ctx, update)
 	default:
@@ -7,17 +7,21 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	domain "github.com/binaryty/evbot/internal/domain/entities"
-	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/fsm"
 )
 
 // handleUserInput ...
 func (h *Handler) handleUserInput(ctx context.Context, update *tgbotapi.Update, text string) error {
-	state, err := h.stateRepo.GetState(ctx, update.Message.From.ID)
+	state, err := h.loadState(ctx, update.Message.From.ID, update.Message.Chat.ID)
 	if err != nil {
-		if errors.Is(err, repository.ErrStateNotFound) {
-			return nil
+		if err = ignoreStateGone(err); err != nil {
+			return fmt.Errorf("get state error: %w", err)
 		}
-		return fmt.Errorf("get state error: %w", err)
+		return nil
+	}
+
+	if !state.IsDraft() {
+		return h.handleDialogInput(ctx, update, text, *state)
 	}
 
 	defer func() {
@@ -26,14 +30,71 @@ func (h *Handler) handleUserInput(ctx context.Context, update *tgbotapi.Update,
 		}
 	}()
 
+	if err := h.eventFlow.Validate(fsm.State(state.Step), text); err != nil {
+		var verr *fsm.ValidationError
+		if errors.As(err, &verr) {
+			h.sendError(update.Message.Chat.ID, verr.Reason)
+			return nil
+		}
+		return err
+	}
+
 	switch state.Step {
 	case domain.StepTitle:
 		return h.handleTitleStep(ctx, update, text, *state)
 	case domain.StepDescription:
 		return h.handleDescriptionStep(ctx, update, text, *state)
+	case domain.StepMedia:
+		return h.handleMediaStep(ctx, update, *state)
+	case domain.StepDate:
+		return h.handleDateInputStep(ctx, update, text, *state)
 	case domain.StepTime:
-		return h.handleFinishEventCreation(ctx, update, text)
+		return h.handleTimeInputStep(ctx, update, text, *state)
+	case domain.StepQuestions:
+		return h.handleQuestionInput(ctx, update, text, *state)
 	default:
 		return nil
 	}
 }
+
+// handleDialogInput проверяет ввод валидатором шага служебного диалога
+// и передаёт сообщение его обработчику.
+func (h *Handler) handleDialogInput(ctx context.Context, update *tgbotapi.Update, text string, state domain.EventState) error {
+	msg := update.Message
+
+	m, ok := h.serviceFlows[state.Flow]
+	if !ok || !m.Has(fsm.State(state.Step)) {
+		// состояние сохранено прежней версией бота
+		if err := h.stateRepo.DeleteState(ctx, msg.From.ID); err != nil {
+			return fmt.Errorf("failed to delete state: %w", err)
+		}
+		h.sendError(msg.Chat.ID, "Действие устарело, начните его заново")
+		return nil
+	}
+
+	if err := m.Validate(fsm.State(state.Step), text); err != nil {
+		var verr *fsm.ValidationError
+		if errors.As(err, &verr) {
+			h.sendError(msg.Chat.ID, verr.Reason)
+			return nil
+		}
+		return err
+	}
+
+	switch state.Flow {
+	case domain.FlowBroadcast:
+		return h.handleBroadcastInput(ctx, update, state)
+	case domain.FlowCheckIn:
+		return h.handleCheckInInput(ctx, update, state)
+	case domain.FlowFeedback:
+		return h.handleFeedbackInput(ctx, update, state)
+	case domain.FlowRegistration:
+		return h.handleRegistrationInput(ctx, update, state)
+	case domain.FlowImport:
+		return h.handleImportInput(ctx, update, state)
+	case domain.FlowDraftName:
+		return h.handleDraftNameInput(ctx, update, state)
+	}
+
+	return nil
+}
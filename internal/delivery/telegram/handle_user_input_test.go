@@ -0,0 +1,94 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+func TestHandleDialogInput(t *testing.T) {
+	const user int64 = 10
+
+	private := &tgbotapi.Chat{ID: user, Type: "private"}
+
+	tests := []struct {
+		name  string
+		state domain.EventState
+		text  string
+		// wantStep шаг после ввода; пустой — диалог удалён.
+		wantStep string
+		wantText string
+	}{
+		{
+			name:     "state of old version",
+			state:    domain.EventState{Flow: domain.FlowCheckIn},
+			text:     "ABCD-EFGH-IJKL-MNOP",
+			wantText: "Действие устарело",
+		},
+		{
+			name:     "invalid comment",
+			state:    domain.EventState{Flow: domain.FlowFeedback, Step: string(stepFeedbackComment)},
+			text:     " ",
+			wantStep: string(stepFeedbackComment),
+			wantText: "Комментарий должен быть текстом",
+		},
+		{
+			name:     "text for choice question",
+			state:    domain.EventState{Flow: domain.FlowRegistration, Step: string(stepAnswerChoice)},
+			text:     "M",
+			wantStep: string(stepAnswerChoice),
+			wantText: "кнопками",
+		},
+		{
+			name:     "ticket extends check-in",
+			state:    domain.EventState{Flow: domain.FlowCheckIn, Step: string(stepCheckInTicket)},
+			text:     "привет",
+			wantStep: string(stepCheckInTicket),
+			wantText: "Не похоже на код билета",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{}
+			h := newTestHandler(t, api)
+			ctx := context.Background()
+
+			if err := h.stateRepo.SaveState(ctx, user, tt.state); err != nil {
+				t.Fatalf("SaveState: %v", err)
+			}
+
+			update := &tgbotapi.Update{Message: &tgbotapi.Message{
+				From: &tgbotapi.User{ID: user},
+				Chat: private,
+				Text: tt.text,
+			}}
+			if err := h.handleUserInput(ctx, update, tt.text); err != nil {
+				t.Fatalf("handleUserInput: %v", err)
+			}
+
+			state, err := h.stateRepo.GetState(ctx, user)
+			switch {
+			case tt.wantStep == "":
+				if !errors.Is(err, repository.ErrStateNotFound) {
+					t.Errorf("GetState error = %v, want ErrStateNotFound", err)
+				}
+			case err != nil:
+				t.Fatalf("GetState: %v", err)
+			case state.Step != tt.wantStep:
+				t.Errorf("step = %q, want %q", state.Step, tt.wantStep)
+			}
+
+			sent := api.sent(user)
+			if len(sent) != 1 || !strings.Contains(sent[0].params["text"], tt.wantText) {
+				t.Errorf("replies = %+v, want one containing %q", sent, tt.wantText)
+			}
+		})
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"log/slog"
+	"time"
 
 	"github.com/binaryty/evbot/internal/config"
+	"github.com/binaryty/evbot/internal/delivery/telegram/calendar"
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	"github.com/binaryty/evbot/internal/delivery/telegram/timepicker"
+	"github.com/binaryty/evbot/internal/fsm"
 	"github.com/binaryty/evbot/internal/repository"
 	"github.com/binaryty/evbot/internal/usecase"
 )
@@ -28,7 +33,23 @@ type Handler struct {
 	eventUC        *usecase.EventUseCase
 	registrationUC *usecase.RegistrationUseCase
 	userUC         *usecase.UserUseCase
+	channelUC      *usecase.ChannelUseCase
+	templateUC     *usecase.TemplateUseCase
+	feedbackUC     *usecase.FeedbackUseCase
+	backupUC       *usecase.BackupUseCase
 	stateRepo      repository.StateRepository
+	eventFlow      *fsm.Machine
+	// serviceFlows автоматы служебных диалогов по EventState.Flow.
+	serviceFlows map[string]*fsm.Machine
+	// loc часовой пояс, в котором распознаются даты, введённые текстом.
+	loc *time.Location
+	// callbacks кодирует данные всех инлайн-кнопок, router разбирает нажатия.
+	callbacks *callback.Codec
+	router    *callback.Router
+	// timePicker клавиатура выбора времени с настройками из конфигурации.
+	timePicker *timepicker.Picker
+	// calendar клавиатура выбора даты в локали из конфигурации.
+	calendar *calendar.Calendar
 }
 
 func NewHandler(
@@ -38,18 +59,48 @@ func NewHandler(
 	eventUC *usecase.EventUseCase,
 	registrationUC *usecase.RegistrationUseCase,
 	userUC *usecase.UserUseCase,
+	channelUC *usecase.ChannelUseCase,
+	templateUC *usecase.TemplateUseCase,
+	feedbackUC *usecase.FeedbackUseCase,
+	backupUC *usecase.BackupUseCase,
 	//userRepo repository.UserRepository,
 	stateRepo repository.StateRepository,
 ) *Handler {
-	return &Handler{
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	// без отдельного секрета ключ подписи выводится из токена бота:
+	// он тоже секретен и не меняется между перезапусками
+	secret := cfg.CallbackSecret
+	if secret == "" {
+		secret = "callback:" + cfg.BotToken
+	}
+	codec := callback.NewCodec([]byte(secret), cfg.CallbackTTL)
+
+	h := &Handler{
 		cfg:            cfg,
 		bot:            bot,
 		logger:         logger,
 		eventUC:        eventUC,
 		registrationUC: registrationUC,
 		userUC:         userUC,
+		channelUC:      channelUC,
+		templateUC:     templateUC,
+		feedbackUC:     feedbackUC,
+		backupUC:       backupUC,
 		stateRepo:      stateRepo,
+		eventFlow:      newEventFlow(),
+		serviceFlows:   newServiceFlows(),
+		loc:            loc,
+		callbacks:      codec,
+		timePicker:     timepicker.New(codec, actTime, cfg.TimePickerStep, cfg.Clock12h),
+		calendar:       calendar.New(codec, actCalendar, cfg.Locale),
 	}
+	h.router = h.newCallbackRouter()
+
+	return h
 }
 
 func (h *Handler) sendError(chatID int64, text string) {
@@ -0,0 +1,166 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/binaryty/evbot/internal/config"
+	"github.com/binaryty/evbot/internal/repository/memory"
+	"github.com/binaryty/evbot/internal/usecase"
+)
+
+// botID ID бота в поддельном Bot API.
+const botID int64 = 1000
+
+// apiCall запрос бота к Bot API.
+type apiCall struct {
+	method string
+	params map[string]string
+}
+
+// fakeAPI поддельный Bot API: запоминает запросы бота и отвечает на
+// getChat и getChatMember из заданных чатов и администраторов.
+type fakeAPI struct {
+	mu    sync.Mutex
+	calls []apiCall
+	// chats чаты по ID или @username.
+	chats map[string]tgbotapi.Chat
+	// admins администраторы чатов.
+	admins map[int64][]int64
+	// blocked пользователи, которые не писали боту: отправка им отклоняется.
+	blocked map[int64]bool
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	call := apiCall{method: r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], params: make(map[string]string)}
+	for k := range r.Form {
+		call.params[k] = r.Form.Get(k)
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	result, err := f.result(call)
+	f.mu.Unlock()
+
+	resp := map[string]any{"ok": err == nil, "result": result}
+	if err != nil {
+		resp["description"] = err.Error()
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// result ответ на запрос; на отправку и редактирование — сообщение.
+func (f *fakeAPI) result(call apiCall) (any, error) {
+	switch call.method {
+	case "getMe":
+		return tgbotapi.User{ID: botID, IsBot: true, UserName: "evbot"}, nil
+
+	case "getChat":
+		chat, ok := f.chats[call.params["chat_id"]]
+		if !ok {
+			return nil, fmt.Errorf("chat not found")
+		}
+		return chat, nil
+
+	case "getChatMember":
+		chatID, _ := strconv.ParseInt(call.params["chat_id"], 10, 64)
+		userID, _ := strconv.ParseInt(call.params["user_id"], 10, 64)
+
+		status := "member"
+		for _, id := range f.admins[chatID] {
+			if id == userID {
+				status = "administrator"
+			}
+		}
+		return tgbotapi.ChatMember{User: &tgbotapi.User{ID: userID}, Status: status}, nil
+	}
+
+	chatID, _ := strconv.ParseInt(call.params["chat_id"], 10, 64)
+	if f.blocked[chatID] {
+		return nil, fmt.Errorf("Forbidden: bot can't initiate conversation with a user")
+	}
+	return tgbotapi.Message{MessageID: len(f.calls), Chat: &tgbotapi.Chat{ID: chatID}}, nil
+}
+
+// sent сообщения, отправленные в чат chatID.
+func (f *fakeAPI) sent(chatID int64) []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []apiCall
+	for _, c := range f.calls {
+		if strings.HasPrefix(c.method, "send") && c.params["chat_id"] == strconv.FormatInt(chatID, 10) {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// newTestHandler обработчик на хранилище в памяти и поддельном Bot API.
+func newTestHandler(t *testing.T, api *fakeAPI) *Handler {
+	t.Helper()
+
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint("token", srv.URL+"/bot%s/%s")
+	if err != nil {
+		t.Fatalf("NewBotAPIWithAPIEndpoint: %v", err)
+	}
+
+	cfg := &config.Config{
+		BotToken:       "token",
+		Timezone:       "UTC",
+		TimePickerStep: 15,
+		Locale:         "ru",
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	s := memory.NewStore()
+	events := memory.NewEventRepository(s)
+	registrations := memory.NewRegistrationRepository(s)
+	questions := memory.NewQuestionRepository(s)
+	tx := memory.NewTransactor(s)
+
+	return NewHandler(
+		cfg,
+		bot,
+		logger,
+		usecase.NewEventUseCase(events, questions, tx),
+		usecase.NewRegistrationUseCase(events, registrations, questions, memory.NewNotificationRepository(s), NewNotifier(bot, logger), tx),
+		usecase.NewUserUseCase(memory.NewUserRepository(s)),
+		usecase.NewChannelUseCase(memory.NewChannelRepository(s), 0),
+		usecase.NewTemplateUseCase(memory.NewTemplateRepository(s), events),
+		usecase.NewFeedbackUseCase(memory.NewFeedbackRepository(s), registrations),
+		usecase.NewBackupUseCase(memory.NewBackupRepository()),
+		memory.NewStateRepository(s),
+	)
+}
+
+// command входящее сообщение с командой в чате chat.
+func command(chat *tgbotapi.Chat, from int64, text string) *tgbotapi.Update {
+	name := strings.Fields(text)[0]
+
+	return &tgbotapi.Update{Message: &tgbotapi.Message{
+		From:     &tgbotapi.User{ID: from, FirstName: "Анна"},
+		Chat:     chat,
+		Text:     text,
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(name)}},
+	}}
+}
@@ -0,0 +1,134 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"log/slog"
+	"strings"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// Notifier отправляет уведомления в личные сообщения: авторам событий — о регистрациях,
+// участникам — об отмене событий.
+type Notifier struct {
+	bot    *tgbotapi.BotAPI
+	logger *slog.Logger
+}
+
+func NewNotifier(bot *tgbotapi.BotAPI, logger *slog.Logger) *Notifier {
+	return &Notifier{
+		bot:    bot,
+		logger: logger,
+	}
+}
+
+// NotifyRegistration ...
+func (n *Notifier) NotifyRegistration(ctx context.Context, event domain.Event, change domain.RegistrationChange) error {
+	text := fmt.Sprintf("🔔 Новая регистрация на «%s»: %s", event.Title, userLabel(change.User))
+	if !change.Registered {
+		text = fmt.Sprintf("🔕 Отмена регистрации на «%s»: %s", event.Title, userLabel(change.User))
+	}
+
+	return n.send(event, text)
+}
+
+// NotifyDigest сообщает итог за период: кто в итоге записался и кто отказался.
+// Если пользователь записался и тут же отменил запись, он в сводку не попадает.
+func (n *Notifier) NotifyDigest(ctx context.Context, event domain.Event, changes []domain.RegistrationChange) error {
+	var (
+		order []int64
+		first = make(map[int64]domain.RegistrationChange)
+		last  = make(map[int64]domain.RegistrationChange)
+	)
+
+	for _, c := range changes {
+		if _, ok := first[c.User.ID]; !ok {
+			first[c.User.ID] = c
+			order = append(order, c.User.ID)
+		}
+		last[c.User.ID] = c
+	}
+
+	var joined, left []string
+	for _, id := range order {
+		// состояние до периода противоположно первому изменению
+		if first[id].Registered != last[id].Registered {
+			continue
+		}
+
+		if last[id].Registered {
+			joined = append(joined, userLabel(last[id].User))
+		} else {
+			left = append(left, userLabel(last[id].User))
+		}
+	}
+
+	if len(joined) == 0 && len(left) == 0 {
+		return nil
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🗓 Сводка регистраций на «%s»\n", event.Title))
+
+	if len(joined) > 0 {
+		text.WriteString(fmt.Sprintf("\n➕ Зарегистрировались (%d):\n• %s\n", len(joined), strings.Join(joined, "\n• ")))
+	}
+
+	if len(left) > 0 {
+		text.WriteString(fmt.Sprintf("\n➖ Отменили регистрацию (%d):\n• %s\n", len(left), strings.Join(left, "\n• ")))
+	}
+
+	return n.send(event, text.String())
+}
+
+// NotifyCancellation пишет каждому записавшемуся, включая ожидающих подтверждения;
+// автору, отменившему событие, сообщение не нужно.
+func (n *Notifier) NotifyCancellation(ctx context.Context, event domain.Event, participants []domain.Participant) error {
+	text := fmt.Sprintf("%s Событие «%s» %s отменено", EmCross, event.Title, event.Date.Format("02.01.2006 15:04"))
+
+	failed := 0
+	for _, p := range participants {
+		if p.ID == event.UserID {
+			continue
+		}
+
+		if _, err := n.bot.Send(tgbotapi.NewMessage(p.ID, text)); err != nil {
+			n.logger.Error("failed to notify participant",
+				slog.Int64("event_id", event.ID),
+				slog.Int64("user_id", p.ID),
+				slog.String("[ERROR]", err.Error()))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to notify %d participants", failed)
+	}
+
+	return nil
+}
+
+// send пишет автору события в личные сообщения.
+func (n *Notifier) send(event domain.Event, text string) error {
+	if _, err := n.bot.Send(tgbotapi.NewMessage(event.UserID, text)); err != nil {
+		n.logger.Error("failed to notify event author",
+			slog.Int64("event_id", event.ID),
+			slog.Int64("user_id", event.UserID),
+			slog.String("[ERROR]", err.Error()))
+
+		return fmt.Errorf("failed to notify event author: %w", err)
+	}
+
+	return nil
+}
+
+// userLabel имя пользователя для уведомлений: «Иван (@ivan)».
+func userLabel(u domain.User) string {
+	if u.UserName == "" {
+		return u.FirstName
+	}
+
+	return fmt.Sprintf("%s (@%s)", u.FirstName, u.UserName)
+}
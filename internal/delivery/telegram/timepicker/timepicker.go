@@ -1,71 +1,295 @@
+// Package timepicker инлайн-клавиатура выбора времени: сначала час, затем
+// минуты с заданным шагом. Состояние хранится в domain.TimePicker.
+//
+// Кнопки кодируются через callback.Codec под действием, переданным в New;
+// первый аргумент — команда пикера:
+//
+//	h <час>      выбрать час (0–23)
+//	m <минуты>   выбрать минуты (кратны шагу)
+//	back         вернуться от минут к часам
+//	ok           подтвердить время
+//	cancel       отказаться от выбора
 package timepicker
 
 import (
+	"errors"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
-	"github.com/binaryty/evbot/internal/delivery/telegram"
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
 	domain "github.com/binaryty/evbot/internal/domain/entities"
 )
 
-func GenerateTimePicker(tp *domain.TimePicker) tgbotapi.InlineKeyboardMarkup {
-	var timePicker [][]tgbotapi.InlineKeyboardButton
+const (
+	emOk    = "✅"
+	emCross = "❌"
+	emBack  = "◀️"
+	emMark  = "•"
+)
 
-	if tp.Step == "hours" {
-		timePicker = generateHours()
-	} else {
-		timePicker = generateMinutes()
+// DefaultStep шаг минут, если в настройках указан неподдерживаемый.
+const DefaultStep = 15
+
+// Steps допустимые шаги минут.
+var Steps = []int{5, 10, 15, 30}
+
+var ErrInvalidCallback = errors.New("invalid time picker callback")
+
+// Action команда пикера, закодированная в кнопке.
+type Action string
+
+const (
+	ActionHour    Action = "h"
+	ActionMinute  Action = "m"
+	ActionBack    Action = "back"
+	ActionConfirm Action = "ok"
+	ActionCancel  Action = "cancel"
+)
+
+// Callback разобранное нажатие.
+type Callback struct {
+	Action Action
+	Value  int
+}
+
+// Parse разбирает данные нажатия, закодированные пикером.
+func Parse(data callback.Data) (Callback, error) {
+	name, err := data.String(0)
+	if err != nil {
+		return Callback{}, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+	}
+
+	cb := Callback{Action: Action(name)}
+
+	switch cb.Action {
+	case ActionHour, ActionMinute:
+		if data.Len() != 2 {
+			return Callback{}, fmt.Errorf("%w: %s needs a value", ErrInvalidCallback, name)
+		}
+
+		if cb.Value, err = data.Int(1); err != nil {
+			return Callback{}, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+		}
+	case ActionBack, ActionConfirm, ActionCancel:
+		if data.Len() != 1 {
+			return Callback{}, fmt.Errorf("%w: unexpected arguments for %s", ErrInvalidCallback, name)
+		}
+	default:
+		return Callback{}, fmt.Errorf("%w: %s", ErrInvalidCallback, name)
+	}
+
+	return cb, nil
+}
+
+// Outcome результат обработки нажатия.
+type Outcome int
+
+const (
+	// Updated состояние изменилось, клавиатуру нужно перерисовать.
+	Updated Outcome = iota
+	// Confirmed время выбрано и подтверждено.
+	Confirmed
+	// Cancelled пользователь отказался от выбора.
+	Cancelled
+	// Incomplete подтверждение до выбора часа.
+	Incomplete
+)
+
+// Picker настройки отображения клавиатуры.
+type Picker struct {
+	codec   *callback.Codec
+	action  callback.Action
+	step    int
+	clock12 bool
+}
+
+// New создаёт пикер, кнопки которого кодируются под действием action,
+// с шагом минут step (5, 10, 15 или 30) и 12- или 24-часовым отображением.
+func New(codec *callback.Codec, action callback.Action, step int, clock12 bool) *Picker {
+	valid := false
+	for _, s := range Steps {
+		if s == step {
+			valid = true
+			break
+		}
 	}
 
-	timePicker = append(timePicker, []tgbotapi.InlineKeyboardButton{
-		tgbotapi.NewInlineKeyboardButtonData(
-			fmt.Sprintf("%s %s", telegram.EmOk, "Готово"),
-			"time_confirm",
-		),
-		tgbotapi.NewInlineKeyboardButtonData(
-			fmt.Sprintf("%s %s", telegram.EmCross, "Отмена"),
-			"time_cancel",
-		),
-	})
+	if !valid {
+		step = DefaultStep
+	}
 
-	t := tgbotapi.NewInlineKeyboardMarkup(timePicker...)
+	return &Picker{
+		codec:   codec,
+		action:  action,
+		step:    step,
+		clock12: clock12,
+	}
+}
 
-	return t
+// Reset начальное состояние: экран выбора часа.
+func (p *Picker) Reset() domain.TimePicker {
+	return domain.TimePicker{Step: domain.TimePickerHours}
 }
 
-func generateHours() [][]tgbotapi.InlineKeyboardButton {
+// Apply применяет нажатие к состоянию tp.
+func (p *Picker) Apply(tp *domain.TimePicker, cb Callback) (Outcome, error) {
+	switch cb.Action {
+	case ActionHour:
+		if cb.Value < 0 || cb.Value > 23 {
+			return Updated, fmt.Errorf("%w: hour %d", ErrInvalidCallback, cb.Value)
+		}
+
+		tp.TempHours = cb.Value
+		tp.Selected = true
+		tp.Step = domain.TimePickerMinutes
+
+		return Updated, nil
+
+	case ActionMinute:
+		if cb.Value < 0 || cb.Value > 59 || cb.Value%p.step != 0 {
+			return Updated, fmt.Errorf("%w: minute %d", ErrInvalidCallback, cb.Value)
+		}
+
+		if !tp.Selected {
+			return Incomplete, nil
+		}
+		tp.TempMinutes = cb.Value
+
+		return Updated, nil
+
+	case ActionBack:
+		tp.Step = domain.TimePickerHours
+
+		return Updated, nil
+
+	case ActionConfirm:
+		if !tp.Selected {
+			return Incomplete, nil
+		}
+
+		return Confirmed, nil
+
+	case ActionCancel:
+		return Cancelled, nil
+	}
+
+	return Updated, fmt.Errorf("%w: %s", ErrInvalidCallback, cb.Action)
+}
+
+// Markup клавиатура для текущего экрана.
+func (p *Picker) Markup(tp domain.TimePicker) tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
-	var row []tgbotapi.InlineKeyboardButton
+
+	controls := []tgbotapi.InlineKeyboardButton{
+		p.button(emOk+" Готово", Callback{Action: ActionConfirm}),
+		p.button(emCross+" Отмена", Callback{Action: ActionCancel}),
+	}
+
+	if tp.Step == domain.TimePickerMinutes {
+		rows = p.minutes(tp)
+		controls = append([]tgbotapi.InlineKeyboardButton{
+			p.button(emBack+" Часы", Callback{Action: ActionBack}),
+		}, controls...)
+	} else {
+		rows = p.hours(tp)
+	}
+
+	rows = append(rows, controls)
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// Format время в выбранном формате отображения.
+func (p *Picker) Format(hour, minute int) string {
+	if !p.clock12 {
+		return fmt.Sprintf("%02d:%02d", hour, minute)
+	}
+
+	h, suffix := to12(hour)
+
+	return fmt.Sprintf("%d:%02d %s", h, minute, suffix)
+}
+
+// to12 час в 12-часовом формате и суффикс AM/PM.
+func to12(hour int) (int, string) {
+	suffix := "AM"
+	if hour >= 12 {
+		suffix = "PM"
+	}
+
+	h := hour % 12
+	if h == 0 {
+		h = 12
+	}
+
+	return h, suffix
+}
+
+// hours сетка часов по 4 в ряд.
+func (p *Picker) hours(tp domain.TimePicker) [][]tgbotapi.InlineKeyboardButton {
+	var labels []string
+	var callbacks []Callback
 
 	for h := 0; h < 24; h++ {
-		btn := tgbotapi.NewInlineKeyboardButtonData(
-			fmt.Sprintf("%02d", h),
-			fmt.Sprintf("time_h:%02d", h),
-		)
-		row = append(row, btn)
+		label := fmt.Sprintf("%02d", h)
+		if p.clock12 {
+			h12, suffix := to12(h)
+			label = fmt.Sprintf("%d %s", h12, suffix)
+		}
 
-		if len(row) == 4 {
-			rows = append(rows, row)
-			row = []tgbotapi.InlineKeyboardButton{}
+		if tp.Selected && tp.TempHours == h {
+			label = emMark + label + emMark
 		}
+
+		labels = append(labels, label)
+		callbacks = append(callbacks, Callback{Action: ActionHour, Value: h})
 	}
 
-	return rows
+	return p.grid(labels, callbacks)
+}
+
+// minutes сетка минут выбранного часа по 4 в ряд.
+func (p *Picker) minutes(tp domain.TimePicker) [][]tgbotapi.InlineKeyboardButton {
+	var labels []string
+	var callbacks []Callback
+
+	for m := 0; m < 60; m += p.step {
+		label := p.Format(tp.TempHours, m)
+		if tp.TempMinutes == m {
+			label = emMark + label + emMark
+		}
+
+		labels = append(labels, label)
+		callbacks = append(callbacks, Callback{Action: ActionMinute, Value: m})
+	}
+
+	return p.grid(labels, callbacks)
 }
 
-func generateMinutes() [][]tgbotapi.InlineKeyboardButton {
-	minutes := []int{0, 15, 30, 45}
+func (p *Picker) grid(labels []string, callbacks []Callback) [][]tgbotapi.InlineKeyboardButton {
 	var rows [][]tgbotapi.InlineKeyboardButton
 	var row []tgbotapi.InlineKeyboardButton
 
-	for _, m := range minutes {
-		btn := tgbotapi.NewInlineKeyboardButtonData(
-			fmt.Sprintf("%02d", m),
-			fmt.Sprintf("time:%02d", m),
-		)
-		row = append(row, btn)
+	for i := range labels {
+		row = append(row, p.button(labels[i], callbacks[i]))
+
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
 	}
-	rows = append(rows, row)
 
 	return rows
 }
+
+func (p *Picker) button(text string, cb Callback) tgbotapi.InlineKeyboardButton {
+	if cb.Action == ActionHour || cb.Action == ActionMinute {
+		return p.codec.Button(text, p.action, string(cb.Action), cb.Value)
+	}
+
+	return p.codec.Button(text, p.action, string(cb.Action))
+}
@@ -0,0 +1,264 @@
+package timepicker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/binaryty/evbot/internal/delivery/telegram/callback"
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+const testAction callback.Action = "tp"
+
+func newTestCodec() *callback.Codec {
+	return callback.NewCodec([]byte("secret"), time.Hour)
+}
+
+func TestParse(t *testing.T) {
+	codec := newTestCodec()
+
+	tests := []struct {
+		name    string
+		args    []interface{}
+		want    Callback
+		wantErr bool
+	}{
+		{name: "hour", args: []interface{}{"h", 7}, want: Callback{Action: ActionHour, Value: 7}},
+		{name: "minute", args: []interface{}{"m", 45}, want: Callback{Action: ActionMinute, Value: 45}},
+		{name: "back", args: []interface{}{"back"}, want: Callback{Action: ActionBack}},
+		{name: "confirm", args: []interface{}{"ok"}, want: Callback{Action: ActionConfirm}},
+		{name: "cancel", args: []interface{}{"cancel"}, want: Callback{Action: ActionCancel}},
+
+		{name: "no command", args: nil, wantErr: true},
+		{name: "unknown command", args: []interface{}{"jump"}, wantErr: true},
+		{name: "hour without value", args: []interface{}{"h"}, wantErr: true},
+		{name: "hour with two values", args: []interface{}{"h", 7, 8}, wantErr: true},
+		{name: "hour is not a number", args: []interface{}{"h", "-"}, wantErr: true},
+		{name: "back with value", args: []interface{}{"back", 1}, wantErr: true},
+		{name: "confirm with value", args: []interface{}{"ok", "now"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := codec.Decode(codec.MustEncode(testAction, tt.args...))
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+
+			got, err := Parse(data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCallback) {
+					t.Errorf("Parse(%v) error = %v, want ErrInvalidCallback", tt.args, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%v): %v", tt.args, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	hours := domain.TimePicker{Step: domain.TimePickerHours}
+	picked := domain.TimePicker{Step: domain.TimePickerMinutes, TempHours: 18, Selected: true}
+
+	tests := []struct {
+		name    string
+		step    int
+		state   domain.TimePicker
+		cb      Callback
+		want    Outcome
+		wantTP  domain.TimePicker
+		wantErr bool
+	}{
+		{
+			name: "pick hour", step: 15, state: hours,
+			cb:     Callback{Action: ActionHour, Value: 18},
+			want:   Updated,
+			wantTP: picked,
+		},
+		{
+			name: "pick midnight", step: 15, state: hours,
+			cb:     Callback{Action: ActionHour, Value: 0},
+			want:   Updated,
+			wantTP: domain.TimePicker{Step: domain.TimePickerMinutes, Selected: true},
+		},
+		{
+			name: "pick minutes", step: 15, state: picked,
+			cb:     Callback{Action: ActionMinute, Value: 45},
+			want:   Updated,
+			wantTP: domain.TimePicker{Step: domain.TimePickerMinutes, TempHours: 18, TempMinutes: 45, Selected: true},
+		},
+		{
+			name: "minutes of smaller step", step: 5, state: picked,
+			cb:     Callback{Action: ActionMinute, Value: 55},
+			want:   Updated,
+			wantTP: domain.TimePicker{Step: domain.TimePickerMinutes, TempHours: 18, TempMinutes: 55, Selected: true},
+		},
+		{
+			name: "minutes before hour", step: 15, state: hours,
+			cb:     Callback{Action: ActionMinute, Value: 30},
+			want:   Incomplete,
+			wantTP: hours,
+		},
+		{
+			name: "back to hours", step: 15, state: picked,
+			cb:     Callback{Action: ActionBack},
+			want:   Updated,
+			wantTP: domain.TimePicker{Step: domain.TimePickerHours, TempHours: 18, Selected: true},
+		},
+		{
+			name: "confirm", step: 15, state: picked,
+			cb:     Callback{Action: ActionConfirm},
+			want:   Confirmed,
+			wantTP: picked,
+		},
+		{
+			name: "confirm before hour", step: 15, state: hours,
+			cb:     Callback{Action: ActionConfirm},
+			want:   Incomplete,
+			wantTP: hours,
+		},
+		{
+			name: "cancel", step: 15, state: picked,
+			cb:     Callback{Action: ActionCancel},
+			want:   Cancelled,
+			wantTP: picked,
+		},
+
+		// данные кнопок подписаны, но пикер не доверяет значениям
+		{name: "hour past 23", step: 15, state: hours, cb: Callback{Action: ActionHour, Value: 24}, wantTP: hours, wantErr: true},
+		{name: "negative hour", step: 15, state: hours, cb: Callback{Action: ActionHour, Value: -1}, wantTP: hours, wantErr: true},
+		{name: "minute past 59", step: 15, state: picked, cb: Callback{Action: ActionMinute, Value: 60}, wantTP: picked, wantErr: true},
+		{name: "negative minute", step: 15, state: picked, cb: Callback{Action: ActionMinute, Value: -15}, wantTP: picked, wantErr: true},
+		{name: "minute off step", step: 15, state: picked, cb: Callback{Action: ActionMinute, Value: 50}, wantTP: picked, wantErr: true},
+		{name: "unsupported step falls back", step: 7, state: picked, cb: Callback{Action: ActionMinute, Value: 35}, wantTP: picked, wantErr: true},
+		{name: "unknown action", step: 15, state: picked, cb: Callback{Action: "jump"}, wantTP: picked, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(newTestCodec(), testAction, tt.step, false)
+
+			tp := tt.state
+			got, err := p.Apply(&tp, tt.cb)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCallback) {
+					t.Errorf("Apply(%+v) error = %v, want ErrInvalidCallback", tt.cb, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Apply(%+v): %v", tt.cb, err)
+				}
+				if got != tt.want {
+					t.Errorf("Apply(%+v) = %v, want %v", tt.cb, got, tt.want)
+				}
+			}
+
+			if tp != tt.wantTP {
+				t.Errorf("state = %+v, want %+v", tp, tt.wantTP)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		clock12      bool
+		want         string
+	}{
+		{9, 5, false, "09:05"},
+		{0, 0, false, "00:00"},
+		{23, 45, false, "23:45"},
+		{0, 5, true, "12:05 AM"},
+		{9, 30, true, "9:30 AM"},
+		{12, 0, true, "12:00 PM"},
+		{23, 45, true, "11:45 PM"},
+	}
+
+	for _, tt := range tests {
+		p := New(newTestCodec(), testAction, 15, tt.clock12)
+		if got := p.Format(tt.hour, tt.minute); got != tt.want {
+			t.Errorf("Format(%d, %d) 12h=%v = %q, want %q", tt.hour, tt.minute, tt.clock12, got, tt.want)
+		}
+	}
+}
+
+func TestMarkup(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    int
+		clock12 bool
+		state   domain.TimePicker
+		buttons int
+		labels  map[int]string
+	}{
+		{
+			name: "hours", step: 15, state: domain.TimePicker{Step: domain.TimePickerHours},
+			buttons: 24 + 2,
+			labels:  map[int]string{0: "00", 23: "23"},
+		},
+		{
+			name: "hours 12h", step: 15, clock12: true,
+			state:   domain.TimePicker{Step: domain.TimePickerHours, TempHours: 13, Selected: true},
+			buttons: 24 + 2,
+			labels:  map[int]string{0: "12 AM", 12: "12 PM", 13: emMark + "1 PM" + emMark},
+		},
+		{
+			name: "minutes", step: 15,
+			state:   domain.TimePicker{Step: domain.TimePickerMinutes, TempHours: 18, TempMinutes: 30, Selected: true},
+			buttons: 4 + 3,
+			labels:  map[int]string{0: "18:00", 2: emMark + "18:30" + emMark},
+		},
+		{
+			name: "minutes of unsupported step", step: 7,
+			state:   domain.TimePicker{Step: domain.TimePickerMinutes, TempHours: 7, Selected: true},
+			buttons: 60/DefaultStep + 3,
+		},
+		{
+			name: "minutes 12h", step: 30, clock12: true,
+			state:   domain.TimePicker{Step: domain.TimePickerMinutes, TempHours: 0, TempMinutes: 30, Selected: true},
+			buttons: 2 + 3,
+			labels:  map[int]string{0: "12:00 AM", 1: emMark + "12:30 AM" + emMark},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := newTestCodec()
+			p := New(codec, testAction, tt.step, tt.clock12)
+
+			var texts []string
+			for _, row := range p.Markup(tt.state).InlineKeyboard {
+				for _, b := range row {
+					texts = append(texts, b.Text)
+
+					// каждая кнопка разбирается обратно
+					data, err := codec.Decode(*b.CallbackData)
+					if err != nil {
+						t.Fatalf("Decode(%q): %v", b.Text, err)
+					}
+					if _, err := Parse(data); err != nil {
+						t.Errorf("Parse(%q): %v", b.Text, err)
+					}
+				}
+			}
+
+			if len(texts) != tt.buttons {
+				t.Fatalf("buttons = %d, want %d: %v", len(texts), tt.buttons, texts)
+			}
+			for i, want := range tt.labels {
+				if texts[i] != want {
+					t.Errorf("button %d = %q, want %q", i, texts[i], want)
+				}
+			}
+		})
+	}
+}
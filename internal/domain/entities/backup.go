@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Snapshot резервная копия базы: строки таблиц с постоянными данными.
+// Незавершённые диалоги и очередь сводок в копию не входят.
+type Snapshot struct {
+	// Version версия формата копии.
+	Version   int         `json:"version"`
+	CreatedAt time.Time   `json:"created_at"`
+	Tables    []TableDump `json:"tables"`
+}
+
+// TableDump строки одной таблицы; значения идут в порядке Columns.
+type TableDump struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// EventPost анонс события, опубликованный в канале.
+type EventPost struct {
+	EventID   int64
+	ChannelID int64
+	MessageID int
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Draft сохранённый черновик диалога пользователя.
+type Draft struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	State     EventState
+	UpdatedAt time.Time
+	// Active черновик, с которым пользователь работает сейчас.
+	Active bool
+}
@@ -9,4 +9,18 @@ var (
 	ErrRegistrationNotFound   = errors.New("registration not found")
 	ErrParticipantNotFound    = errors.New("participant not found")
 	ErrConcurrentModification = errors.New("concurrent modification detected")
+	ErrChannelNotFound        = errors.New("channel not found")
+	ErrStateExpired           = errors.New("state expired")
+	ErrTemplateNotFound       = errors.New("template not found")
+	ErrAccessDenied           = errors.New("access denied")
+	ErrInvalidNotifyMode      = errors.New("invalid notify mode")
+	ErrTicketNotFound         = errors.New("ticket not found")
+	ErrAlreadyCheckedIn       = errors.New("already checked in")
+	ErrInvalidRating          = errors.New("invalid rating")
+	ErrFeedbackNotFound       = errors.New("feedback not found")
+	ErrAnswersRequired        = errors.New("answers to registration questions are required")
+	ErrInvalidAnswer          = errors.New("invalid answer")
+	ErrAlreadyRegistered      = errors.New("already registered")
+	ErrRegistrationPending    = errors.New("registration is pending approval")
+	ErrUnsupportedBackup      = errors.New("unsupported backup format")
 )
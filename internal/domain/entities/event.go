@@ -1,28 +1,87 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 const (
 	StepTitle       = "title"
 	StepDescription = "description"
+	StepMedia       = "media"
 	StepDate        = "date"
 	StepTime        = "time"
+	StepQuestions   = "questions"
+	StepConfirm     = "confirm"
 	StepCompleted   = "completed"
 )
 
+const (
+	// FlowEvent мастер создания события (значение по умолчанию).
+	FlowEvent = ""
+	// FlowBroadcast ожидание сообщения для рассылки участникам события.
+	FlowBroadcast = "broadcast"
+	// FlowCheckIn режим отметки участников по билетам.
+	FlowCheckIn = "checkin"
+	// FlowFeedback ожидание комментария к оценке события.
+	FlowFeedback = "feedback"
+	// FlowRegistration ответы на вопросы события перед регистрацией.
+	FlowRegistration = "registration"
+	// FlowImport ожидание файла с событиями и подтверждение импорта.
+	FlowImport = "import"
+	// FlowDraftName ожидание нового имени черновика.
+	FlowDraftName = "draft_name"
+)
+
+// GlobalSpace пространство событий, созданных в личных сообщениях с ботом.
+// События групповых чатов хранятся в пространстве с ID этого чата.
+const GlobalSpace int64 = 0
+
 type Event struct {
 	ID          int64
 	UserID      int64
+	ChatID      int64
 	Title       string
 	Description string
-	Date        time.Time
-	CreatedAt   time.Time
+	// PhotoFileID обложка (афиша) события, file_id Telegram.
+	PhotoFileID string
+	// DocumentFileID вложение (например, программа в PDF), file_id Telegram.
+	DocumentFileID string
+	Date           time.Time
+	// NotifyMode уведомления автора о регистрациях.
+	NotifyMode NotifyMode
+	// RequiresApproval заявки на участие подтверждает автор события.
+	RequiresApproval bool
+	// Questions вопросы участникам; хранятся отдельно и заполняются
+	// только в черновике и при создании события.
+	Questions []Question
+	CreatedAt time.Time
 }
 
 type EventState struct {
+	// Flow диалог, к которому относится состояние: мастер события или служебный
+	// диалог; Step — состояние его автомата.
+	Flow         string
 	Step         string
 	TempEvent    Event
 	TimePicker   TimePicker
 	SelectedDate time.Time
-	CreatedAt    time.Time
+	// Editing шаг открыт из предпросмотра: после ввода вернуться к подтверждению.
+	Editing bool
+	// KeepTime время события уже известно (копия или шаблон):
+	// после выбора даты шаг времени пропускается.
+	KeepTime bool
+	// DraftName имя черновика, заданное пользователем; если оно пустое,
+	// черновик называется по событию.
+	DraftName string `json:",omitempty"`
+	// Payload данные служебного диалога в JSON; тип данных у каждого диалога свой.
+	Payload json.RawMessage `json:",omitempty"`
+	// CreatedAt момент последнего сохранения состояния, заполняется репозиторием.
+	CreatedAt time.Time
+}
+
+// IsDraft состояние относится к мастеру события и хранится как черновик.
+// Служебные диалоги (рассылка, отметка, опрос, импорт) черновиками не являются.
+func (s EventState) IsDraft() bool {
+	return s.Flow == FlowEvent
 }
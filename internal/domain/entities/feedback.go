@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Feedback оценка события участником и необязательный комментарий.
+type Feedback struct {
+	EventID   int64
+	User      User
+	Rating    int
+	Comment   string
+	CreatedAt time.Time
+}
+
+// FeedbackSummary сводка отзывов о событии для автора.
+type FeedbackSummary struct {
+	Count   int
+	Average float64
+	// Distribution число оценок по звёздам: индекс 0 — одна звезда.
+	Distribution [MaxRating]int
+	// Comments отзывы с комментариями, новые первыми.
+	Comments []Feedback
+}
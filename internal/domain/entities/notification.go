@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// NotifyMode как автор события узнаёт о регистрациях и отменах.
+type NotifyMode string
+
+const (
+	// NotifyOff уведомления выключены (значение по умолчанию).
+	NotifyOff NotifyMode = "off"
+	// NotifyInstant сообщение автору сразу после каждой регистрации или отмены.
+	NotifyInstant NotifyMode = "instant"
+	// NotifyDigest изменения копятся и приходят одной сводкой раз в день.
+	NotifyDigest NotifyMode = "digest"
+)
+
+// RegistrationChange регистрация или отмена регистрации на событие.
+type RegistrationChange struct {
+	ID         int64
+	EventID    int64
+	User       User
+	Registered bool
+	CreatedAt  time.Time
+}
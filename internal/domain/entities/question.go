@@ -0,0 +1,33 @@
+package domain
+
+// QuestionKind тип вопроса при регистрации.
+type QuestionKind string
+
+const (
+	// QuestionText ответ свободным текстом.
+	QuestionText QuestionKind = "text"
+	// QuestionSingle выбор одного варианта.
+	QuestionSingle QuestionKind = "single"
+	// QuestionMulti выбор одного или нескольких вариантов.
+	QuestionMulti QuestionKind = "multi"
+)
+
+// Question вопрос, на который участник отвечает при регистрации на событие.
+type Question struct {
+	ID       int64
+	EventID  int64
+	Position int
+	Kind     QuestionKind
+	Text     string
+	// Options варианты ответа для вопросов с выбором.
+	Options []string
+}
+
+// AnswerSeparator разделитель выбранных вариантов в ответе с множественным выбором.
+const AnswerSeparator = "; "
+
+// Answer ответ участника на вопрос; выбранные варианты перечисляются через AnswerSeparator.
+type Answer struct {
+	QuestionID int64
+	Value      string
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Template шаблон события: всё, кроме даты, которую выбирают при создании.
+type Template struct {
+	ID             int64
+	UserID         int64
+	ChatID         int64
+	Title          string
+	Description    string
+	PhotoFileID    string
+	DocumentFileID string
+	Hour           int
+	Minute         int
+	CreatedAt      time.Time
+}
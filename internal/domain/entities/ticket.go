@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Ticket билет участника: выдаётся при регистрации и предъявляется на входе.
+type Ticket struct {
+	EventID int64
+	UserID  int64
+	Token   string
+	// Status билет заявки, ещё не подтверждённой автором, на входе недействителен.
+	Status RegistrationStatus
+	// CheckedInAt момент отметки на входе, нулевой — участник ещё не пришёл.
+	CheckedInAt time.Time
+}
@@ -1,10 +1,16 @@
 package domain
 
-import "time"
+const (
+	TimePickerHours   = "hours"
+	TimePickerMinutes = "minutes"
+)
 
+// TimePicker состояние клавиатуры выбора времени.
 type TimePicker struct {
-	SelectedTime time.Time
-	TempHours    int
-	TempMinutes  int
-	Step         string
+	// Step открытый экран: TimePickerHours или TimePickerMinutes.
+	Step        string
+	TempHours   int
+	TempMinutes int
+	// Selected час выбран, время можно подтвердить.
+	Selected bool
 }
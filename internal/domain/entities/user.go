@@ -8,7 +8,23 @@ type User struct {
 	UserName  string
 }
 
+// RegistrationStatus состояние регистрации пользователя на событие.
+type RegistrationStatus string
+
+const (
+	// RegistrationNone пользователь не зарегистрирован.
+	RegistrationNone RegistrationStatus = ""
+	// RegistrationPending заявка ждёт решения автора события.
+	RegistrationPending  RegistrationStatus = "pending"
+	RegistrationApproved RegistrationStatus = "approved"
+)
+
 type Participant struct {
 	User
+	Status       RegistrationStatus
 	RegisteredAt time.Time
+	// CheckedInAt момент отметки на входе, нулевой — участник ещё не пришёл.
+	CheckedInAt time.Time
+	// Answers ответы на вопросы события.
+	Answers []Answer
 }
@@ -0,0 +1,184 @@
+package eventimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// Столбцы таблицы событий.
+const (
+	columnTitle       = "title"
+	columnDescription = "description"
+	columnDate        = "date"
+	columnTime        = "time"
+	columnStart       = "start"
+)
+
+// columnAliases названия столбцов в заголовке, в нижнем регистре.
+var columnAliases = map[string]string{
+	"title":          columnTitle,
+	"name":           columnTitle,
+	"summary":        columnTitle,
+	"subject":        columnTitle,
+	"название":       columnTitle,
+	"событие":        columnTitle,
+	"тема":           columnTitle,
+	"description":    columnDescription,
+	"details":        columnDescription,
+	"описание":       columnDescription,
+	"date":           columnDate,
+	"start date":     columnDate,
+	"дата":           columnDate,
+	"time":           columnTime,
+	"start time":     columnTime,
+	"время":          columnTime,
+	"start":          columnStart,
+	"datetime":       columnStart,
+	"начало":         columnStart,
+	"дата и время":   columnStart,
+	"дата, время":    columnStart,
+	"дата начала":    columnDate,
+	"время начала":   columnTime,
+	"start datetime": columnStart,
+}
+
+var (
+	dateLayouts = []string{"2006-01-02", "02.01.2006", "2.1.2006", "02.01.06", "01/02/2006", "1/2/2006"}
+	timeLayouts = []string{"15:04", "15:04:05", "3:04 PM", "3:04PM", "3:04:05 PM"}
+)
+
+// ParseCSV читает таблицу с заголовком. Нужны столбцы названия и даты
+// (отдельно дата и время или вместе); разделитель — запятая или точка с запятой.
+// Даты через косую черту читаются по-американски: месяц/день/год.
+func ParseCSV(r io.Reader, loc *time.Location) ([]domain.Event, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+
+	first := data
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		first = first[:i]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	// таблицы из русского Excel разделены точкой с запятой
+	if bytes.Count(first, []byte(";")) > bytes.Count(first, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	names, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrNoEvents
+	}
+	if err != nil {
+		return nil, &LineError{Line: 1, Err: err}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range names {
+		if c, ok := columnAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, dup := columns[c]; !dup {
+				columns[c] = i
+			}
+		}
+	}
+
+	if _, ok := columns[columnTitle]; !ok {
+		return nil, fmt.Errorf("%w: title", ErrMissingColumn)
+	}
+
+	_, hasDate := columns[columnDate]
+	_, hasStart := columns[columnStart]
+	if !hasDate && !hasStart {
+		return nil, fmt.Errorf("%w: date", ErrMissingColumn)
+	}
+
+	var events []domain.Event
+
+	// line номер записи таблицы; заголовок — первая
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &LineError{Line: line, Err: err}
+		}
+
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		date, err := parseCSVTime(field(columnStart), field(columnDate), field(columnTime), loc)
+		if err != nil {
+			return nil, &LineError{Line: line, Err: err}
+		}
+
+		events = append(events, domain.Event{
+			Title:       field(columnTitle),
+			Description: field(columnDescription),
+			Date:        date,
+		})
+	}
+
+	return events, nil
+}
+
+// parseCSVTime дата и время из общего столбца start или из отдельных
+// столбцов; без времени событие начинается в 00:00.
+func parseCSVTime(start, date, clock string, loc *time.Location) (time.Time, error) {
+	if start != "" {
+		if t, err := time.Parse(time.RFC3339, start); err == nil {
+			return wallClock(t, loc), nil
+		}
+
+		date, clock = start, ""
+		if i := strings.IndexAny(start, " T"); i >= 0 {
+			date, clock = start[:i], strings.TrimSpace(start[i+1:])
+		}
+	}
+
+	day, ok := parseLayouts(dateLayouts, date)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, date)
+	}
+
+	if clock == "" {
+		return day, nil
+	}
+
+	t, ok := parseLayouts(timeLayouts, strings.ToUpper(clock))
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, clock)
+	}
+
+	return day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), nil
+}
+
+func parseLayouts(layouts []string, value string) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
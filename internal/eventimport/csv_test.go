@@ -0,0 +1,178 @@
+package eventimport
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCSV(t *testing.T) {
+	type event struct {
+		title       string
+		description string
+		date        time.Time
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []event
+	}{
+		{
+			name:  "separate date and time",
+			input: "title,description,date,time\nМитап,Про Go,2026-11-20,19:00\n",
+			want:  []event{{title: "Митап", description: "Про Go", date: wall(2026, 11, 20, 19, 0)}},
+		},
+		{
+			name: "russian excel with semicolons",
+			input: utf8BOM + "Название;Описание;Дата;Время\r\n" +
+				"Субботник;Берём перчатки;25.04.2026;10:00\r\n" +
+				"Ярмарка;\"Вход; свободный\";26.04.26;12:30\r\n",
+			want: []event{
+				{title: "Субботник", description: "Берём перчатки", date: wall(2026, 4, 25, 10, 0)},
+				{title: "Ярмарка", description: "Вход; свободный", date: wall(2026, 4, 26, 12, 30)},
+			},
+		},
+		{
+			name:  "american date and 12-hour clock",
+			input: "Subject,Start Date,Start Time\nStandup,11/3/2026,9:30 am\n",
+			want:  []event{{title: "Standup", date: wall(2026, 11, 3, 9, 30)}},
+		},
+		{
+			name:  "date without time starts at midnight",
+			input: "title,date\nДень рождения,2026-12-01\n",
+			want:  []event{{title: "День рождения", date: wall(2026, 12, 1, 0, 0)}},
+		},
+		{
+			name:  "combined start column",
+			input: "name,start\nДоклад,2026-11-20 19:00\nВстреча,20.11.2026 08:15\n",
+			want: []event{
+				{title: "Доклад", date: wall(2026, 11, 20, 19, 0)},
+				{title: "Встреча", date: wall(2026, 11, 20, 8, 15)},
+			},
+		},
+		{
+			name:  "rfc3339 start converted to user zone",
+			input: "title,start\nСозвон,2026-11-20T16:00:00Z\n",
+			want:  []event{{title: "Созвон", date: wall(2026, 11, 20, 19, 0)}},
+		},
+		{
+			name:  "blank rows and short records",
+			input: "title,date,description\n\n,,\nЛекция,2026-11-20\n",
+			want:  []event{{title: "Лекция", date: wall(2026, 11, 20, 0, 0)}},
+		},
+		{
+			name:  "first matching column wins",
+			input: "Тема,Название,Дата\nОсновное,Запасное,2026-11-20\n",
+			want:  []event{{title: "Основное", date: wall(2026, 11, 20, 0, 0)}},
+		},
+		{
+			name:  "header only",
+			input: "title,date\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ParseCSV(strings.NewReader(tt.input), moscow)
+			if err != nil {
+				t.Fatalf("ParseCSV: %v", err)
+			}
+
+			if len(events) != len(tt.want) {
+				t.Fatalf("ParseCSV = %d events, want %d: %+v", len(events), len(tt.want), events)
+			}
+			for i, want := range tt.want {
+				got := events[i]
+				if got.Title != want.title {
+					t.Errorf("event %d title = %q, want %q", i, got.Title, want.title)
+				}
+				if got.Description != want.description {
+					t.Errorf("event %d description = %q, want %q", i, got.Description, want.description)
+				}
+				if !got.Date.Equal(want.date) || got.Date.Location() != time.UTC {
+					t.Errorf("event %d date = %v, want %v", i, got.Date, want.date)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCSVInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErr  error
+		wantLine int
+	}{
+		{name: "empty file", input: "", wantErr: ErrNoEvents},
+		{name: "no title column", input: "date,time\n2026-11-20,19:00\n", wantErr: ErrMissingColumn},
+		{name: "no date column", input: "title,time\nМитап,19:00\n", wantErr: ErrMissingColumn},
+		{name: "bad date", input: "title,date\nМитап,2026-11-20\nЛекция,20 ноября\n", wantErr: ErrInvalidDate, wantLine: 3},
+		{name: "bad time", input: "title,date,time\nМитап,2026-11-20,25:00\n", wantErr: ErrInvalidDate, wantLine: 2},
+		{name: "unterminated quote", input: "title,date\n\"Митап,2026-11-20\n", wantLine: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.input), moscow)
+			if err == nil {
+				t.Fatal("ParseCSV error = nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseCSV error = %v, want %v", err, tt.wantErr)
+			}
+
+			var lineErr *LineError
+			if tt.wantLine == 0 {
+				if errors.As(err, &lineErr) {
+					t.Errorf("ParseCSV error = %v, want no line", err)
+				}
+				return
+			}
+			if !errors.As(err, &lineErr) || lineErr.Line != tt.wantLine {
+				t.Errorf("ParseCSV error = %v, want line %d", err, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	var many strings.Builder
+	many.WriteString("title,date\n")
+	for i := 0; i <= MaxEvents; i++ {
+		fmt.Fprintf(&many, "Событие %d,2026-11-20\n", i)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		input   string
+		want    int
+		wantErr error
+	}{
+		{name: "csv", file: "events.CSV", input: "title,date\nМитап,2026-11-20\n", want: 1},
+		{name: "ics", file: "calendar.ics", input: calendar("BEGIN:VEVENT", "DTSTART:20261120T190000", "END:VEVENT"), want: 1},
+		{name: "unsupported", file: "events.xlsx", input: "title,date\n", wantErr: ErrUnsupportedFormat},
+		{name: "no events", file: "calendar.ics", input: calendar(), wantErr: ErrNoEvents},
+		{name: "too many events", file: "events.csv", input: many.String(), wantErr: ErrTooManyEvents},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Supported(tt.file); got != (tt.wantErr != ErrUnsupportedFormat) {
+				t.Errorf("Supported(%q) = %v", tt.file, got)
+			}
+
+			events, err := Parse(tt.file, strings.NewReader(tt.input), moscow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.file, err, tt.wantErr)
+			}
+			if len(events) != tt.want {
+				t.Errorf("Parse(%q) = %d events, want %d", tt.file, len(events), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package eventimport читает события из файлов других систем: календарей
+// iCalendar (.ics) и таблиц CSV. Даты событий возвращаются так же, как их
+// хранит бот: время на часах часового пояса пользователей в виде UTC.
+package eventimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// MaxEvents предел событий в одном файле.
+const MaxEvents = 100
+
+// utf8BOM метка порядка байтов, которой Excel и некоторые календари начинают файл.
+const utf8BOM = "\xef\xbb\xbf"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	ErrNoEvents          = errors.New("no events found")
+	ErrTooManyEvents     = fmt.Errorf("more than %d events", MaxEvents)
+	ErrMissingColumn     = errors.New("missing required column")
+	ErrInvalidDate       = errors.New("invalid date")
+)
+
+// LineError ошибка в конкретной строке файла.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Supported файл с таким именем можно импортировать.
+func Supported(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".ics", ".csv":
+		return true
+	}
+
+	return false
+}
+
+// Parse разбирает файл по расширению имени; время без явного часового
+// пояса считается временем loc.
+func Parse(name string, r io.Reader, loc *time.Location) ([]domain.Event, error) {
+	var (
+		events []domain.Event
+		err    error
+	)
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".ics":
+		events, err = ParseICS(r, loc)
+	case ".csv":
+		events, err = ParseCSV(r, loc)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(events) == 0:
+		return nil, ErrNoEvents
+	case len(events) > MaxEvents:
+		return nil, ErrTooManyEvents
+	}
+
+	return events, nil
+}
+
+// wallClock момент t на часах loc в виде UTC, как хранятся даты событий.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
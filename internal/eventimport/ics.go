@@ -0,0 +1,205 @@
+package eventimport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// property строка iCalendar вида NAME;PARAM=VALUE:значение.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICS читает события VEVENT. Из повторяющихся событий берётся только
+// первое вхождение, отменённые (STATUS:CANCELLED) пропускаются.
+func ParseICS(r io.Reader, loc *time.Location) ([]domain.Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events    []domain.Event
+		current   *domain.Event
+		cancelled bool
+		hasStart  bool
+		start     int
+		// components вложенность BEGIN/END: свойства VALARM внутри
+		// VEVENT не должны попасть в событие
+		components []string
+	)
+
+	for i, line := range lines {
+		if line.text == "" {
+			continue
+		}
+
+		p := parseProperty(line.text)
+
+		switch p.name {
+		case "BEGIN":
+			components = append(components, strings.ToUpper(p.value))
+			if strings.EqualFold(p.value, "VEVENT") {
+				current, cancelled, hasStart, start = &domain.Event{}, false, false, lines[i].number
+			}
+			continue
+
+		case "END":
+			if len(components) > 0 {
+				components = components[:len(components)-1]
+			}
+
+			if strings.EqualFold(p.value, "VEVENT") && current != nil {
+				if !hasStart {
+					return nil, &LineError{Line: start, Err: fmt.Errorf("%w: DTSTART is missing", ErrInvalidDate)}
+				}
+				if !cancelled {
+					events = append(events, *current)
+				}
+				current = nil
+			}
+			continue
+		}
+
+		if current == nil || len(components) == 0 || components[len(components)-1] != "VEVENT" {
+			continue
+		}
+
+		switch p.name {
+		case "SUMMARY":
+			current.Title = strings.TrimSpace(unescape(p.value))
+		case "DESCRIPTION":
+			current.Description = strings.TrimSpace(unescape(p.value))
+		case "STATUS":
+			cancelled = strings.EqualFold(p.value, "CANCELLED")
+		case "DTSTART":
+			date, err := parseICSTime(p, loc)
+			if err != nil {
+				return nil, &LineError{Line: line.number, Err: err}
+			}
+			current.Date = date
+			hasStart = true
+		}
+	}
+
+	return events, nil
+}
+
+type icsLine struct {
+	number int
+	text   string
+}
+
+// unfold склеивает продолженные строки: продолжение начинается с пробела или табуляции.
+func unfold(r io.Reader) ([]icsLine, error) {
+	var lines []icsLine
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if n == 1 {
+			text = strings.TrimPrefix(text, utf8BOM)
+		}
+
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+
+		lines = append(lines, icsLine{number: n, text: text})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseProperty разбирает строку; двоеточие в кавычках значения параметра
+// не считается разделителем.
+func parseProperty(line string) property {
+	quoted := false
+	colon := -1
+
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+
+	if colon < 0 {
+		return property{name: strings.ToUpper(line)}
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	p := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+
+	for _, param := range parts[1:] {
+		if kv := strings.SplitN(param, "=", 2); len(kv) == 2 {
+			p.params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+
+	return p
+}
+
+// parseICSTime понимает дату (VALUE=DATE), время в UTC (…Z),
+// время пояса TZID и «плавающее» время, которое относится к loc.
+func parseICSTime(p property, loc *time.Location) (time.Time, error) {
+	value := strings.TrimSpace(p.value)
+
+	if p.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, time.UTC)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, value)
+		}
+
+		return t, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, value)
+		}
+
+		return wallClock(t, loc), nil
+	}
+
+	zone := loc
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			zone = l
+		}
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, value)
+	}
+
+	return wallClock(t, loc), nil
+}
+
+// unescape раскрывает экранирование текста iCalendar.
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,219 @@
+package eventimport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// moscow часовой пояс пользователей в тестах: UTC+3 без перехода на летнее время.
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+// calendar собирает файл iCalendar из строк с переводами строк CRLF.
+func calendar(lines ...string) string {
+	return strings.Join(append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...), "END:VCALENDAR"), "\r\n") + "\r\n"
+}
+
+func wall(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParseICS(t *testing.T) {
+	type event struct {
+		title       string
+		description string
+		date        time.Time
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []event
+	}{
+		{
+			name: "floating time in user zone",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Митап",
+				"DTSTART:20261120T190000",
+				"END:VEVENT",
+			),
+			want: []event{{title: "Митап", date: wall(2026, 11, 20, 19, 0)}},
+		},
+		{
+			name: "utc converted to user zone",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Созвон",
+				"DTSTART:20261120T160000Z",
+				"END:VEVENT",
+			),
+			want: []event{{title: "Созвон", date: wall(2026, 11, 20, 19, 0)}},
+		},
+		{
+			name: "tzid",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Webinar",
+				"DTSTART;TZID=Europe/Berlin:20260715T100000",
+				"END:VEVENT",
+			),
+			// летом в Берлине UTC+2: 10:00 там — 11:00 в Москве
+			want: []event{{title: "Webinar", date: wall(2026, 7, 15, 11, 0)}},
+		},
+		{
+			name: "quoted tzid",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Webinar",
+				`DTSTART;TZID="Asia/Yekaterinburg":20260715T100000`,
+				"END:VEVENT",
+			),
+			want: []event{{title: "Webinar", date: wall(2026, 7, 15, 8, 0)}},
+		},
+		{
+			name: "unknown tzid falls back to user zone",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Встреча",
+				"DTSTART;TZID=Custom Zone:20260715T100000",
+				"END:VEVENT",
+			),
+			want: []event{{title: "Встреча", date: wall(2026, 7, 15, 10, 0)}},
+		},
+		{
+			name: "all-day date",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Субботник",
+				"DTSTART;VALUE=DATE:20260425",
+				"END:VEVENT",
+			),
+			want: []event{{title: "Субботник", date: wall(2026, 4, 25, 0, 0)}},
+		},
+		{
+			name: "folded lines",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Очень длинное название, которое кален",
+				" дарь перенёс на следующую строку",
+				"DESCRIPTION:Первая строка\\nвторая строка\\, с запятой",
+				"\t и продолжение после табуляции",
+				"DTSTART:2026112",
+				" 0T190000",
+				"END:VEVENT",
+			),
+			want: []event{{
+				title:       "Очень длинное название, которое календарь перенёс на следующую строку",
+				description: "Первая строка\nвторая строка, с запятой и продолжение после табуляции",
+				date:        wall(2026, 11, 20, 19, 0),
+			}},
+		},
+		{
+			name: "cancelled and alarm skipped",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Отменено",
+				"STATUS:CANCELLED",
+				"DTSTART:20261120T190000",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"SUMMARY:Концерт",
+				"DTSTART:20261121T200000",
+				"BEGIN:VALARM",
+				"DESCRIPTION:Напоминание",
+				"TRIGGER:-PT15M",
+				"END:VALARM",
+				"END:VEVENT",
+			),
+			want: []event{{title: "Концерт", date: wall(2026, 11, 21, 20, 0)}},
+		},
+		{
+			name:  "byte order mark and lf line endings",
+			input: utf8BOM + "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Lunch\nDTSTART:20261120T130000\nEND:VEVENT\nEND:VCALENDAR\n",
+			want:  []event{{title: "Lunch", date: wall(2026, 11, 20, 13, 0)}},
+		},
+		{
+			name:  "no events",
+			input: calendar(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ParseICS(strings.NewReader(tt.input), moscow)
+			if err != nil {
+				t.Fatalf("ParseICS: %v", err)
+			}
+
+			if len(events) != len(tt.want) {
+				t.Fatalf("ParseICS = %d events, want %d: %+v", len(events), len(tt.want), events)
+			}
+			for i, want := range tt.want {
+				got := events[i]
+				if got.Title != want.title {
+					t.Errorf("event %d title = %q, want %q", i, got.Title, want.title)
+				}
+				if got.Description != want.description {
+					t.Errorf("event %d description = %q, want %q", i, got.Description, want.description)
+				}
+				if !got.Date.Equal(want.date) || got.Date.Location() != time.UTC {
+					t.Errorf("event %d date = %v, want %v", i, got.Date, want.date)
+				}
+			}
+		})
+	}
+}
+
+func TestParseICSInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLine int
+	}{
+		{
+			name: "missing start",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Без даты",
+				"END:VEVENT",
+			),
+			wantLine: 3,
+		},
+		{
+			name: "malformed start",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"SUMMARY:Митап",
+				"DTSTART:2026-11-20 19:00",
+				"END:VEVENT",
+			),
+			wantLine: 5,
+		},
+		{
+			name: "malformed all-day date",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"DTSTART;VALUE=DATE:20261340",
+				"END:VEVENT",
+			),
+			wantLine: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseICS(strings.NewReader(tt.input), moscow)
+
+			var lineErr *LineError
+			if !errors.As(err, &lineErr) || !errors.Is(err, ErrInvalidDate) {
+				t.Fatalf("ParseICS error = %v, want LineError with ErrInvalidDate", err)
+			}
+			if lineErr.Line != tt.wantLine {
+				t.Errorf("line = %d, want %d", lineErr.Line, tt.wantLine)
+			}
+		})
+	}
+}
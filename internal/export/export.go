@@ -0,0 +1,35 @@
+// Package export выгружает таблицы в файлы для Excel и других табличных
+// редакторов: CSV и XLSX.
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// Table таблица с заголовком; строки могут быть короче заголовка.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// utf8BOM без метки порядка байтов Excel открывает CSV в однобайтовой кодировке.
+const utf8BOM = "\xef\xbb\xbf"
+
+// WriteCSV записывает таблицу в CSV в кодировке UTF-8.
+func WriteCSV(w io.Writer, t Table) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header); err != nil {
+		return err
+	}
+
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
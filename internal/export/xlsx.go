@@ -0,0 +1,129 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Минимальная книга из одного листа: все ячейки — строки, заголовок выделен
+// жирным и закреплён при прокрутке.
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	stylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+)
+
+// boldStyle индекс стиля заголовка в cellXfs.
+const boldStyle = 1
+
+// WriteXLSX записывает таблицу в книгу Excel.
+func WriteXLSX(w io.Writer, t Table) error {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", []byte(contentTypesXML)},
+		{"_rels/.rels", []byte(rootRelsXML)},
+		{"xl/workbook.xml", []byte(workbookXML)},
+		{"xl/_rels/workbook.xml.rels", []byte(workbookRelsXML)},
+		{"xl/styles.xml", []byte(stylesXML)},
+		{"xl/worksheets/sheet1.xml", sheetXML(t)},
+	}
+
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", p.name, err)
+		}
+
+		if _, err := f.Write(p.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", p.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// sheetXML лист с ячейками-строками, хранящимися прямо в листе (inlineStr).
+func sheetXML(t Table) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+		`</sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+
+	writeRow(&b, 1, t.Header, boldStyle)
+	for i, row := range t.Rows {
+		writeRow(&b, i+2, row, 0)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return b.Bytes()
+}
+
+func writeRow(b *bytes.Buffer, n int, cells []string, style int) {
+	b.WriteString(`<row r="` + strconv.Itoa(n) + `">`)
+
+	for i, value := range cells {
+		b.WriteString(`<c r="` + columnName(i) + strconv.Itoa(n) + `" t="inlineStr"`)
+		if style != 0 {
+			b.WriteString(` s="` + strconv.Itoa(style) + `"`)
+		}
+		b.WriteString(`><is><t xml:space="preserve">`)
+		// недопустимые в XML символы EscapeText заменяет на U+FFFD
+		_ = xml.EscapeText(b, []byte(value))
+		b.WriteString(`</t></is></c>`)
+	}
+
+	b.WriteString(`</row>`)
+}
+
+// columnName буквенное имя столбца по индексу с нуля: A, …, Z, AA, AB, …
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
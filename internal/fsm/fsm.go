@@ -0,0 +1,149 @@
+// Package fsm описывает многошаговые диалоги бота как конечные автоматы:
+// объявленные состояния, переходы между ними, валидаторы ввода и общую
+// семантику отмены.
+package fsm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// State состояние диалога.
+type State string
+
+// Event действие пользователя, вызывающее переход.
+type Event string
+
+const (
+	Next   Event = "next"
+	Back   Event = "back"
+	Skip   Event = "skip"
+	Done   Event = "done"
+	Cancel Event = "cancel"
+)
+
+// Final диалог завершён: отменён или доведён до конца.
+const Final State = ""
+
+var (
+	ErrUnknownState = errors.New("unknown state")
+	ErrNoTransition = errors.New("transition not allowed")
+)
+
+// ValidationError ввод не прошёл проверку; Reason показывается пользователю.
+type ValidationError struct {
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed: " + e.Reason
+}
+
+// Invalid ...
+func Invalid(reason string) error {
+	return &ValidationError{Reason: reason}
+}
+
+// Validator проверяет текстовый ввод пользователя на шаге.
+type Validator func(input string) error
+
+type Machine struct {
+	name        string
+	initial     State
+	validators  map[State]Validator
+	transitions map[State]map[Event]State
+}
+
+// New создает автомат с начальным состоянием initial.
+func New(name string, initial State) *Machine {
+	m := &Machine{
+		name:        name,
+		initial:     initial,
+		validators:  make(map[State]Validator),
+		transitions: make(map[State]map[Event]State),
+	}
+
+	return m.State(initial, nil)
+}
+
+// State объявляет состояние; validate может быть nil.
+func (m *Machine) State(state State, validate Validator) *Machine {
+	if _, ok := m.transitions[state]; !ok {
+		m.transitions[state] = make(map[Event]State)
+	}
+
+	if validate != nil {
+		m.validators[state] = validate
+	}
+
+	return m
+}
+
+// Transition объявляет переход from --event--> to. Оба состояния должны быть
+// объявлены заранее, кроме to == Final.
+func (m *Machine) Transition(from State, event Event, to State) *Machine {
+	if _, ok := m.transitions[from]; !ok {
+		panic(fmt.Sprintf("fsm %s: transition from undeclared state %q", m.name, from))
+	}
+
+	if _, ok := m.transitions[to]; !ok && to != Final {
+		panic(fmt.Sprintf("fsm %s: transition to undeclared state %q", m.name, to))
+	}
+
+	m.transitions[from][event] = to
+
+	return m
+}
+
+func (m *Machine) Name() string {
+	return m.name
+}
+
+func (m *Machine) Initial() State {
+	return m.initial
+}
+
+// Has ...
+func (m *Machine) Has(state State) bool {
+	_, ok := m.transitions[state]
+	return ok
+}
+
+// Can проверяет, объявлен ли переход.
+func (m *Machine) Can(from State, event Event) bool {
+	_, err := m.Fire(from, event)
+	return err == nil
+}
+
+// Validate проверяет ввод для состояния.
+func (m *Machine) Validate(state State, input string) error {
+	if !m.Has(state) {
+		return fmt.Errorf("fsm %s: %w: %q", m.name, ErrUnknownState, state)
+	}
+
+	validate, ok := m.validators[state]
+	if !ok {
+		return nil
+	}
+
+	return validate(input)
+}
+
+// Fire возвращает состояние после события. Cancel допустим из любого
+// состояния и завершает диалог.
+func (m *Machine) Fire(from State, event Event) (State, error) {
+	edges, ok := m.transitions[from]
+	if !ok {
+		return from, fmt.Errorf("fsm %s: %w: %q", m.name, ErrUnknownState, from)
+	}
+
+	if to, ok := edges[event]; ok {
+		return to, nil
+	}
+
+	if event == Cancel {
+		return Final, nil
+	}
+
+	return from, fmt.Errorf("fsm %s: %w: %q --%s-->", m.name, ErrNoTransition, from, event)
+}
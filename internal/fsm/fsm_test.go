@@ -0,0 +1,129 @@
+package fsm
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTestMachine a → b → c, из b можно вернуться, c проверяет ввод.
+func newTestMachine() *Machine {
+	m := New("test", "a").
+		State("b", nil).
+		State("c", func(input string) error {
+			if input == "" {
+				return Invalid("пусто")
+			}
+			return nil
+		})
+
+	return m.Transition("a", Next, "b").
+		Transition("b", Back, "a").
+		Transition("b", Next, "c").
+		Transition("c", Done, Final)
+}
+
+func TestFire(t *testing.T) {
+	m := newTestMachine()
+
+	tests := []struct {
+		name    string
+		from    State
+		event   Event
+		want    State
+		wantErr error
+	}{
+		{name: "next", from: "a", event: Next, want: "b"},
+		{name: "back", from: "b", event: Back, want: "a"},
+		{name: "to final", from: "c", event: Done, want: Final},
+		{name: "cancel from initial", from: "a", event: Cancel, want: Final},
+		{name: "cancel from any state", from: "c", event: Cancel, want: Final},
+		{name: "undeclared event", from: "a", event: Back, want: "a", wantErr: ErrNoTransition},
+		{name: "unknown event", from: "b", event: "jump", want: "b", wantErr: ErrNoTransition},
+		{name: "unknown state", from: "z", event: Next, want: "z", wantErr: ErrUnknownState},
+		{name: "cancel from unknown state", from: "z", event: Cancel, want: "z", wantErr: ErrUnknownState},
+		{name: "final is not a state", from: Final, event: Next, want: Final, wantErr: ErrUnknownState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Fire(tt.from, tt.event)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Fire(%q, %q) error = %v, want %v", tt.from, tt.event, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Fire(%q, %q) = %q, want %q", tt.from, tt.event, got, tt.want)
+			}
+			if can := m.Can(tt.from, tt.event); can != (tt.wantErr == nil) {
+				t.Errorf("Can(%q, %q) = %v", tt.from, tt.event, can)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	m := newTestMachine()
+
+	tests := []struct {
+		name       string
+		state      State
+		input      string
+		wantReason string
+		wantErr    error
+	}{
+		{name: "no validator", state: "a", input: ""},
+		{name: "valid input", state: "c", input: "ok"},
+		{name: "invalid input", state: "c", input: "", wantReason: "пусто"},
+		{name: "unknown state", state: "z", input: "ok", wantErr: ErrUnknownState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.Validate(tt.state, tt.input)
+
+			var verr *ValidationError
+			switch {
+			case tt.wantReason != "":
+				if !errors.As(err, &verr) || verr.Reason != tt.wantReason {
+					t.Errorf("Validate(%q, %q) = %v, want reason %q", tt.state, tt.input, err, tt.wantReason)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Errorf("Validate(%q, %q) = %v, want %v", tt.state, tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransitionToUndeclaredStatePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		from State
+		to   State
+	}{
+		{name: "from", from: "z", to: "a"},
+		{name: "to", from: "a", to: "z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Transition(%q, %q) did not panic", tt.from, tt.to)
+				}
+			}()
+
+			New("test", "a").Transition(tt.from, Next, tt.to)
+		})
+	}
+}
+
+func TestMachineInfo(t *testing.T) {
+	m := newTestMachine()
+
+	if m.Name() != "test" || m.Initial() != "a" {
+		t.Errorf("Name() = %q, Initial() = %q", m.Name(), m.Initial())
+	}
+
+	if !m.Has("c") || m.Has("z") || m.Has(Final) {
+		t.Error("Has reports wrong states")
+	}
+}
@@ -0,0 +1,1034 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+var (
+	// ErrNotFound на изображении нет QR-кода.
+	ErrNotFound = errors.New("qr code not found")
+	// ErrUnreadable код найден, но не читается: снят нечётко, повреждён
+	// или построен в неподдерживаемом формате.
+	ErrUnreadable = errors.New("qr code is unreadable")
+
+	errTooManyErrors = errors.New("too many errors to correct")
+)
+
+const (
+	// minBlock наименьшая сторона блока в пикселях, по которому усредняется
+	// яркость; у больших снимков блок растёт вместе с ними.
+	minBlock = 8
+	// blocksPerSide сколько блоков укладывается на короткой стороне снимка.
+	blocksPerSide = 100
+	// minContrast разброс яркости, меньше которого окрестность считается однотонной.
+	minContrast = 24
+	// maxFinders сколько узоров, подтверждённых большим числом строк, перебирается.
+	maxFinders = 8
+	// maxAttempts сколько троек узоров пробуется прочитать.
+	maxAttempts = 3
+	// minAlignmentScore совпадений из 25 модулей выравнивающего узора.
+	minAlignmentScore = 23
+	// maxCornerShift насколько модулей дальний угол кода без выравнивающего
+	// узора может отойти от угла параллелограмма.
+	maxCornerShift = 3.0
+)
+
+// alignmentScales поправки размера модуля у выравнивающего узора: при съёмке
+// под углом модули в дальнем от поисковых узоров углу крупнее или мельче.
+var alignmentScales = []float64{1, 1.2, 1.4, 0.85}
+
+// Decode находит на изображении QR-код и возвращает его содержимое.
+// Читаются коды уровня коррекции M версий 1–10, как у Encode, с цифровыми,
+// буквенно-цифровыми и байтовыми сегментами. Снимок может быть повёрнут
+// и немного искажён перспективой.
+func Decode(img image.Image) (string, error) {
+	bm := binarize(img)
+
+	candidates := locate(bm.findFinders())
+	if len(candidates) == 0 {
+		return "", ErrNotFound
+	}
+	if len(candidates) > maxAttempts {
+		candidates = candidates[:maxAttempts]
+	}
+
+	// ошибка самой вероятной догадки объясняет неудачу лучше остальных
+	var first error
+	for _, corners := range candidates {
+		for _, version := range bm.versionGuesses(corners) {
+			content, err := bm.read(corners, version)
+			if err == nil {
+				return content, nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+	}
+
+	if first == nil {
+		first = ErrUnreadable
+	}
+
+	return "", first
+}
+
+// point точка изображения в пикселях.
+type point struct {
+	x, y float64
+}
+
+func distance(a, b point) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+// bitmap чёрно-белое изображение: true — тёмный пиксель.
+type bitmap struct {
+	w, h int
+	dark []bool
+}
+
+func (b *bitmap) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < b.w && y < b.h
+}
+
+// at пиксель (x, y) тёмный; за границей изображения — светлый.
+func (b *bitmap) at(x, y int) bool {
+	return b.inside(x, y) && b.dark[y*b.w+x]
+}
+
+// binarize переводит изображение в чёрно-белое с порогом по средней яркости
+// окрестности 5×5 блоков, чтобы код читался при неравномерном освещении.
+// Однотонные окрестности — например, середина крупного модуля — сравниваются
+// с общим порогом изображения. Порог не подходит вплотную к самому тёмному
+// и самому светлому пикселю окрестности: иначе пара светлых пикселей на краю
+// тёмной области превращает её середину в шум.
+func binarize(img image.Image) *bitmap {
+	r := img.Bounds()
+	w, h := r.Dx(), r.Dy()
+
+	block := max(minBlock, min(w, h)/blocksPerSide)
+	bw := (w + block - 1) / block
+	bh := (h + block - 1) / block
+
+	lum := make([]uint8, w*h)
+	sum := make([]int, bw*bh)
+	count := make([]int, bw*bh)
+	lo := make([]uint8, bw*bh)
+	hi := make([]uint8, bw*bh)
+	for i := range lo {
+		lo[i] = 255
+	}
+
+	var hist [256]int
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := color.GrayModel.Convert(img.At(r.Min.X+x, r.Min.Y+y)).(color.Gray).Y
+			lum[y*w+x] = v
+			hist[v]++
+
+			blk := (y/block)*bw + x/block
+			sum[blk] += int(v)
+			count[blk]++
+			if v < lo[blk] {
+				lo[blk] = v
+			}
+			if v > hi[blk] {
+				hi[blk] = v
+			}
+		}
+	}
+
+	global := otsu(&hist, w*h)
+	bm := &bitmap{w: w, h: h, dark: make([]bool, w*h)}
+
+	for by := 0; by < bh; by++ {
+		for bx := 0; bx < bw; bx++ {
+			s, n := 0, 0
+			darkest, lightest := uint8(255), uint8(0)
+
+			for y := by - 2; y <= by+2; y++ {
+				for x := bx - 2; x <= bx+2; x++ {
+					if x < 0 || y < 0 || x >= bw || y >= bh {
+						continue
+					}
+
+					blk := y*bw + x
+					s += sum[blk]
+					n += count[blk]
+					if lo[blk] < darkest {
+						darkest = lo[blk]
+					}
+					if hi[blk] > lightest {
+						lightest = hi[blk]
+					}
+				}
+			}
+
+			threshold := global
+			if spread := int(lightest) - int(darkest); spread >= minContrast {
+				threshold = min(max(s/n, int(darkest)+spread/4), int(lightest)-spread/4)
+			}
+
+			for y := by * block; y < (by+1)*block && y < h; y++ {
+				for x := bx * block; x < (bx+1)*block && x < w; x++ {
+					bm.dark[y*w+x] = int(lum[y*w+x]) < threshold
+				}
+			}
+		}
+	}
+
+	return bm
+}
+
+// otsu порог яркости, лучше всего разделяющий гистограмму на тёмное и светлое.
+func otsu(hist *[256]int, total int) int {
+	var sum float64
+	for v, n := range hist {
+		sum += float64(v * n)
+	}
+
+	threshold, best := 128, 0.0
+	var sumDark, dark float64
+
+	for v, n := range hist {
+		dark += float64(n)
+		if dark == 0 {
+			continue
+		}
+
+		light := float64(total) - dark
+		if light == 0 {
+			break
+		}
+
+		sumDark += float64(v * n)
+		diff := sumDark/dark - (sum-sumDark)/light
+
+		if between := dark * light * diff * diff; between > best {
+			threshold, best = v+1, between
+		}
+	}
+
+	return threshold
+}
+
+// finder поисковый узор: центр, размер модуля в пикселях и число строк,
+// в которых он найден.
+type finder struct {
+	point
+	module float64
+	count  int
+}
+
+// findFinders ищет в строках изображения серии 1:1:3:1:1 (тёмная, светлая,
+// тёмная, светлая, тёмная) и подтверждает их такой же серией по вертикали.
+func (b *bitmap) findFinders() []finder {
+	var found []finder
+
+	add := func(f finder) {
+		for i := range found {
+			c := &found[i]
+			if math.Abs(c.x-f.x) <= c.module && math.Abs(c.y-f.y) <= c.module &&
+				f.module < 2*c.module && c.module < 2*f.module {
+				n := float64(c.count)
+				c.x = (c.x*n + f.x) / (n + 1)
+				c.y = (c.y*n + f.y) / (n + 1)
+				c.module = (c.module*n + f.module) / (n + 1)
+				c.count++
+				return
+			}
+		}
+		found = append(found, f)
+	}
+
+	for y := 0; y < b.h; y++ {
+		var counts [5]int
+		state := 0
+
+		for x := 0; x <= b.w; x++ {
+			dark := x < b.w && b.at(x, y)
+
+			switch {
+			case dark && state%2 == 1:
+				state++
+				counts[state]++
+			case dark:
+				counts[state]++
+			case state%2 == 1:
+				counts[state]++
+			case state == 0 && counts[0] == 0:
+				// светлое поле перед первой тёмной серией
+			case state == 4:
+				if f, ok := b.confirmFinder(x, y, counts); ok {
+					add(f)
+				}
+				counts = [5]int{counts[2], counts[3], counts[4], 1, 0}
+				state = 3
+			default:
+				state++
+				counts[state]++
+			}
+		}
+	}
+
+	return found
+}
+
+// confirmFinder проверяет серию, закончившуюся перед пикселем (end, y),
+// по вертикали и повторно по горизонтали через уточнённый центр.
+func (b *bitmap) confirmFinder(end, y int, counts [5]int) (finder, bool) {
+	if !finderRatio(counts) {
+		return finder{}, false
+	}
+
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	similar := func(n int) bool {
+		return 5*abs(n-total) < 2*total
+	}
+
+	cx := float64(end-counts[4]-counts[3]) - float64(counts[2])/2
+
+	cy, vertical, ok := b.crossCheck(int(cx), y, 0, 1)
+	if !ok || !similar(vertical) {
+		return finder{}, false
+	}
+
+	cx, horizontal, ok := b.crossCheck(int(cx), int(cy), 1, 0)
+	if !ok || !similar(horizontal) {
+		return finder{}, false
+	}
+
+	return finder{
+		point:  point{cx, cy},
+		module: float64(horizontal+vertical) / 14,
+		count:  1,
+	}, true
+}
+
+// crossCheck проверяет серию 1:1:3:1:1 вдоль направления (dx, dy) через
+// пиксель (x, y) центрального квадрата узора. Возвращает координату центра
+// вдоль направления и общую длину серии.
+func (b *bitmap) crossCheck(x, y, dx, dy int) (float64, int, bool) {
+	if !b.at(x, y) {
+		return 0, 0, false
+	}
+
+	var counts [5]int
+
+	// назад: центр, светлое кольцо, тёмное кольцо
+	i := 0
+	for ; b.at(x-i*dx, y-i*dy); i++ {
+		counts[2]++
+	}
+	for ; b.inside(x-i*dx, y-i*dy) && !b.at(x-i*dx, y-i*dy); i++ {
+		counts[1]++
+	}
+	for ; b.at(x-i*dx, y-i*dy); i++ {
+		counts[0]++
+	}
+
+	// вперёд
+	j := 1
+	for ; b.at(x+j*dx, y+j*dy); j++ {
+		counts[2]++
+	}
+	for ; b.inside(x+j*dx, y+j*dy) && !b.at(x+j*dx, y+j*dy); j++ {
+		counts[3]++
+	}
+	for ; b.at(x+j*dx, y+j*dy); j++ {
+		counts[4]++
+	}
+
+	if !finderRatio(counts) {
+		return 0, 0, false
+	}
+
+	pos := x
+	if dy != 0 {
+		pos = y
+	}
+	// центральная серия заканчивается перед правым светлым кольцом
+	center := float64(pos+j-counts[4]-counts[3]) - float64(counts[2])/2
+
+	return center, counts[0] + counts[1] + counts[2] + counts[3] + counts[4], true
+}
+
+// finderRatio длины серий соотносятся как 1:1:3:1:1 с допуском в полмодуля.
+func finderRatio(counts [5]int) bool {
+	total := 0
+	for _, n := range counts {
+		if n == 0 {
+			return false
+		}
+		total += n
+	}
+
+	if total < 7 {
+		return false
+	}
+
+	module := float64(total) / 7
+	tolerance := module / 2
+
+	return math.Abs(module-float64(counts[0])) < tolerance &&
+		math.Abs(module-float64(counts[1])) < tolerance &&
+		math.Abs(3*module-float64(counts[2])) < 3*tolerance &&
+		math.Abs(module-float64(counts[3])) < tolerance &&
+		math.Abs(module-float64(counts[4])) < tolerance
+}
+
+// locate тройки узоров, похожие на углы одного кода, от лучшей к худшей.
+// Узоры тройки упорядочены: левый верхний, правый верхний, левый нижний.
+func locate(finders []finder) [][3]finder {
+	sort.Slice(finders, func(i, j int) bool {
+		return finders[i].count > finders[j].count
+	})
+	if len(finders) > maxFinders {
+		finders = finders[:maxFinders]
+	}
+
+	type candidate struct {
+		corners [3]finder
+		score   float64
+	}
+
+	var list []candidate
+	for i := 0; i < len(finders); i++ {
+		for j := i + 1; j < len(finders); j++ {
+			for k := j + 1; k < len(finders); k++ {
+				if corners, score, ok := orderCorners(finders[i], finders[j], finders[k]); ok {
+					list = append(list, candidate{corners: corners, score: score})
+				}
+			}
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].score < list[j].score
+	})
+
+	result := make([][3]finder, len(list))
+	for i, c := range list {
+		result[i] = c.corners
+	}
+
+	return result
+}
+
+// orderCorners упорядочивает узоры, если они образуют равнобедренный
+// прямоугольный треугольник; score — отклонение от него (меньше — лучше).
+func orderCorners(a, b, c finder) ([3]finder, float64, bool) {
+	// вершина прямого угла лежит напротив самой длинной стороны
+	corner, p, q := a, b, c
+	hyp := distance(b.point, c.point)
+	if d := distance(c.point, a.point); d > hyp {
+		corner, p, q, hyp = b, c, a, d
+	}
+	if d := distance(a.point, b.point); d > hyp {
+		corner, p, q, hyp = c, a, b, d
+	}
+
+	l1, l2 := distance(corner.point, p.point), distance(corner.point, q.point)
+	module := (a.module + b.module + c.module) / 3
+
+	legs := math.Abs(l1-l2) / math.Max(l1, l2)
+	angle := math.Abs(hyp*hyp-l1*l1-l2*l2) / (hyp * hyp)
+	sizes := (math.Max(a.module, math.Max(b.module, c.module)) -
+		math.Min(a.module, math.Min(b.module, c.module))) / module
+
+	// между центрами узоров от 14 модулей у версии 1 до 50 у версии 10
+	span := (l1 + l2) / 2 / module
+	if legs > 0.3 || angle > 0.3 || sizes > 0.5 || span < 10 || span > 60 {
+		return [3]finder{}, 0, false
+	}
+
+	// правый верхний узор — следующий по часовой стрелке (ось y направлена вниз)
+	if (p.x-corner.x)*(q.y-corner.y)-(p.y-corner.y)*(q.x-corner.x) < 0 {
+		p, q = q, p
+	}
+
+	return [3]finder{corner, p, q}, legs + angle + sizes, true
+}
+
+// versionGuesses версии, подходящие к расстоянию между узорами, начиная
+// с самой вероятной.
+func (b *bitmap) versionGuesses(corners [3]finder) []int {
+	module := b.moduleSize(corners)
+	span := (distance(corners[0].point, corners[1].point) + distance(corners[0].point, corners[2].point)) / 2 / module
+
+	// между центрами узоров size−7 модулей, size = 17 + 4·версия
+	estimate := (span - 10) / 4
+	guess := int(math.Round(estimate))
+
+	order := []int{guess, guess - 1, guess + 1}
+	if estimate > float64(guess) {
+		order[1], order[2] = order[2], order[1]
+	}
+
+	var versions []int
+	for _, v := range order {
+		if v >= 1 && v < len(blocks) {
+			versions = append(versions, v)
+		}
+	}
+
+	return versions
+}
+
+// moduleSize размер модуля по сериям поисковых узоров вдоль сторон кода.
+// Горизонтальные серии, по которым узоры найдены, у повёрнутого кода длиннее.
+func (b *bitmap) moduleSize(corners [3]finder) float64 {
+	tl, tr, bl := corners[0].point, corners[1].point, corners[2].point
+
+	var sum float64
+	n := 0
+	for _, side := range [][2]point{{tl, tr}, {tr, tl}, {tl, bl}, {bl, tl}} {
+		if d, ok := b.edgeDistance(side[0], side[1]); ok {
+			// от центра узора до внешнего края рамки 3,5 модуля
+			sum += d / 3.5
+			n++
+		}
+	}
+
+	if n == 0 {
+		return (corners[0].module + corners[1].module + corners[2].module) / 3
+	}
+
+	return sum / float64(n)
+}
+
+// edgeDistance расстояние от центра узора from в сторону to до конца его
+// тёмной рамки: центр, светлое кольцо, рамка.
+func (b *bitmap) edgeDistance(from, to point) (float64, bool) {
+	length := distance(from, to)
+	dx, dy := (to.x-from.x)/length, (to.y-from.y)/length
+
+	runs, dark := 0, true
+	for step := 0.0; step < length/2; step++ {
+		if b.at(int(math.Floor(from.x+dx*step)), int(math.Floor(from.y+dy*step))) != dark {
+			if runs++; runs == 3 {
+				return step, true
+			}
+			dark = !dark
+		}
+	}
+
+	return 0, false
+}
+
+// read считывает код версии version по найденным углам.
+func (b *bitmap) read(corners [3]finder, version int) (string, error) {
+	size := 17 + 4*version
+	tl, tr, bl := corners[0].point, corners[1].point, corners[2].point
+
+	// четвёртая опорная точка — угол параллелограмма по трём узорам, а если
+	// найден правый нижний выравнивающий узор, то его центр: он учитывает перспективу
+	far := float64(size) - 3.5
+	src := [4]point{{3.5, 3.5}, {far, 3.5}, {far, far}, {3.5, far}}
+	dst := [4]point{tl, tr, {tr.x + bl.x - tl.x, tr.y + bl.y - tl.y}, bl}
+
+	if version > 1 {
+		at := float64(size) - 6.5
+		if p, ok := b.findAlignment(newTransform(src, dst), at); ok {
+			src[2], dst[2] = point{at, at}, p
+			return b.sample(src, dst, version)
+		}
+	}
+
+	content, err := b.sample(src, dst, version)
+	if err == nil {
+		return content, nil
+	}
+
+	// без выравнивающего узора перспективу по трём точкам не оценить: дальний
+	// угол подбирается рядом с углом параллелограмма, пока коды коррекции
+	// не подтвердят прочитанное
+	span := far - 3.5
+	ux := point{(tr.x - tl.x) / span, (tr.y - tl.y) / span}
+	uy := point{(bl.x - tl.x) / span, (bl.y - tl.y) / span}
+	corner := dst[2]
+
+	for _, shift := range cornerShifts {
+		dst[2] = point{
+			corner.x + shift.x*ux.x + shift.y*uy.x,
+			corner.y + shift.x*ux.y + shift.y*uy.y,
+		}
+		if content, err := b.sample(src, dst, version); err == nil {
+			return content, nil
+		}
+	}
+
+	return "", err
+}
+
+// cornerShifts сдвиги дальнего угла в модулях, от ближних к дальним.
+var cornerShifts = func() []point {
+	var shifts []point
+	for dy := -maxCornerShift; dy <= maxCornerShift; dy += 0.5 {
+		for dx := -maxCornerShift; dx <= maxCornerShift; dx += 0.5 {
+			if dx != 0 || dy != 0 {
+				shifts = append(shifts, point{dx, dy})
+			}
+		}
+	}
+
+	sort.SliceStable(shifts, func(i, j int) bool {
+		return math.Hypot(shifts[i].x, shifts[i].y) < math.Hypot(shifts[j].x, shifts[j].y)
+	})
+
+	return shifts
+}()
+
+// sample строит модули кода по преобразованию опорных точек src кода
+// в точки dst изображения и читает содержимое.
+func (b *bitmap) sample(src, dst [4]point, version int) (string, error) {
+	t := newTransform(src, dst)
+	size := 17 + 4*version
+
+	code := newCode(version)
+	code.drawFunctionPatterns(version)
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			p := t.apply(float64(x)+0.5, float64(y)+0.5)
+			code.modules[y][x] = b.at(int(math.Floor(p.x)), int(math.Floor(p.y)))
+		}
+	}
+
+	return code.decode(version)
+}
+
+// findAlignment ищет выравнивающий узор 5×5 рядом с точкой (at, at) кода,
+// куда его помещает преобразование t. Чем сильнее перспектива, тем дальше
+// узор от этой точки, поэтому окрестность расширяется постепенно.
+func (b *bitmap) findAlignment(t transform, at float64) (point, bool) {
+	center := t.apply(at, at)
+	right := t.apply(at+1, at)
+	down := t.apply(at, at+1)
+
+	ux := point{right.x - center.x, right.y - center.y}
+	uy := point{down.x - center.x, down.y - center.y}
+	module := math.Max(math.Hypot(ux.x, ux.y), math.Hypot(uy.x, uy.y))
+
+	for _, reach := range []float64{4, 8, 16} {
+		if p, ok := b.searchAlignment(center, ux, uy, int(reach*module), module); ok {
+			return p, true
+		}
+	}
+
+	return point{}, false
+}
+
+// searchAlignment ищет центр узора в квадрате radius пикселей вокруг center.
+// Из одинаково подходящих точек берётся ближайшая к center — соседние узоры
+// дальше — и уточняется по подходящим точкам в пределах модуля от неё.
+func (b *bitmap) searchAlignment(center, ux, uy point, radius int, module float64) (point, bool) {
+	best := 0
+	var matches []point
+
+	// центр достаточно найти с точностью до четверти модуля
+	step := max(1, int(module/4))
+	for py := int(center.y) - radius; py <= int(center.y)+radius; py += step {
+		for px := int(center.x) - radius; px <= int(center.x)+radius; px += step {
+			p := point{float64(px) + 0.5, float64(py) + 0.5}
+
+			score := 0
+			for _, k := range alignmentScales {
+				score = max(score, b.alignmentScore(p, point{k * ux.x, k * ux.y}, point{k * uy.x, k * uy.y}))
+			}
+
+			if score > best {
+				best, matches = score, matches[:0]
+			}
+			if score == best {
+				matches = append(matches, p)
+			}
+		}
+	}
+
+	if best < minAlignmentScore {
+		return point{}, false
+	}
+
+	nearest := matches[0]
+	for _, m := range matches {
+		if distance(m, center) < distance(nearest, center) {
+			nearest = m
+		}
+	}
+
+	var sx, sy float64
+	n := 0
+	for _, m := range matches {
+		if distance(m, nearest) <= module {
+			sx, sy = sx+m.x, sy+m.y
+			n++
+		}
+	}
+
+	return point{sx / float64(n), sy / float64(n)}, true
+}
+
+// alignmentScore сколько из 25 модулей вокруг p совпадают с выравнивающим
+// узором при сторонах модуля ux и uy.
+func (b *bitmap) alignmentScore(p, ux, uy point) int {
+	score := 0
+	for j := -2; j <= 2; j++ {
+		for i := -2; i <= 2; i++ {
+			// светлое кольцо между тёмным центром и тёмной рамкой
+			want := max(abs(i), abs(j)) != 1
+			qx := p.x + float64(i)*ux.x + float64(j)*uy.x
+			qy := p.y + float64(i)*ux.y + float64(j)*uy.y
+			if b.at(int(math.Floor(qx)), int(math.Floor(qy))) == want {
+				score++
+			}
+		}
+	}
+
+	return score
+}
+
+// transform перспективное преобразование плоскости в однородных координатах.
+type transform [3][3]float64
+
+func (t transform) apply(x, y float64) point {
+	w := t[2][0]*x + t[2][1]*y + t[2][2]
+
+	return point{
+		(t[0][0]*x + t[0][1]*y + t[0][2]) / w,
+		(t[1][0]*x + t[1][1]*y + t[1][2]) / w,
+	}
+}
+
+// newTransform преобразование, переводящее четырёхугольник src в dst.
+func newTransform(src, dst [4]point) transform {
+	return squareToQuad(dst).mul(squareToQuad(src).adjugate())
+}
+
+// squareToQuad переводит единичный квадрат в четырёхугольник q с углами
+// в порядке (0,0), (1,0), (1,1), (0,1).
+func squareToQuad(q [4]point) transform {
+	dx3 := q[0].x - q[1].x + q[2].x - q[3].x
+	dy3 := q[0].y - q[1].y + q[2].y - q[3].y
+
+	if dx3 == 0 && dy3 == 0 {
+		return transform{
+			{q[1].x - q[0].x, q[3].x - q[0].x, q[0].x},
+			{q[1].y - q[0].y, q[3].y - q[0].y, q[0].y},
+			{0, 0, 1},
+		}
+	}
+
+	dx1, dx2 := q[1].x-q[2].x, q[3].x-q[2].x
+	dy1, dy2 := q[1].y-q[2].y, q[3].y-q[2].y
+	den := dx1*dy2 - dx2*dy1
+	g := (dx3*dy2 - dx2*dy3) / den
+	h := (dx1*dy3 - dx3*dy1) / den
+
+	return transform{
+		{q[1].x - q[0].x + g*q[1].x, q[3].x - q[0].x + h*q[3].x, q[0].x},
+		{q[1].y - q[0].y + g*q[1].y, q[3].y - q[0].y + h*q[3].y, q[0].y},
+		{g, h, 1},
+	}
+}
+
+// adjugate присоединённая матрица: обратная с точностью до множителя,
+// чего достаточно для однородных координат.
+func (t transform) adjugate() transform {
+	return transform{
+		{t[1][1]*t[2][2] - t[1][2]*t[2][1], t[0][2]*t[2][1] - t[0][1]*t[2][2], t[0][1]*t[1][2] - t[0][2]*t[1][1]},
+		{t[1][2]*t[2][0] - t[1][0]*t[2][2], t[0][0]*t[2][2] - t[0][2]*t[2][0], t[0][2]*t[1][0] - t[0][0]*t[1][2]},
+		{t[1][0]*t[2][1] - t[1][1]*t[2][0], t[0][1]*t[2][0] - t[0][0]*t[2][1], t[0][0]*t[1][1] - t[0][1]*t[1][0]},
+	}
+}
+
+func (t transform) mul(o transform) transform {
+	var r transform
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				r[i][j] += t[i][k] * o[k][j]
+			}
+		}
+	}
+
+	return r
+}
+
+// decode читает содержимое из модулей считанного кода версии version.
+func (c *Code) decode(version int) (string, error) {
+	mask, err := c.readFormat()
+	if err != nil {
+		return "", err
+	}
+	c.applyMask(mask)
+
+	b := blocks[version]
+	total := b.dataLen() + (b.g1Count+b.g2Count)*b.ecLen
+
+	codewords := make([]byte, 0, total)
+	var cur byte
+	n := 0
+	c.walkData(func(x, y int) {
+		if len(codewords) == total {
+			return
+		}
+
+		cur <<= 1
+		if c.modules[y][x] {
+			cur |= 1
+		}
+
+		if n++; n == 8 {
+			codewords = append(codewords, cur)
+			cur, n = 0, 0
+		}
+	})
+
+	data, err := deinterleave(version, codewords)
+	if err != nil {
+		return "", err
+	}
+
+	return parseData(version, data)
+}
+
+// readFormat маска из информации о формате. Берётся допустимое значение,
+// ближайшее к одной из двух копий, если оно отличается не больше чем на 3 бита.
+func (c *Code) readFormat() (int, error) {
+	bit := func(x, y int) int {
+		if c.modules[y][x] {
+			return 1
+		}
+		return 0
+	}
+
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= bit(8, i) << uint(i)
+	}
+	first |= bit(8, 7)<<6 | bit(8, 8)<<7 | bit(7, 8)<<8
+	for i := 9; i < 15; i++ {
+		first |= bit(14-i, 8) << uint(i)
+	}
+
+	for i := 0; i < 8; i++ {
+		second |= bit(c.Size-1-i, 8) << uint(i)
+	}
+	for i := 8; i < 15; i++ {
+		second |= bit(8, c.Size-15+i) << uint(i)
+	}
+
+	bestDiff, bestLevel, bestMask := 16, 0, 0
+	for level := 0; level < 4; level++ {
+		for mask := 0; mask < 8; mask++ {
+			want := formatBits(level, mask)
+			for _, got := range []int{first, second} {
+				if diff := bits.OnesCount(uint(want ^ got)); diff < bestDiff {
+					bestDiff, bestLevel, bestMask = diff, level, mask
+				}
+			}
+		}
+	}
+
+	if bestDiff > 3 {
+		return 0, fmt.Errorf("%w: damaged format information", ErrUnreadable)
+	}
+	if bestLevel != levelM {
+		return 0, fmt.Errorf("%w: unsupported error correction level", ErrUnreadable)
+	}
+
+	return bestMask, nil
+}
+
+// deinterleave собирает блоки из перемежённых кодовых слов, исправляет
+// в них ошибки и возвращает слова данных по порядку.
+func deinterleave(version int, codewords []byte) ([]byte, error) {
+	b := blocks[version]
+	count := b.g1Count + b.g2Count
+
+	blks := make([][]byte, count)
+	i := 0
+	for k := 0; k <= b.g1Data; k++ {
+		for j := range blks {
+			n := b.g1Data
+			if j >= b.g1Count {
+				n++
+			}
+
+			if k < n {
+				blks[j] = append(blks[j], codewords[i])
+				i++
+			}
+		}
+	}
+	for k := 0; k < b.ecLen; k++ {
+		for j := range blks {
+			blks[j] = append(blks[j], codewords[i])
+			i++
+		}
+	}
+
+	data := make([]byte, 0, b.dataLen())
+	for _, blk := range blks {
+		if err := rsCorrect(blk, b.ecLen); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnreadable, err)
+		}
+		data = append(data, blk[:len(blk)-b.ecLen]...)
+	}
+
+	return data, nil
+}
+
+// Режимы сегментов данных. Encode пишет только байтовый, но другие
+// кодировщики сами выбирают цифровой и буквенно-цифровой режим для
+// подходящих частей строки — например, для кода билета без ссылки.
+const (
+	modeNumeric      = 0x1
+	modeAlphanumeric = 0x2
+	modeByte         = 0x4
+)
+
+// alphanumeric алфавит буквенно-цифрового режима.
+const alphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// countBits длина поля количества символов сегмента в версиях 1–9 и 10–26.
+var countBits = map[int][2]int{
+	modeNumeric:      {10, 12},
+	modeAlphanumeric: {9, 11},
+	modeByte:         {8, 16},
+}
+
+// parseData разбирает поток данных из цифровых, буквенно-цифровых
+// и байтовых сегментов.
+func parseData(version int, data []byte) (string, error) {
+	r := bitReader{bytes: data}
+	large := 0
+	if version >= 10 {
+		large = 1
+	}
+
+	var out []byte
+	for r.left() >= 4 {
+		mode := r.read(4)
+		if mode == 0 {
+			break
+		}
+
+		lenBits, ok := countBits[mode]
+		if !ok {
+			return "", fmt.Errorf("%w: unsupported mode %d", ErrUnreadable, mode)
+		}
+		if r.left() < lenBits[large] {
+			return "", fmt.Errorf("%w: truncated data", ErrUnreadable)
+		}
+		n := r.read(lenBits[large])
+
+		var err error
+		switch mode {
+		case modeNumeric:
+			out, err = readNumeric(&r, out, n)
+		case modeAlphanumeric:
+			out, err = readAlphanumeric(&r, out, n)
+		default:
+			out, err = readBytes(&r, out, n)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return string(out), nil
+}
+
+// readNumeric n цифр: по три в 10 битах, остаток — в 4 или 7 битах.
+func readNumeric(r *bitReader, out []byte, n int) ([]byte, error) {
+	for n > 0 {
+		digits := min(n, 3)
+		width := [4]int{0, 4, 7, 10}[digits]
+		if r.left() < width {
+			return nil, fmt.Errorf("%w: truncated data", ErrUnreadable)
+		}
+
+		v := r.read(width)
+		group := fmt.Sprintf("%0*d", digits, v)
+		if len(group) != digits {
+			return nil, fmt.Errorf("%w: bad numeric data", ErrUnreadable)
+		}
+
+		out = append(out, group...)
+		n -= digits
+	}
+
+	return out, nil
+}
+
+// readAlphanumeric n символов: по два в 11 битах, последний нечётный — в 6.
+func readAlphanumeric(r *bitReader, out []byte, n int) ([]byte, error) {
+	size := len(alphanumeric)
+
+	for ; n >= 2; n -= 2 {
+		if r.left() < 11 {
+			return nil, fmt.Errorf("%w: truncated data", ErrUnreadable)
+		}
+
+		v := r.read(11)
+		if v >= size*size {
+			return nil, fmt.Errorf("%w: bad alphanumeric data", ErrUnreadable)
+		}
+		out = append(out, alphanumeric[v/size], alphanumeric[v%size])
+	}
+
+	if n == 1 {
+		if r.left() < 6 {
+			return nil, fmt.Errorf("%w: truncated data", ErrUnreadable)
+		}
+
+		v := r.read(6)
+		if v >= size {
+			return nil, fmt.Errorf("%w: bad alphanumeric data", ErrUnreadable)
+		}
+		out = append(out, alphanumeric[v])
+	}
+
+	return out, nil
+}
+
+// readBytes n байтов по 8 бит.
+func readBytes(r *bitReader, out []byte, n int) ([]byte, error) {
+	if r.left() < 8*n {
+		return nil, fmt.Errorf("%w: truncated data", ErrUnreadable)
+	}
+
+	for ; n > 0; n-- {
+		out = append(out, byte(r.read(8)))
+	}
+
+	return out, nil
+}
+
+type bitReader struct {
+	bytes []byte
+	n     int
+}
+
+func (r *bitReader) left() int {
+	return len(r.bytes)*8 - r.n
+}
+
+func (r *bitReader) read(count int) int {
+	v := 0
+	for i := 0; i < count; i++ {
+		v = v<<1 | int(r.bytes[r.n>>3]>>uint(7-r.n&7))&1
+		r.n++
+	}
+
+	return v
+}
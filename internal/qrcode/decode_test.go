@@ -0,0 +1,336 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// render рисует код на светлом фоне: каждый пиксель результата переводится
+// в координаты модулей обратным преобразованием toModule.
+func render(c *Code, side int, toModule func(x, y float64) (float64, float64)) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Gray{Y: 235}), image.Point{}, draw.Src)
+
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			mx, my := toModule(float64(x)+0.5, float64(y)+0.5)
+			ix, iy := int(math.Floor(mx)), int(math.Floor(my))
+			if ix >= 0 && iy >= 0 && ix < c.Size && iy < c.Size && c.Dark(ix, iy) {
+				img.SetGray(x, y, color.Gray{Y: 30})
+			}
+		}
+	}
+
+	return img
+}
+
+// rotated код со стороной модуля scale пикселей, повёрнутый на angle градусов.
+func rotated(c *Code, scale float64, angle float64) *image.Gray {
+	side := int(float64(c.Size+2*quietZone) * scale * 1.5)
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	mid := float64(side) / 2
+	half := float64(c.Size) / 2
+
+	return render(c, side, func(x, y float64) (float64, float64) {
+		dx, dy := (x-mid)/scale, (y-mid)/scale
+		return cos*dx + sin*dy + half, -sin*dx + cos*dy + half
+	})
+}
+
+// tilted код, снятый под углом: верхний край короче нижнего.
+func tilted(c *Code, scale float64) *image.Gray {
+	size := float64(c.Size)
+	side := int((size + 2*quietZone) * scale)
+	pad := quietZone * scale
+	far := pad + size*scale
+	shrink := size * scale * 0.12
+
+	toModule := newTransform(
+		[4]point{{pad + shrink, pad}, {far - shrink, pad}, {far, far}, {pad, far}},
+		[4]point{{0, 0}, {size, 0}, {size, size}, {0, size}},
+	)
+
+	return render(c, side, func(x, y float64) (float64, float64) {
+		p := toModule.apply(x, y)
+		return p.x, p.y
+	})
+}
+
+// recompress изображение после сжатия JPEG, как у фото в Telegram.
+func recompress(t *testing.T, img image.Image, quality int) image.Image {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+
+	out, err := jpeg.Decode(&buf)
+	if err != nil {
+		t.Fatalf("jpeg.Decode: %v", err)
+	}
+
+	return out
+}
+
+// referenceCases изображения сторонних кодировщиков в testdata/reference:
+// их режимы сегментов и маски выбраны не нашим Encode.
+var referenceCases = []struct {
+	name    string
+	content string
+}{
+	{name: "short", content: "evbot"},
+	{name: "ticket_code", content: "ABCD-EFGH-IJKL-MNOP"},
+	{name: "ticket_link", content: "https://t.me/evbot_events_bot?start=ci_ABCDEFGHIJKLMNOP"},
+	{name: "cyrillic", content: "Билет на «Митап»"},
+	{name: "numeric", content: "4607012345678"},
+	{name: "alnum_link", content: "HTTPS://T.ME/EVBOT?START=CI_ABCD"},
+	{name: "v6", content: strings.Repeat("0123456789", 10)},
+	{name: "v10_full", content: strings.Repeat("c", 213)},
+}
+
+// loadReference читает изображение из testdata/reference.
+func loadReference(t *testing.T, name string) image.Image {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "reference", name))
+	if err != nil {
+		t.Fatalf("open reference: %v", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("decode reference %s: %v", name, err)
+	}
+
+	return img
+}
+
+// photographed имитирует снимок экрана телефона: перспектива с поворотом,
+// размытие, неравномерное освещение, шум матрицы и сжатие JPEG.
+func photographed(t *testing.T, src image.Image, seed int64) image.Image {
+	t.Helper()
+
+	rnd := rand.New(rand.NewSource(seed))
+	b := src.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	side := int(math.Max(w, h) * 1.3)
+	mid := float64(side) / 2
+
+	// углы исходного изображения на снимке: поворот и случайный сдвиг
+	// каждого угла до 7% стороны
+	sin, cos := math.Sincos((rnd.Float64()*50 - 25) * math.Pi / 180)
+	var quad [4]point
+	for i, c := range [4]point{{-w / 2, -h / 2}, {w / 2, -h / 2}, {w / 2, h / 2}, {-w / 2, h / 2}} {
+		jx := (rnd.Float64()*2 - 1) * 0.07 * w
+		jy := (rnd.Float64()*2 - 1) * 0.07 * h
+		quad[i] = point{mid + cos*c.x - sin*c.y + jx, mid + sin*c.x + cos*c.y + jy}
+	}
+	toSource := newTransform(quad, [4]point{{0, 0}, {w, 0}, {w, h}, {0, h}})
+
+	gray := func(x, y int) float64 {
+		if x < 0 || y < 0 || x >= b.Dx() || y >= b.Dy() {
+			return 200
+		}
+		return float64(color.GrayModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y)
+	}
+
+	sharp := make([]float64, side*side)
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			p := toSource.apply(float64(x)+0.5, float64(y)+0.5)
+			sx, sy := p.x-0.5, p.y-0.5
+			x0, y0 := int(math.Floor(sx)), int(math.Floor(sy))
+			fx, fy := sx-float64(x0), sy-float64(y0)
+
+			sharp[y*side+x] = (gray(x0, y0)*(1-fx)+gray(x0+1, y0)*fx)*(1-fy) +
+				(gray(x0, y0+1)*(1-fx)+gray(x0+1, y0+1)*fx)*fy
+		}
+	}
+
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			var sum float64
+			n := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if xx, yy := x+dx, y+dy; xx >= 0 && yy >= 0 && xx < side && yy < side {
+						sum += sharp[yy*side+xx]
+						n++
+					}
+				}
+			}
+
+			// свет падает сбоку: дальний угол заметно темнее
+			light := 1.05 - 0.45*float64(x+y)/float64(2*side)
+			v := sum/float64(n)*light + rnd.NormFloat64()*10
+			img.SetGray(x, y, color.Gray{Y: uint8(math.Max(0, math.Min(255, v)))})
+		}
+	}
+
+	return recompress(t, img, 70)
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	for _, tt := range goldenCases {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.content)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			for _, scale := range []int{3, 10} {
+				got, err := Decode(code.Image(scale))
+				if err != nil {
+					t.Fatalf("Decode(scale %d): %v", scale, err)
+				}
+				if got != tt.content {
+					t.Errorf("Decode(scale %d) = %q, want %q", scale, got, tt.content)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodePhoto(t *testing.T) {
+	const content = "https://t.me/evbot_events_bot?start=ci_ABCDEFGHIJKLMNOP"
+
+	code, err := Encode(content)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{name: "jpeg", img: recompress(t, code.Image(8), 60)},
+		{name: "rotated 90", img: rotated(code, 7, 90)},
+		{name: "rotated 180", img: rotated(code, 7, 180)},
+		{name: "rotated 17", img: recompress(t, rotated(code, 7.3, 17), 75)},
+		{name: "rotated -40", img: rotated(code, 6, -40)},
+		{name: "tilted", img: recompress(t, tilted(code, 9), 75)},
+		{name: "large rotated", img: recompress(t, rotated(code, 25, 30), 70)},
+		{name: "large tilted", img: recompress(t, tilted(code, 30), 70)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.img)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if got != content {
+				t.Errorf("Decode = %q, want %q", got, content)
+			}
+		})
+	}
+}
+
+func TestDecodeTiltedLargeVersion(t *testing.T) {
+	content := strings.Repeat("c", 213)
+
+	code, err := Encode(content)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := Decode(recompress(t, tilted(code, 6), 80))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got != content {
+		t.Errorf("Decode = %q, want %q", got, content)
+	}
+}
+
+func TestDecodeCorrectsDamage(t *testing.T) {
+	const content = "ABCD-EFGH-IJKL-MNOP"
+
+	code, err := Encode(content)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	// несколько испорченных модулей данных исправляются кодами коррекции
+	rnd := rand.New(rand.NewSource(1))
+	flipped := 0
+	for flipped < 6 {
+		x, y := rnd.Intn(code.Size), rnd.Intn(code.Size)
+		if code.function[y][x] {
+			continue
+		}
+		code.modules[y][x] = !code.modules[y][x]
+		flipped++
+	}
+
+	got, err := Decode(code.Image(5))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got != content {
+		t.Errorf("Decode = %q, want %q", got, content)
+	}
+}
+
+func TestDecodeNotFound(t *testing.T) {
+	blank := image.NewGray(image.Rect(0, 0, 200, 200))
+	draw.Draw(blank, blank.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	noise := image.NewGray(image.Rect(0, 0, 200, 200))
+	rnd := rand.New(rand.NewSource(1))
+	for i := range noise.Pix {
+		noise.Pix[i] = uint8(rnd.Intn(256))
+	}
+
+	for name, img := range map[string]image.Image{"blank": blank, "noise": noise} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode(img); !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrUnreadable) {
+				t.Errorf("Decode error = %v, want ErrNotFound or ErrUnreadable", err)
+			}
+		})
+	}
+}
+
+func TestDecodeReference(t *testing.T) {
+	for _, tt := range referenceCases {
+		for _, encoder := range []string{"skip2", "boombuler"} {
+			name := encoder + "_" + tt.name
+			t.Run(name, func(t *testing.T) {
+				img := loadReference(t, name+".png")
+
+				got, err := Decode(img)
+				if err != nil {
+					t.Fatalf("Decode: %v", err)
+				}
+				if got != tt.content {
+					t.Errorf("Decode = %q, want %q", got, tt.content)
+				}
+
+				for seed := int64(1); seed <= 3; seed++ {
+					got, err := Decode(photographed(t, img, seed))
+					if err != nil {
+						t.Errorf("Decode(photo %d): %v", seed, err)
+						continue
+					}
+					if got != tt.content {
+						t.Errorf("Decode(photo %d) = %q, want %q", seed, got, tt.content)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,540 @@
+// Package qrcode строит QR-коды (ISO/IEC 18004) без внешних зависимостей:
+// байтовый режим, уровень коррекции M, версии 1–10 — до 213 байт,
+// чего хватает для ссылок и кодов билетов.
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrTooLong содержимое не помещается в QR-код версии 10.
+var ErrTooLong = errors.New("content is too long")
+
+// quietZone ширина обязательного светлого поля вокруг кода, в модулях.
+const quietZone = 4
+
+// block параметры блоков данных версии для уровня коррекции M.
+type block struct {
+	ecLen   int // кодовых слов коррекции на блок
+	g1Count int // блоков первой группы
+	g1Data  int // кодовых слов данных в блоке первой группы
+	g2Count int // блоков второй группы (в них на одно слово данных больше)
+}
+
+// blocks индекс — номер версии.
+var blocks = [...]block{
+	1:  {10, 1, 16, 0},
+	2:  {16, 1, 28, 0},
+	3:  {26, 1, 44, 0},
+	4:  {18, 2, 32, 0},
+	5:  {24, 2, 43, 0},
+	6:  {16, 4, 27, 0},
+	7:  {18, 4, 31, 0},
+	8:  {22, 2, 38, 2},
+	9:  {22, 3, 36, 2},
+	10: {26, 4, 43, 1},
+}
+
+// alignments координаты центров выравнивающих узоров по версиям.
+var alignments = [...][]int{
+	1:  nil,
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+func (b block) dataLen() int {
+	return b.g1Count*b.g1Data + b.g2Count*(b.g1Data+1)
+}
+
+// Code построенный QR-код: квадрат Size×Size модулей без светлого поля.
+type Code struct {
+	Size    int
+	modules [][]bool
+	// function модули служебных узоров, не затрагиваемые данными и маской.
+	function [][]bool
+}
+
+// Encode кодирует строку в QR-код минимальной подходящей версии.
+func Encode(content string) (*Code, error) {
+	data := []byte(content)
+
+	version := 0
+	for v := 1; v < len(blocks); v++ {
+		// режим (4 бита) + длина (8 или 16 бит) + данные
+		header := 12
+		if v >= 10 {
+			header = 20
+		}
+		if header+8*len(data) <= 8*blocks[v].dataLen() {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	c := newCode(version)
+	c.drawFunctionPatterns(version)
+	c.drawCodewords(interleave(version, dataCodewords(version, data)))
+	c.applyBestMask(version)
+
+	return c, nil
+}
+
+// Dark модуль (x, y) тёмный.
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// Image изображение кода со светлым полем, scale — пикселей на модуль.
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	side := (c.Size + 2*quietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex((x+quietZone)*scale+dx, (y+quietZone)*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// PNG изображение кода в формате PNG.
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Image(scale)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func newCode(version int) *Code {
+	size := 17 + 4*version
+
+	c := &Code{
+		Size:     size,
+		modules:  make([][]bool, size),
+		function: make([][]bool, size),
+	}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.function[i] = make([]bool, size)
+	}
+
+	return c
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+// drawFunctionPatterns рисует поисковые, синхронизирующие и выравнивающие
+// узоры и резервирует место под служебную информацию.
+func (c *Code) drawFunctionPatterns(version int) {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	pos := alignments[version]
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			// углы, занятые поисковыми узорами
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	// резерв под формат и версию, настоящие значения — после выбора маски
+	c.drawFormat(0)
+	c.drawVersion(version)
+}
+
+// drawFinder поисковый узор 7×7 с разделителем вокруг, (cx, cy) — центр.
+func (c *Code) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= c.Size || y < 0 || y >= c.Size {
+				continue
+			}
+
+			d := abs(dx)
+			if abs(dy) > d {
+				d = abs(dy)
+			}
+			c.setFunction(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawAlignment выравнивающий узор 5×5, (cx, cy) — центр.
+func (c *Code) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			d := abs(dx)
+			if abs(dy) > d {
+				d = abs(dy)
+			}
+			c.setFunction(cx+dx, cy+dy, d != 1)
+		}
+	}
+}
+
+// levelM биты уровня коррекции M в служебной информации о формате.
+const levelM = 0
+
+// formatBits информация о формате: уровень коррекции и маска, защищённые BCH(15,5).
+func formatBits(level, mask int) int {
+	data := level<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawFormat записывает уровень коррекции и маску в обе копии.
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(levelM, mask)
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	// копия у левого верхнего поискового узора
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// копия, разделённая между двумя другими поисковыми узорами
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	// всегда тёмный модуль
+	c.setFunction(8, c.Size-8, true)
+}
+
+// versionBits номер версии, защищённый BCH(18,6).
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1f25)
+	}
+
+	return version<<12 | rem
+}
+
+// drawVersion записывает номер версии, начиная с версии 7.
+func (c *Code) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+
+	bits := versionBits(version)
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords раскладывает биты кодовых слов по модулям данных;
+// оставшиеся модули — биты-заполнители — остаются светлыми.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	c.walkData(func(x, y int) {
+		if i < len(codewords)*8 {
+			c.modules[y][x] = (codewords[i>>3]>>uint(7-i&7))&1 != 0
+		}
+		i++
+	})
+}
+
+// walkData обходит модули данных в порядке записи: змейкой по парам
+// столбцов справа налево, снизу вверх и обратно.
+func (c *Code) walkData(visit func(x, y int)) {
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		// столбец синхронизирующего узора пропускается
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+
+				if !c.function[y][x] {
+					visit(x, y)
+				}
+			}
+		}
+	}
+}
+
+// applyBestMask выбирает маску с наименьшим штрафом и записывает её в формат.
+func (c *Code) applyBestMask(version int) {
+	best, bestPenalty := 0, -1
+
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+
+		// маска обратима: повторное наложение возвращает исходные данные
+		c.applyMask(mask)
+	}
+
+	c.applyMask(best)
+	c.drawFormat(best)
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.function[y][x] {
+				continue
+			}
+
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty штраф по четырём правилам стандарта: длинные серии, блоки 2×2,
+// узоры, похожие на поисковые, и перекос тёмных модулей.
+func (c *Code) penalty() int {
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return c.modules[x][y]
+		}
+		return c.modules[y][x]
+	}
+
+	finderLike := [2][11]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	result := 0
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < c.Size; y++ {
+			run := 1
+			for x := 1; x <= c.Size; x++ {
+				if x < c.Size && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for x := 0; x+11 <= c.Size; x++ {
+				for _, pattern := range finderLike {
+					match := true
+					for k := 0; k < 11; k++ {
+						if at(x+k, y, vertical) != pattern[k] {
+							match = false
+							break
+						}
+					}
+					if match {
+						result += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+
+			if x+1 < c.Size && y+1 < c.Size {
+				v := c.modules[y][x]
+				if v == c.modules[y][x+1] && v == c.modules[y+1][x] && v == c.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	result += abs(dark*100/total-50) / 5 * 10
+
+	return result
+}
+
+// dataCodewords формирует поток данных: режим, длина, байты, терминатор и заполнение.
+func dataCodewords(version int, data []byte) []byte {
+	capacity := blocks[version].dataLen()
+
+	var w bitWriter
+	w.write(0x4, 4)
+	if version >= 10 {
+		w.write(len(data), 16)
+	} else {
+		w.write(len(data), 8)
+	}
+	for _, b := range data {
+		w.write(int(b), 8)
+	}
+
+	terminator := capacity*8 - w.n
+	if terminator > 4 {
+		terminator = 4
+	}
+	w.write(0, terminator)
+	if w.n%8 != 0 {
+		w.write(0, 8-w.n%8)
+	}
+
+	out := w.bytes
+	for pad := byte(0xec); len(out) < capacity; pad ^= 0xec ^ 0x11 {
+		out = append(out, pad)
+	}
+
+	return out
+}
+
+// interleave делит данные на блоки, добавляет к каждому коды Рида — Соломона
+// и перемежает слова блоков.
+func interleave(version int, data []byte) []byte {
+	b := blocks[version]
+	count := b.g1Count + b.g2Count
+
+	dataBlocks := make([][]byte, 0, count)
+	ecBlocks := make([][]byte, 0, count)
+
+	offset := 0
+	for i := 0; i < count; i++ {
+		n := b.g1Data
+		if i >= b.g1Count {
+			n++
+		}
+
+		chunk := data[offset : offset+n]
+		offset += n
+
+		dataBlocks = append(dataBlocks, chunk)
+		ecBlocks = append(ecBlocks, reedSolomon(chunk, b.ecLen))
+	}
+
+	out := make([]byte, 0, len(data)+count*b.ecLen)
+	for i := 0; i <= b.g1Data; i++ {
+		for _, blk := range dataBlocks {
+			if i < len(blk) {
+				out = append(out, blk[i])
+			}
+		}
+	}
+	for i := 0; i < b.ecLen; i++ {
+		for _, blk := range ecBlocks {
+			out = append(out, blk[i])
+		}
+	}
+
+	return out
+}
+
+type bitWriter struct {
+	bytes []byte
+	n     int
+}
+
+func (w *bitWriter) write(value, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.bytes = append(w.bytes, 0)
+		}
+		if (value>>uint(i))&1 != 0 {
+			w.bytes[len(w.bytes)-1] |= 0x80 >> uint(w.n%8)
+		}
+		w.n++
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
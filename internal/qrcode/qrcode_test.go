@@ -0,0 +1,165 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// goldenCases содержимое и ожидаемая версия кода; границы версий проверяются
+// строками предельной длины. Эталоны testdata/<name>.golden построены не Encode,
+// а сторонним кодировщиком (testdata/reference/gen), и при изменении списка
+// перестраиваются им же.
+var goldenCases = []struct {
+	name    string
+	content string
+	version int
+}{
+	{name: "short", content: "evbot", version: 1},
+	{name: "v1_full", content: strings.Repeat("a", 14), version: 1},
+	{name: "v2_min", content: strings.Repeat("a", 15), version: 2},
+	{name: "ticket_code", content: "ABCD-EFGH-IJKL-MNOP", version: 2},
+	{name: "cyrillic", content: "Билет на «Митап»", version: 3},
+	{name: "ticket_link", content: "https://t.me/evbot_events_bot?start=ci_ABCDEFGHIJKLMNOP", version: 4},
+	{name: "v6", content: strings.Repeat("0123456789", 10), version: 6},
+	{name: "v7_full", content: strings.Repeat("b", 122), version: 7},
+	{name: "v8_min", content: strings.Repeat("b", 123), version: 8},
+	{name: "v10_full", content: strings.Repeat("c", 213), version: 10},
+}
+
+// matrix код построчно: «#» — тёмный модуль, «.» — светлый.
+func matrix(c *Code) []byte {
+	var buf bytes.Buffer
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Dark(x, y) {
+				buf.WriteByte('#')
+			} else {
+				buf.WriteByte('.')
+			}
+		}
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+func TestEncodeGolden(t *testing.T) {
+	for _, tt := range goldenCases {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.content)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			if version := (code.Size - 17) / 4; version != tt.version {
+				t.Errorf("version = %d, want %d", version, tt.version)
+			}
+
+			got := matrix(code)
+			path := filepath.Join("testdata", tt.name+".golden")
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("code differs from %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("c", 214)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Encode(214 bytes) error = %v, want ErrTooLong", err)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	// значения из таблицы стандарта для уровня M
+	want := [8]string{
+		"101010000010010",
+		"101000100100101",
+		"101111001111100",
+		"101101101001011",
+		"100010111111001",
+		"100000011001110",
+		"100111110010111",
+		"100101010100000",
+	}
+
+	for mask, bits := range want {
+		if got := fmt.Sprintf("%015b", formatBits(levelM, mask)); got != bits {
+			t.Errorf("formatBits(M, %d) = %s, want %s", mask, got, bits)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	// значения из таблицы стандарта
+	want := map[int]int{7: 0x07c94, 8: 0x085bc, 9: 0x09a99, 10: 0x0a4d3}
+
+	for version, bits := range want {
+		if got := versionBits(version); got != bits {
+			t.Errorf("versionBits(%d) = %#05x, want %#05x", version, got, bits)
+		}
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	// «HELLO WORLD», версия 1-M: известный пример кодовых слов коррекции
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := reedSolomon(data, len(want)); !bytes.Equal(got, want) {
+		t.Errorf("reedSolomon = %v, want %v", got, want)
+	}
+}
+
+func TestReedSolomonCorrect(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	const ecLen = 10
+	codeword := append(append([]byte{}, data...), reedSolomon(data, ecLen)...)
+
+	tests := []struct {
+		name    string
+		errors  map[int]byte
+		wantErr bool
+	}{
+		{name: "no errors"},
+		{name: "one error", errors: map[int]byte{3: 0xff}},
+		{name: "error in correction codewords", errors: map[int]byte{20: 0x01}},
+		{name: "five errors", errors: map[int]byte{0: 1, 5: 2, 11: 3, 17: 4, 25: 5}},
+		{name: "six errors", errors: map[int]byte{0: 1, 5: 2, 11: 3, 17: 4, 22: 5, 25: 6}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := append([]byte{}, codeword...)
+			for i, e := range tt.errors {
+				block[i] ^= e
+			}
+
+			err := rsCorrect(block, ecLen)
+			if tt.wantErr {
+				// больше n/2 ошибок исправить нельзя; главное — не выдать неверные данные
+				if err == nil && !bytes.Equal(block, codeword) {
+					t.Error("rsCorrect returned wrong data without error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("rsCorrect: %v", err)
+			}
+			if !bytes.Equal(block, codeword) {
+				t.Errorf("block = %v, want %v", block, codeword)
+			}
+		})
+	}
+}
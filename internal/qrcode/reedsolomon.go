@@ -0,0 +1,206 @@
+package qrcode
+
+// Арифметика поля GF(256) с образующим многочленом x⁸+x⁴+x³+x²+1 (0x11d).
+var (
+	gfExp [512]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+
+	// продолжение таблицы избавляет умножение от взятия по модулю
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// generator многочлен (x−α⁰)(x−α¹)…(x−αⁿ⁻¹), старший коэффициент опущен.
+func generator(n int) []byte {
+	g := make([]byte, n)
+	g[n-1] = 1
+
+	root := byte(1)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			g[j] = gfMul(g[j], root)
+			if j+1 < n {
+				g[j] ^= g[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+
+	return g
+}
+
+// reedSolomon кодовые слова коррекции: остаток от деления data·xⁿ на генератор.
+func reedSolomon(data []byte, n int) []byte {
+	g := generator(n)
+	rem := make([]byte, n)
+
+	for _, b := range data {
+		factor := b ^ rem[0]
+		copy(rem, rem[1:])
+		rem[n-1] = 0
+
+		for i := range rem {
+			rem[i] ^= gfMul(g[i], factor)
+		}
+	}
+
+	return rem
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// gfPow αⁿ.
+func gfPow(n int) byte {
+	return gfExp[n%255]
+}
+
+// evalPoly значение многочлена с коэффициентами по возрастанию степени.
+func evalPoly(p []byte, x byte) byte {
+	var y byte
+	for i := len(p) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ p[i]
+	}
+
+	return y
+}
+
+// syndromes значения кодового слова в корнях генератора α⁰…αⁿ⁻¹;
+// все нули — ошибок нет.
+func syndromes(block []byte, n int) ([]byte, bool) {
+	synd := make([]byte, n)
+	clean := true
+
+	for j := range synd {
+		x := gfPow(j)
+		for _, b := range block {
+			synd[j] = gfMul(synd[j], x) ^ b
+		}
+		if synd[j] != 0 {
+			clean = false
+		}
+	}
+
+	return synd, clean
+}
+
+// rsCorrect исправляет на месте до n/2 ошибочных слов блока: данные и n слов
+// коррекции. Локатор ошибок строится алгоритмом Берлекэмпа — Мэсси,
+// позиции находятся перебором Ченя, значения — по формуле Форни.
+func rsCorrect(block []byte, n int) error {
+	synd, clean := syndromes(block, n)
+	if clean {
+		return nil
+	}
+
+	locator := []byte{1}
+	prev := []byte{1}
+	errs, shift, last := 0, 1, byte(1)
+
+	for k := 0; k < n; k++ {
+		d := synd[k]
+		for i := 1; i <= errs && i < len(locator); i++ {
+			d ^= gfMul(locator[i], synd[k-i])
+		}
+
+		if d == 0 {
+			shift++
+			continue
+		}
+
+		next := make([]byte, len(locator))
+		if len(prev)+shift > len(next) {
+			next = make([]byte, len(prev)+shift)
+		}
+		copy(next, locator)
+
+		factor := gfDiv(d, last)
+		for i, p := range prev {
+			next[i+shift] ^= gfMul(factor, p)
+		}
+
+		if 2*errs <= k {
+			prev, errs, last, shift = locator, k+1-errs, d, 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+
+	if 2*errs > n {
+		return errTooManyErrors
+	}
+	// степень локатора не больше числа ошибок, старшие коэффициенты нулевые
+	if len(locator) > errs+1 {
+		locator = locator[:errs+1]
+	}
+
+	// Ω(x) = S(x)·Λ(x) mod xⁿ
+	omega := make([]byte, n)
+	for i := range omega {
+		for j := 0; j <= i && j < len(locator); j++ {
+			omega[i] ^= gfMul(synd[i-j], locator[j])
+		}
+	}
+
+	// производная Λ в поле характеристики 2: остаются нечётные степени
+	derivative := make([]byte, len(locator))
+	for i := 1; i < len(locator); i += 2 {
+		derivative[i-1] = locator[i]
+	}
+
+	found := 0
+	for k := range block {
+		// слово k — коэффициент при x^(len−1−k)
+		power := len(block) - 1 - k
+		inv := gfPow(255 - power%255)
+		if evalPoly(locator, inv) != 0 {
+			continue
+		}
+
+		denom := evalPoly(derivative, inv)
+		if denom == 0 {
+			return errTooManyErrors
+		}
+
+		block[k] ^= gfMul(gfPow(power), gfDiv(evalPoly(omega, inv), denom))
+		found++
+	}
+
+	if found != errs {
+		return errTooManyErrors
+	}
+
+	if _, clean := syndromes(block, n); !clean {
+		return errTooManyErrors
+	}
+
+	return nil
+}
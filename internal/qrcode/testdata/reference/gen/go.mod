@@ -0,0 +1,8 @@
+module reference
+
+go 1.22
+
+require (
+	github.com/boombuler/barcode v1.1.0
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+)
@@ -0,0 +1,104 @@
+// Команда gen строит эталоны для тестов пакета qrcode сторонними
+// кодировщиками, независимыми от нашего Encode:
+//
+//   - ../../<name>.golden — матрицы boombuler/barcode (уровень M, байтовый режим);
+//   - ../<encoder>_<name>.png — изображения boombuler/barcode и skip2/go-qrcode
+//     с режимами и масками, которые библиотеки выбирают сами.
+//
+// Запуск из этого каталога: go run .
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	skip2 "github.com/skip2/go-qrcode"
+)
+
+// goldenCases совпадают с goldenCases в qrcode_test.go.
+var goldenCases = []struct{ name, content string }{
+	{"short", "evbot"},
+	{"v1_full", strings.Repeat("a", 14)},
+	{"v2_min", strings.Repeat("a", 15)},
+	{"ticket_code", "ABCD-EFGH-IJKL-MNOP"},
+	{"cyrillic", "Билет на «Митап»"},
+	{"ticket_link", "https://t.me/evbot_events_bot?start=ci_ABCDEFGHIJKLMNOP"},
+	{"v6", strings.Repeat("0123456789", 10)},
+	{"v7_full", strings.Repeat("b", 122)},
+	{"v8_min", strings.Repeat("b", 123)},
+	{"v10_full", strings.Repeat("c", 213)},
+}
+
+// imageCases совпадают с referenceCases в decode_test.go.
+var imageCases = []struct{ name, content string }{
+	{"short", "evbot"},
+	{"ticket_code", "ABCD-EFGH-IJKL-MNOP"},
+	{"ticket_link", "https://t.me/evbot_events_bot?start=ci_ABCDEFGHIJKLMNOP"},
+	{"cyrillic", "Билет на «Митап»"},
+	{"numeric", "4607012345678"},
+	{"alnum_link", "HTTPS://T.ME/EVBOT?START=CI_ABCD"},
+	{"v6", strings.Repeat("0123456789", 10)},
+	{"v10_full", strings.Repeat("c", 213)},
+}
+
+func main() {
+	for _, c := range goldenCases {
+		code, err := qr.Encode(c.content, qr.M, qr.Unicode)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var b strings.Builder
+		side := code.Bounds().Dx()
+		for y := 0; y < side; y++ {
+			for x := 0; x < side; x++ {
+				if r, _, _, _ := code.At(x, y).RGBA(); r == 0 {
+					b.WriteByte('#')
+				} else {
+					b.WriteByte('.')
+				}
+			}
+			b.WriteByte('\n')
+		}
+
+		write(filepath.Join("..", "..", c.name+".golden"), []byte(b.String()))
+	}
+
+	for _, c := range imageCases {
+		picture, err := skip2.Encode(c.content, skip2.Medium, 256)
+		if err != nil {
+			log.Fatal(err)
+		}
+		write(filepath.Join("..", "skip2_"+c.name+".png"), picture)
+
+		code, err := qr.Encode(c.content, qr.M, qr.Auto)
+		if err != nil {
+			log.Fatal(err)
+		}
+		scaled, err := barcode.Scale(code, code.Bounds().Dx()*6, code.Bounds().Dy()*6)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// у boombuler нет светлого поля: без него код не найти на фото
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, withQuietZone(scaled, 4*6)); err != nil {
+			log.Fatal(err)
+		}
+		write(filepath.Join("..", "boombuler_"+c.name+".png"), buf.Bytes())
+	}
+}
+
+func write(path string, data []byte) {
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(path)
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// withQuietZone изображение с белым полем шириной pad пикселей.
+func withQuietZone(img image.Image, pad int) image.Image {
+	b := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, b.Dx()+2*pad, b.Dy()+2*pad))
+	draw.Draw(out, out.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(out, b.Sub(b.Min).Add(image.Pt(pad, pad)), img, b.Min, draw.Src)
+
+	return out
+}
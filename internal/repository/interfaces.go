@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	domain "github.com/binaryty/evbot/internal/domain/entities"
 )
@@ -11,26 +12,115 @@ var (
 	ErrStateNotFound = errors.New("state not found")
 )
 
+// Transactor выполняет fn в одной транзакции: репозитории, вызванные
+// с контекстом, который получает fn, работают внутри неё.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type EventRepository interface {
 	Save(ctx context.Context, event domain.Event) (int64, error)
 	GetByID(ctx context.Context, eventID int64) (*domain.Event, error)
 	GetByUserID(ctx context.Context, userID int64) ([]domain.Event, error)
+	GetByChatID(ctx context.Context, chatID int64) ([]domain.Event, error)
 	GetAll(ctx context.Context) ([]domain.Event, error)
 	Delete(ctx context.Context, eventID int64) error
+	SetNotifyMode(ctx context.Context, eventID int64, mode domain.NotifyMode) error
+	SetApproval(ctx context.Context, eventID int64, required bool) error
 }
 
+// StateRepository состояния диалогов. Черновиков мастера событий у пользователя
+// может быть несколько, один из них активный. Служебный диалог (см.
+// domain.EventState.IsDraft) хранится отдельно, один на пользователя, и,
+// пока он не завершён, перекрывает активный черновик.
 type StateRepository interface {
+	// GetState возвращает служебный диалог, а если его нет — активный черновик.
 	GetState(ctx context.Context, userID int64) (*domain.EventState, error)
+	// SaveState сохраняет служебный диалог или обновляет активный черновик
+	// (создаёт, если его нет) — в зависимости от state.Flow.
 	SaveState(ctx context.Context, userID int64, state domain.EventState) error
+	// DeleteState завершает служебный диалог, а если его нет — удаляет активный черновик.
 	DeleteState(ctx context.Context, userID int64) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+	// CreateDraft создаёт черновик события и делает его активным;
+	// незавершённый служебный диалог при этом прерывается.
+	CreateDraft(ctx context.Context, userID int64, state domain.EventState) (int64, error)
+	ListDrafts(ctx context.Context, userID int64) ([]domain.Draft, error)
+	// ActivateDraft делает черновик активным; служебный диалог прерывается.
+	ActivateDraft(ctx context.Context, userID int64, draftID int64) error
+	DeleteDraft(ctx context.Context, userID int64, draftID int64) error
+	// RenameDraft задаёт черновику имя, которое сохраняется при дальнейшем
+	// редактировании.
+	RenameDraft(ctx context.Context, userID int64, draftID int64, name string) error
 }
 
 type RegistrationRepository interface {
-	Register(ctx context.Context, eventID int64, userID int64) error
+	// Register при повторной регистрации возвращает ошибку, оборачивающую domain.ErrAlreadyRegistered.
+	Register(ctx context.Context, eventID int64, userID int64, ticket string, status domain.RegistrationStatus) error
 	Unregister(ctx context.Context, eventID int64, userID int64) error
+	// Status возвращает domain.RegistrationNone, если пользователь не зарегистрирован.
+	Status(ctx context.Context, eventID int64, userID int64) (domain.RegistrationStatus, error)
+	// Approve подтверждает заявку, ожидающую решения.
+	Approve(ctx context.Context, eventID int64, userID int64) error
 	GetParticipants(ctx context.Context, eventID int64) ([]domain.Participant, error)
 	IsRegistered(ctx context.Context, eventID int64, userID int64) (bool, error)
 	GetParticipantsPaginated(ctx context.Context, eventID int64, offset int, limit int) ([]domain.Participant, int, error)
+	GetTicket(ctx context.Context, eventID int64, userID int64) (*domain.Ticket, error)
+	GetTicketByToken(ctx context.Context, token string) (*domain.Ticket, error)
+	CheckIn(ctx context.Context, token string, at time.Time) error
+}
+
+// QuestionRepository вопросы событий и ответы участников на них.
+type QuestionRepository interface {
+	SaveQuestions(ctx context.Context, eventID int64, questions []domain.Question) error
+	GetQuestions(ctx context.Context, eventID int64) ([]domain.Question, error)
+	SaveAnswers(ctx context.Context, eventID int64, userID int64, answers []domain.Answer) error
+	// GetAnswers ответы всех участников события по ID пользователя.
+	GetAnswers(ctx context.Context, eventID int64) (map[int64][]domain.Answer, error)
+	DeleteAnswers(ctx context.Context, eventID int64, userID int64) error
+}
+
+// NotificationRepository очередь изменений регистраций для ежедневной сводки.
+type NotificationRepository interface {
+	Enqueue(ctx context.Context, change domain.RegistrationChange) error
+	Pending(ctx context.Context) ([]domain.RegistrationChange, error)
+	Delete(ctx context.Context, ids []int64) error
+}
+
+// FeedbackRepository отзывы участников и отметки о разосланных опросах.
+type FeedbackRepository interface {
+	SaveRating(ctx context.Context, feedback domain.Feedback) error
+	SaveComment(ctx context.Context, eventID int64, userID int64, comment string) error
+	GetByEventID(ctx context.Context, eventID int64) ([]domain.Feedback, error)
+	// EventsAwaitingSurvey события, начавшиеся позже after и не позже before,
+	// по которым опрос ещё не рассылался.
+	EventsAwaitingSurvey(ctx context.Context, after, before time.Time) ([]domain.Event, error)
+	MarkSurveySent(ctx context.Context, eventID int64, at time.Time) error
+}
+
+// BackupRepository выгрузка и восстановление всех постоянных данных.
+type BackupRepository interface {
+	// Dump читает согласованный снимок данных.
+	Dump(ctx context.Context) (*domain.Snapshot, error)
+	// Restore вставляет строки снимка, заменяя записи с теми же ключами;
+	// повторное восстановление той же копии ничего не меняет.
+	Restore(ctx context.Context, snapshot *domain.Snapshot) error
+}
+
+type ChannelRepository interface {
+	SetChannel(ctx context.Context, chatID int64, channelID int64) error
+	GetChannel(ctx context.Context, chatID int64) (int64, error)
+	DeleteChannel(ctx context.Context, chatID int64) error
+	SavePost(ctx context.Context, post domain.EventPost) error
+	GetPosts(ctx context.Context, eventID int64) ([]domain.EventPost, error)
+	DeletePosts(ctx context.Context, eventID int64) error
+}
+
+type TemplateRepository interface {
+	Save(ctx context.Context, template domain.Template) (int64, error)
+	GetByID(ctx context.Context, templateID int64) (*domain.Template, error)
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Template, error)
+	Delete(ctx context.Context, templateID int64) error
 }
 
 type UserRepository interface {
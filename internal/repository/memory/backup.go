@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// ErrBackupUnsupported данные в памяти не переживают перезапуск: копировать их незачем.
+var ErrBackupUnsupported = errors.New("backup is not supported by the in-memory store")
+
+type BackupRepository struct{}
+
+func NewBackupRepository() *BackupRepository {
+	return &BackupRepository{}
+}
+
+func (r *BackupRepository) Dump(context.Context) (*domain.Snapshot, error) {
+	return nil, ErrBackupUnsupported
+}
+
+func (r *BackupRepository) Restore(context.Context, *domain.Snapshot) error {
+	return ErrBackupUnsupported
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type ChannelRepository struct {
+	s *Store
+}
+
+func NewChannelRepository(s *Store) *ChannelRepository {
+	return &ChannelRepository{
+		s: s,
+	}
+}
+
+func (r *ChannelRepository) SetChannel(ctx context.Context, chatID int64, channelID int64) error {
+	defer r.s.lock(ctx)()
+
+	r.s.channels[chatID] = channelID
+
+	return nil
+}
+
+func (r *ChannelRepository) GetChannel(_ context.Context, chatID int64) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	channelID, ok := r.s.channels[chatID]
+	if !ok {
+		return 0, domain.ErrChannelNotFound
+	}
+
+	return channelID, nil
+}
+
+func (r *ChannelRepository) DeleteChannel(ctx context.Context, chatID int64) error {
+	defer r.s.lock(ctx)()
+
+	delete(r.s.channels, chatID)
+
+	return nil
+}
+
+func (r *ChannelRepository) SavePost(ctx context.Context, post domain.EventPost) error {
+	defer r.s.lock(ctx)()
+
+	posts := r.s.posts[post.EventID]
+	for i := range posts {
+		if posts[i].ChannelID == post.ChannelID {
+			posts[i].MessageID = post.MessageID
+			return nil
+		}
+	}
+
+	r.s.posts[post.EventID] = append(posts, post)
+
+	return nil
+}
+
+func (r *ChannelRepository) GetPosts(_ context.Context, eventID int64) ([]domain.EventPost, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return append([]domain.EventPost(nil), r.s.posts[eventID]...), nil
+}
+
+func (r *ChannelRepository) DeletePosts(ctx context.Context, eventID int64) error {
+	defer r.s.lock(ctx)()
+
+	delete(r.s.posts, eventID)
+
+	return nil
+}
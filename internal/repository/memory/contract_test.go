@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/binaryty/evbot/internal/repository/repotest"
+)
+
+func TestContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Storage {
+		s := NewStore()
+
+		return repotest.Storage{
+			Events:        NewEventRepository(s),
+			Users:         NewUserRepository(s),
+			States:        NewStateRepository(s),
+			Registrations: NewRegistrationRepository(s),
+			Channels:      NewChannelRepository(s),
+			Templates:     NewTemplateRepository(s),
+			Notifications: NewNotificationRepository(s),
+			Feedback:      NewFeedbackRepository(s),
+			Questions:     NewQuestionRepository(s),
+			Transactor:    NewTransactor(s),
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type EventRepository struct {
+	s *Store
+}
+
+func NewEventRepository(s *Store) *EventRepository {
+	return &EventRepository{
+		s: s,
+	}
+}
+
+func (r *EventRepository) Save(ctx context.Context, e domain.Event) (int64, error) {
+	defer r.s.lock(ctx)()
+
+	if e.NotifyMode == "" {
+		e.NotifyMode = domain.NotifyOff
+	}
+
+	r.s.eventSeq++
+	e.ID = r.s.eventSeq
+	e.Date = e.Date.UTC()
+	e.CreatedAt = e.CreatedAt.UTC()
+	// вопросы хранит QuestionRepository
+	e.Questions = nil
+
+	r.s.events[e.ID] = e
+
+	return e.ID, nil
+}
+
+func (r *EventRepository) GetByID(_ context.Context, eventID int64) (*domain.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	event, ok := r.s.events[eventID]
+	if !ok {
+		return nil, domain.ErrEventNotFound
+	}
+
+	return &event, nil
+}
+
+func (r *EventRepository) GetByUserID(_ context.Context, userID int64) ([]domain.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.filterEvents(func(e domain.Event) bool { return e.UserID == userID }), nil
+}
+
+func (r *EventRepository) GetByChatID(_ context.Context, chatID int64) ([]domain.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.filterEvents(func(e domain.Event) bool { return e.ChatID == chatID }), nil
+}
+
+func (r *EventRepository) GetAll(_ context.Context) ([]domain.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.filterEvents(func(domain.Event) bool { return true }), nil
+}
+
+// Delete удаляет событие вместе с зависящими от него данными, как каскад в базе.
+func (r *EventRepository) Delete(ctx context.Context, eventID int64) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.events[eventID]; !ok {
+		return domain.ErrEventNotFound
+	}
+
+	delete(r.s.events, eventID)
+	delete(r.s.registrations, eventID)
+	delete(r.s.questions, eventID)
+	delete(r.s.answers, eventID)
+	delete(r.s.surveys, eventID)
+	delete(r.s.feedback, eventID)
+	delete(r.s.posts, eventID)
+
+	changes := r.s.changes[:0]
+	for _, c := range r.s.changes {
+		if c.EventID != eventID {
+			changes = append(changes, c)
+		}
+	}
+	r.s.changes = changes
+
+	return nil
+}
+
+func (r *EventRepository) SetNotifyMode(ctx context.Context, eventID int64, mode domain.NotifyMode) error {
+	return r.update(ctx, eventID, func(e *domain.Event) { e.NotifyMode = mode })
+}
+
+func (r *EventRepository) SetApproval(ctx context.Context, eventID int64, required bool) error {
+	return r.update(ctx, eventID, func(e *domain.Event) { e.RequiresApproval = required })
+}
+
+// update ...
+func (r *EventRepository) update(ctx context.Context, eventID int64, fn func(e *domain.Event)) error {
+	defer r.s.lock(ctx)()
+
+	event, ok := r.s.events[eventID]
+	if !ok {
+		return domain.ErrEventNotFound
+	}
+
+	fn(&event)
+	r.s.events[eventID] = event
+
+	return nil
+}
+
+// filterEvents события, подходящие под условие, новые первыми; вызывается под блокировкой.
+func (s *Store) filterEvents(match func(e domain.Event) bool) []domain.Event {
+	var events []domain.Event
+	for _, e := range s.events {
+		if match(e) {
+			events = append(events, e)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.After(events[j].Date)
+		}
+		return events[i].ID > events[j].ID
+	})
+
+	return events
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type FeedbackRepository struct {
+	s *Store
+}
+
+func NewFeedbackRepository(s *Store) *FeedbackRepository {
+	return &FeedbackRepository{
+		s: s,
+	}
+}
+
+// SaveRating сохраняет оценку; повторная оценка заменяет прежнюю, комментарий остаётся.
+func (r *FeedbackRepository) SaveRating(ctx context.Context, f domain.Feedback) error {
+	defer r.s.lock(ctx)()
+
+	if i, ok := r.s.feedbackIndex(f.EventID, f.User.ID); ok {
+		r.s.feedback[f.EventID][i].Rating = f.Rating
+		return nil
+	}
+
+	r.s.feedback[f.EventID] = append(r.s.feedback[f.EventID], domain.Feedback{
+		EventID:   f.EventID,
+		User:      domain.User{ID: f.User.ID},
+		Rating:    f.Rating,
+		CreatedAt: f.CreatedAt.UTC(),
+	})
+
+	return nil
+}
+
+func (r *FeedbackRepository) SaveComment(ctx context.Context, eventID int64, userID int64, comment string) error {
+	defer r.s.lock(ctx)()
+
+	i, ok := r.s.feedbackIndex(eventID, userID)
+	if !ok {
+		return domain.ErrFeedbackNotFound
+	}
+
+	r.s.feedback[eventID][i].Comment = comment
+
+	return nil
+}
+
+// GetByEventID новые отзывы первыми; имя автора берётся из пользователей.
+func (r *FeedbackRepository) GetByEventID(_ context.Context, eventID int64) ([]domain.Feedback, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var feedback []domain.Feedback
+	for _, f := range r.s.feedback[eventID] {
+		if user, ok := r.s.users[f.User.ID]; ok {
+			f.User = user
+		}
+
+		feedback = append(feedback, f)
+	}
+
+	sort.SliceStable(feedback, func(i, j int) bool {
+		return feedback[i].CreatedAt.After(feedback[j].CreatedAt)
+	})
+
+	return feedback, nil
+}
+
+func (r *FeedbackRepository) EventsAwaitingSurvey(_ context.Context, after, before time.Time) ([]domain.Event, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := r.s.filterEvents(func(e domain.Event) bool {
+		_, sent := r.s.surveys[e.ID]
+		return !sent && e.Date.After(after) && !e.Date.After(before)
+	})
+
+	// filterEvents отдаёт новые первыми, а опросы рассылаются от старых к новым
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+
+	return events, nil
+}
+
+func (r *FeedbackRepository) MarkSurveySent(ctx context.Context, eventID int64, at time.Time) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.surveys[eventID]; !ok {
+		r.s.surveys[eventID] = at.UTC()
+	}
+
+	return nil
+}
+
+// feedbackIndex вызывается под блокировкой.
+func (s *Store) feedbackIndex(eventID int64, userID int64) (int, bool) {
+	for i, f := range s.feedback[eventID] {
+		if f.User.ID == userID {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
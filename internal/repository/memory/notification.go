@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type NotificationRepository struct {
+	s *Store
+}
+
+func NewNotificationRepository(s *Store) *NotificationRepository {
+	return &NotificationRepository{
+		s: s,
+	}
+}
+
+func (r *NotificationRepository) Enqueue(ctx context.Context, c domain.RegistrationChange) error {
+	defer r.s.lock(ctx)()
+
+	r.s.changeSeq++
+	c.ID = r.s.changeSeq
+	c.CreatedAt = c.CreatedAt.UTC()
+
+	r.s.changes = append(r.s.changes, c)
+
+	return nil
+}
+
+// Pending возвращает накопленные изменения в порядке поступления.
+func (r *NotificationRepository) Pending(_ context.Context) ([]domain.RegistrationChange, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return append([]domain.RegistrationChange(nil), r.s.changes...), nil
+}
+
+func (r *NotificationRepository) Delete(ctx context.Context, ids []int64) error {
+	defer r.s.lock(ctx)()
+
+	deleted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+
+	changes := r.s.changes[:0]
+	for _, c := range r.s.changes {
+		if !deleted[c.ID] {
+			changes = append(changes, c)
+		}
+	}
+	r.s.changes = changes
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type QuestionRepository struct {
+	s *Store
+}
+
+func NewQuestionRepository(s *Store) *QuestionRepository {
+	return &QuestionRepository{
+		s: s,
+	}
+}
+
+func (r *QuestionRepository) SaveQuestions(ctx context.Context, eventID int64, questions []domain.Question) error {
+	defer r.s.lock(ctx)()
+
+	for i, q := range questions {
+		r.s.questionSeq++
+
+		q.ID = r.s.questionSeq
+		q.EventID = eventID
+		q.Position = i
+		q.Options = append([]string(nil), q.Options...)
+
+		r.s.questions[eventID] = append(r.s.questions[eventID], q)
+	}
+
+	return nil
+}
+
+func (r *QuestionRepository) GetQuestions(_ context.Context, eventID int64) ([]domain.Question, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var questions []domain.Question
+	for _, q := range r.s.questions[eventID] {
+		q.Options = append([]string(nil), q.Options...)
+		questions = append(questions, q)
+	}
+
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Position < questions[j].Position
+	})
+
+	return questions, nil
+}
+
+// SaveAnswers заменяет ответы участника.
+func (r *QuestionRepository) SaveAnswers(ctx context.Context, eventID int64, userID int64, answers []domain.Answer) error {
+	defer r.s.lock(ctx)()
+
+	byUser, ok := r.s.answers[eventID]
+	if !ok {
+		byUser = make(map[int64][]domain.Answer)
+		r.s.answers[eventID] = byUser
+	}
+
+	saved := byUser[userID]
+	for _, a := range answers {
+		replaced := false
+		for i := range saved {
+			if saved[i].QuestionID == a.QuestionID {
+				saved[i].Value = a.Value
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			saved = append(saved, a)
+		}
+	}
+	byUser[userID] = saved
+
+	return nil
+}
+
+// GetAnswers ответы упорядочены по позиции вопроса; ответы на удалённые вопросы пропускаются.
+func (r *QuestionRepository) GetAnswers(_ context.Context, eventID int64) (map[int64][]domain.Answer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	positions := make(map[int64]int)
+	for _, q := range r.s.questions[eventID] {
+		positions[q.ID] = q.Position
+	}
+
+	answers := make(map[int64][]domain.Answer)
+	for userID, saved := range r.s.answers[eventID] {
+		var list []domain.Answer
+		for _, a := range saved {
+			if _, ok := positions[a.QuestionID]; ok {
+				list = append(list, a)
+			}
+		}
+
+		if len(list) == 0 {
+			continue
+		}
+
+		sort.SliceStable(list, func(i, j int) bool {
+			return positions[list[i].QuestionID] < positions[list[j].QuestionID]
+		})
+		answers[userID] = list
+	}
+
+	return answers, nil
+}
+
+func (r *QuestionRepository) DeleteAnswers(ctx context.Context, eventID int64, userID int64) error {
+	defer r.s.lock(ctx)()
+
+	delete(r.s.answers[eventID], userID)
+
+	return nil
+}
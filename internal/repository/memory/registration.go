@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type RegistrationRepository struct {
+	s *Store
+}
+
+func NewRegistrationRepository(s *Store) *RegistrationRepository {
+	return &RegistrationRepository{
+		s: s,
+	}
+}
+
+// Register повторная регистрация нарушает первичный ключ, как и в базе:
+// возвращается ошибка, оборачивающая domain.ErrAlreadyRegistered.
+func (r *RegistrationRepository) Register(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	ticket string,
+	status domain.RegistrationStatus,
+) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.registration(eventID, userID); ok {
+		return fmt.Errorf("failed to register: %w", domain.ErrAlreadyRegistered)
+	}
+
+	r.s.registrations[eventID] = append(r.s.registrations[eventID], registration{
+		userID:    userID,
+		ticket:    ticket,
+		status:    status,
+		createdAt: time.Now().UTC(),
+	})
+
+	return nil
+}
+
+func (r *RegistrationRepository) Unregister(ctx context.Context, eventID int64, userID int64) error {
+	defer r.s.lock(ctx)()
+
+	i, ok := r.s.registration(eventID, userID)
+	if !ok {
+		return domain.ErrRegistrationNotFound
+	}
+
+	regs := r.s.registrations[eventID]
+	r.s.registrations[eventID] = append(regs[:i], regs[i+1:]...)
+
+	return nil
+}
+
+func (r *RegistrationRepository) Status(_ context.Context, eventID int64, userID int64) (domain.RegistrationStatus, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	i, ok := r.s.registration(eventID, userID)
+	if !ok {
+		return domain.RegistrationNone, nil
+	}
+
+	return r.s.registrations[eventID][i].status, nil
+}
+
+func (r *RegistrationRepository) Approve(ctx context.Context, eventID int64, userID int64) error {
+	defer r.s.lock(ctx)()
+
+	i, ok := r.s.registration(eventID, userID)
+	if !ok || r.s.registrations[eventID][i].status != domain.RegistrationPending {
+		return domain.ErrRegistrationNotFound
+	}
+
+	r.s.registrations[eventID][i].status = domain.RegistrationApproved
+
+	return nil
+}
+
+// GetParticipants регистрации без записи о пользователе пропускаются, как при JOIN.
+func (r *RegistrationRepository) GetParticipants(_ context.Context, eventID int64) ([]domain.Participant, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.s.participants(r.s.registrations[eventID]), nil
+}
+
+func (r *RegistrationRepository) IsRegistered(_ context.Context, eventID int64, userID int64) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	_, ok := r.s.registration(eventID, userID)
+
+	return ok, nil
+}
+
+func (r *RegistrationRepository) GetParticipantsPaginated(
+	_ context.Context,
+	eventID int64,
+	offset int,
+	limit int) ([]domain.Participant, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	regs := r.s.registrations[eventID]
+	total := len(regs)
+
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if limit < 0 || end > total {
+		end = total
+	}
+
+	return r.s.participants(regs[offset:end]), total, nil
+}
+
+func (r *RegistrationRepository) GetTicket(_ context.Context, eventID int64, userID int64) (*domain.Ticket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	i, ok := r.s.registration(eventID, userID)
+	if !ok {
+		return nil, domain.ErrTicketNotFound
+	}
+
+	return newTicket(eventID, r.s.registrations[eventID][i]), nil
+}
+
+func (r *RegistrationRepository) GetTicketByToken(_ context.Context, token string) (*domain.Ticket, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	eventID, i, ok := r.s.ticket(token)
+	if !ok {
+		return nil, domain.ErrTicketNotFound
+	}
+
+	return newTicket(eventID, r.s.registrations[eventID][i]), nil
+}
+
+// CheckIn отмечает приход по билету; повторная отметка не меняет время первой.
+func (r *RegistrationRepository) CheckIn(ctx context.Context, token string, at time.Time) error {
+	defer r.s.lock(ctx)()
+
+	eventID, i, ok := r.s.ticket(token)
+	if !ok {
+		return domain.ErrTicketNotFound
+	}
+
+	reg := &r.s.registrations[eventID][i]
+	if !reg.checkedInAt.IsZero() {
+		return domain.ErrAlreadyCheckedIn
+	}
+	reg.checkedInAt = at.UTC()
+
+	return nil
+}
+
+// registration индекс регистрации пользователя в списке события; вызывается под блокировкой.
+func (s *Store) registration(eventID int64, userID int64) (int, bool) {
+	for i, reg := range s.registrations[eventID] {
+		if reg.userID == userID {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// ticket событие и индекс регистрации по билету; вызывается под блокировкой.
+func (s *Store) ticket(token string) (int64, int, bool) {
+	for eventID, regs := range s.registrations {
+		for i, reg := range regs {
+			if reg.ticket == token {
+				return eventID, i, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// participants вызывается под блокировкой.
+func (s *Store) participants(regs []registration) []domain.Participant {
+	var participants []domain.Participant
+	for _, reg := range regs {
+		user, ok := s.users[reg.userID]
+		if !ok {
+			continue
+		}
+
+		participants = append(participants, domain.Participant{
+			User:         user,
+			Status:       reg.status,
+			RegisteredAt: reg.createdAt,
+			CheckedInAt:  reg.checkedInAt,
+		})
+	}
+
+	return participants
+}
+
+func newTicket(eventID int64, reg registration) *domain.Ticket {
+	return &domain.Ticket{
+		EventID:     eventID,
+		UserID:      reg.userID,
+		Token:       reg.ticket,
+		Status:      reg.status,
+		CheckedInAt: reg.checkedInAt,
+	}
+}
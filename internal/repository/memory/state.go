@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+// StateRepository хранит состояния диалогов: черновики мастера событий,
+// из которых один активный, и служебный диалог пользователя, который
+// перекрывает активный черновик, пока не завершится.
+type StateRepository struct {
+	s *Store
+}
+
+func NewStateRepository(s *Store) *StateRepository {
+	return &StateRepository{
+		s: s,
+	}
+}
+
+func (r *StateRepository) GetState(_ context.Context, userID int64) (*domain.EventState, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	stateData, updatedAt, ok := r.s.currentState(userID)
+	if !ok {
+		return nil, repository.ErrStateNotFound
+	}
+
+	var state domain.EventState
+	if err := json.Unmarshal(stateData, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal error: %w", err)
+	}
+	state.CreatedAt = updatedAt
+
+	return &state, nil
+}
+
+// SaveState сохраняет служебный диалог или обновляет активный черновик,
+// а если его нет — создаёт новый.
+func (r *StateRepository) SaveState(ctx context.Context, userID int64, state domain.EventState) error {
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	defer r.s.lock(ctx)()
+
+	if !state.IsDraft() {
+		r.s.dialogs[userID] = dialog{stateData: stateData, updatedAt: time.Now().UTC()}
+		return nil
+	}
+
+	d, ok := r.s.drafts[r.s.activeDrafts[userID]]
+	if !ok {
+		r.s.createDraft(userID, state, stateData)
+		return nil
+	}
+
+	d.name = draftName(state)
+	d.stateData = stateData
+	d.updatedAt = time.Now().UTC()
+	r.s.drafts[d.id] = d
+
+	return nil
+}
+
+// CreateDraft сохраняет новый черновик и делает его активным;
+// прежний активный черновик остаётся в списке.
+func (r *StateRepository) CreateDraft(ctx context.Context, userID int64, state domain.EventState) (int64, error) {
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	defer r.s.lock(ctx)()
+
+	return r.s.createDraft(userID, state, stateData), nil
+}
+
+// DeleteState завершает служебный диалог, а если его нет — удаляет активный черновик.
+func (r *StateRepository) DeleteState(ctx context.Context, userID int64) error {
+	defer r.s.lock(ctx)()
+
+	// черновик под завершённым диалогом снова становится текущим
+	if _, ok := r.s.dialogs[userID]; ok {
+		delete(r.s.dialogs, userID)
+		return nil
+	}
+
+	if draftID, ok := r.s.activeDrafts[userID]; ok {
+		delete(r.s.drafts, draftID)
+		delete(r.s.activeDrafts, userID)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет черновики и служебные диалоги, не обновлявшиеся с момента before.
+func (r *StateRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	defer r.s.lock(ctx)()
+
+	var n int64
+	for id, d := range r.s.drafts {
+		if d.updatedAt.Before(before) {
+			delete(r.s.drafts, id)
+			n++
+		}
+	}
+
+	for userID, d := range r.s.dialogs {
+		if d.updatedAt.Before(before) {
+			delete(r.s.dialogs, userID)
+			n++
+		}
+	}
+
+	for userID, draftID := range r.s.activeDrafts {
+		if _, ok := r.s.drafts[draftID]; !ok {
+			delete(r.s.activeDrafts, userID)
+		}
+	}
+
+	return n, nil
+}
+
+func (r *StateRepository) ListDrafts(_ context.Context, userID int64) ([]domain.Draft, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var drafts []domain.Draft
+	for _, d := range r.s.drafts {
+		if d.userID != userID {
+			continue
+		}
+
+		item := domain.Draft{
+			ID:        d.id,
+			UserID:    d.userID,
+			Name:      d.name,
+			UpdatedAt: d.updatedAt,
+			Active:    r.s.activeDrafts[userID] == d.id,
+		}
+
+		if err := json.Unmarshal(d.stateData, &item.State); err != nil {
+			return nil, fmt.Errorf("unmarshal error: %w", err)
+		}
+		item.State.CreatedAt = item.UpdatedAt
+
+		drafts = append(drafts, item)
+	}
+
+	sort.Slice(drafts, func(i, j int) bool {
+		if !drafts[i].UpdatedAt.Equal(drafts[j].UpdatedAt) {
+			return drafts[i].UpdatedAt.After(drafts[j].UpdatedAt)
+		}
+		return drafts[i].ID > drafts[j].ID
+	})
+
+	return drafts, nil
+}
+
+// ActivateDraft делает черновик пользователя активным и прерывает служебный диалог.
+func (r *StateRepository) ActivateDraft(ctx context.Context, userID int64, draftID int64) error {
+	defer r.s.lock(ctx)()
+
+	d, ok := r.s.drafts[draftID]
+	if !ok || d.userID != userID {
+		return repository.ErrStateNotFound
+	}
+
+	r.s.activeDrafts[userID] = draftID
+	delete(r.s.dialogs, userID)
+
+	return nil
+}
+
+// DeleteDraft удаляет черновик пользователя, в том числе активный.
+func (r *StateRepository) DeleteDraft(ctx context.Context, userID int64, draftID int64) error {
+	defer r.s.lock(ctx)()
+
+	d, ok := r.s.drafts[draftID]
+	if !ok || d.userID != userID {
+		return repository.ErrStateNotFound
+	}
+
+	delete(r.s.drafts, draftID)
+	if r.s.activeDrafts[userID] == draftID {
+		delete(r.s.activeDrafts, userID)
+	}
+
+	return nil
+}
+
+// RenameDraft задаёт имя черновика пользователя; имя хранится и в самом
+// состоянии, чтобы SaveState его не перезаписал.
+func (r *StateRepository) RenameDraft(ctx context.Context, userID int64, draftID int64, name string) error {
+	defer r.s.lock(ctx)()
+
+	d, ok := r.s.drafts[draftID]
+	if !ok || d.userID != userID {
+		return repository.ErrStateNotFound
+	}
+
+	var state domain.EventState
+	if err := json.Unmarshal(d.stateData, &state); err != nil {
+		return fmt.Errorf("unmarshal error: %w", err)
+	}
+	state.DraftName = name
+
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	d.name = name
+	d.stateData = stateData
+	d.updatedAt = time.Now().UTC()
+	r.s.drafts[draftID] = d
+
+	return nil
+}
+
+// createDraft вызывается под блокировкой на запись.
+func (s *Store) createDraft(userID int64, state domain.EventState, stateData []byte) int64 {
+	s.draftSeq++
+
+	now := time.Now().UTC()
+	s.drafts[s.draftSeq] = draft{
+		id:        s.draftSeq,
+		userID:    userID,
+		name:      draftName(state),
+		stateData: stateData,
+		createdAt: now,
+		updatedAt: now,
+	}
+	s.activeDrafts[userID] = s.draftSeq
+	delete(s.dialogs, userID)
+
+	return s.draftSeq
+}
+
+// currentState служебный диалог, а если его нет — активный черновик;
+// вызывается под блокировкой.
+func (s *Store) currentState(userID int64) ([]byte, time.Time, bool) {
+	if d, ok := s.dialogs[userID]; ok {
+		return d.stateData, d.updatedAt, true
+	}
+
+	if d, ok := s.drafts[s.activeDrafts[userID]]; ok {
+		return d.stateData, d.updatedAt, true
+	}
+
+	return nil, time.Time{}, false
+}
+
+// draftName ...
+func draftName(state domain.EventState) string {
+	if state.DraftName != "" {
+		return state.DraftName
+	}
+
+	if state.TempEvent.Title != "" {
+		return state.TempEvent.Title
+	}
+
+	return "Без названия"
+}
@@ -0,0 +1,148 @@
+// Package memory хранит данные бота в памяти процесса: для тестов usecase'ов
+// и демонстрационного режима. Репозитории одного Store работают с общими
+// данными, как таблицы одной базы, и безопасны для конкурентного вызова.
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// Store общее хранилище репозиториев.
+type Store struct {
+	mu sync.RWMutex
+	tables
+
+	// txMu выстраивает транзакции в очередь, см. Transactor.
+	txMu sync.Mutex
+}
+
+// tables данные хранилища; Transactor сохраняет их копию и восстанавливает
+// при откате.
+type tables struct {
+	users  map[int64]domain.User
+	events map[int64]domain.Event
+	// registrations регистрации события в порядке поступления.
+	registrations map[int64][]registration
+	questions     map[int64][]domain.Question
+	// answers ответы по событию и пользователю.
+	answers  map[int64]map[int64][]domain.Answer
+	changes  []domain.RegistrationChange
+	surveys  map[int64]time.Time
+	feedback map[int64][]domain.Feedback
+	channels map[int64]int64
+	posts    map[int64][]domain.EventPost
+
+	templates map[int64]domain.Template
+	drafts    map[int64]draft
+	// activeDrafts активный черновик пользователя.
+	activeDrafts map[int64]int64
+	// dialogs служебный диалог пользователя.
+	dialogs map[int64]dialog
+
+	// последние выданные идентификаторы, у каждой сущности свой счётчик
+	eventSeq    int64
+	questionSeq int64
+	changeSeq   int64
+	templateSeq int64
+	draftSeq    int64
+}
+
+type registration struct {
+	userID      int64
+	ticket      string
+	status      domain.RegistrationStatus
+	createdAt   time.Time
+	checkedInAt time.Time
+}
+
+type draft struct {
+	id     int64
+	userID int64
+	name   string
+	// stateData состояние в JSON: как и в базе, каждый читатель получает свою копию.
+	stateData []byte
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type dialog struct {
+	stateData []byte
+	updatedAt time.Time
+}
+
+func NewStore() *Store {
+	return &Store{tables: tables{
+		users:         make(map[int64]domain.User),
+		events:        make(map[int64]domain.Event),
+		registrations: make(map[int64][]registration),
+		questions:     make(map[int64][]domain.Question),
+		answers:       make(map[int64]map[int64][]domain.Answer),
+		surveys:       make(map[int64]time.Time),
+		feedback:      make(map[int64][]domain.Feedback),
+		channels:      make(map[int64]int64),
+		posts:         make(map[int64][]domain.EventPost),
+		templates:     make(map[int64]domain.Template),
+		drafts:        make(map[int64]draft),
+		activeDrafts:  make(map[int64]int64),
+		dialogs:       make(map[int64]dialog),
+	}}
+}
+
+// lock блокирует хранилище на запись и возвращает разблокировку. Запись вне
+// транзакции ждёт завершения открытой транзакции: иначе её откат стёр бы
+// эту запись вместе со своими изменениями.
+func (s *Store) lock(ctx context.Context) (unlock func()) {
+	if ctx.Value(txKey{}) != nil {
+		s.mu.Lock()
+		return s.mu.Unlock
+	}
+
+	s.txMu.Lock()
+	s.mu.Lock()
+
+	return func() {
+		s.mu.Unlock()
+		s.txMu.Unlock()
+	}
+}
+
+// clone копия данных: срезы и вложенные карты копируются, потому что
+// репозитории изменяют их элементы на месте.
+func (t tables) clone() tables {
+	c := t
+	c.users = maps.Clone(t.users)
+	c.events = maps.Clone(t.events)
+	c.registrations = cloneSlices(t.registrations)
+	c.questions = cloneSlices(t.questions)
+	c.answers = make(map[int64]map[int64][]domain.Answer, len(t.answers))
+	for eventID, byUser := range t.answers {
+		c.answers[eventID] = cloneSlices(byUser)
+	}
+	c.changes = slices.Clone(t.changes)
+	c.surveys = maps.Clone(t.surveys)
+	c.feedback = cloneSlices(t.feedback)
+	c.channels = maps.Clone(t.channels)
+	c.posts = cloneSlices(t.posts)
+	c.templates = maps.Clone(t.templates)
+	c.drafts = maps.Clone(t.drafts)
+	c.activeDrafts = maps.Clone(t.activeDrafts)
+	c.dialogs = maps.Clone(t.dialogs)
+
+	return c
+}
+
+// cloneSlices ...
+func cloneSlices[K comparable, V any](m map[K][]V) map[K][]V {
+	c := make(map[K][]V, len(m))
+	for k, v := range m {
+		c[k] = slices.Clone(v)
+	}
+
+	return c
+}
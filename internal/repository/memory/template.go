@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type TemplateRepository struct {
+	s *Store
+}
+
+func NewTemplateRepository(s *Store) *TemplateRepository {
+	return &TemplateRepository{
+		s: s,
+	}
+}
+
+func (r *TemplateRepository) Save(ctx context.Context, t domain.Template) (int64, error) {
+	defer r.s.lock(ctx)()
+
+	r.s.templateSeq++
+	t.ID = r.s.templateSeq
+	t.CreatedAt = t.CreatedAt.UTC()
+
+	r.s.templates[t.ID] = t
+
+	return t.ID, nil
+}
+
+func (r *TemplateRepository) GetByID(_ context.Context, templateID int64) (*domain.Template, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	t, ok := r.s.templates[templateID]
+	if !ok {
+		return nil, domain.ErrTemplateNotFound
+	}
+
+	return &t, nil
+}
+
+func (r *TemplateRepository) GetByUserID(_ context.Context, userID int64) ([]domain.Template, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var templates []domain.Template
+	for _, t := range r.s.templates {
+		if t.UserID == userID {
+			templates = append(templates, t)
+		}
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		if !templates[i].CreatedAt.Equal(templates[j].CreatedAt) {
+			return templates[i].CreatedAt.After(templates[j].CreatedAt)
+		}
+		return templates[i].ID > templates[j].ID
+	})
+
+	return templates, nil
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, templateID int64) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.templates[templateID]; !ok {
+		return domain.ErrTemplateNotFound
+	}
+
+	delete(r.s.templates, templateID)
+
+	return nil
+}
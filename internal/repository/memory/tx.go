@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+)
+
+// txKey отметка в контексте, что транзакция уже открыта.
+type txKey struct{}
+
+// Transactor выполняет транзакции по одной. Перед транзакцией сохраняется
+// копия данных хранилища, при ошибке она восстанавливается: изменения,
+// сделанные до ошибки, откатываются, как в базе.
+type Transactor struct {
+	s *Store
+}
+
+func NewTransactor(s *Store) *Transactor {
+	return &Transactor{
+		s: s,
+	}
+}
+
+// WithinTx выполняет fn в транзакции и откатывает её, если fn вернула ошибку.
+// Вложенный вызов присоединяется к внешней транзакции.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	t.s.txMu.Lock()
+	defer t.s.txMu.Unlock()
+
+	t.s.mu.RLock()
+	saved := t.s.tables.clone()
+	t.s.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		t.s.mu.Lock()
+		t.s.tables = saved
+		t.s.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
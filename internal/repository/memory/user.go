@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type UserRepository struct {
+	s *Store
+}
+
+func NewUserRepository(s *Store) *UserRepository {
+	return &UserRepository{
+		s: s,
+	}
+}
+
+func (r *UserRepository) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	defer r.s.lock(ctx)()
+
+	r.s.users[user.ID] = *user
+
+	return nil
+}
+
+func (r *UserRepository) GetByID(_ context.Context, userID int64) (*domain.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	user, ok := r.s.users[userID]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// backupTables таблицы копии в порядке восстановления: сначала те,
+// на которые ссылаются остальные. Набор и порядок столбцов совпадают
+// со схемой SQLite, поэтому копию можно перенести из одной базы в другую.
+var backupTables = []string{
+	"users",
+	"events",
+	"registrations",
+	"questions",
+	"answers",
+	"channels",
+	"event_posts",
+	"templates",
+	"surveys",
+	"feedback",
+}
+
+type BackupRepository struct {
+	db *sql.DB
+}
+
+func NewBackupRepository(db *sql.DB) *BackupRepository {
+	return &BackupRepository{
+		db: db,
+	}
+}
+
+// Dump читает все таблицы из одного снимка базы: бот может продолжать работу.
+func (r *BackupRepository) Dump(ctx context.Context) (*domain.Snapshot, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	snapshot := &domain.Snapshot{CreatedAt: time.Now().UTC()}
+
+	for _, table := range backupTables {
+		dump, err := dumpTable(ctx, tx, table)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Tables = append(snapshot.Tables, dump)
+	}
+
+	return snapshot, nil
+}
+
+func dumpTable(ctx context.Context, tx *sql.Tx, table string) (domain.TableDump, error) {
+	dump := domain.TableDump{Name: table}
+
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+table)
+	if err != nil {
+		return dump, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	if dump.Columns, err = rows.Columns(); err != nil {
+		return dump, fmt.Errorf("failed to get columns of %s: %w", table, err)
+	}
+
+	for rows.Next() {
+		values := make([]interface{}, len(dump.Columns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return dump, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+
+		for i, v := range values {
+			// драйвер отдаёт время в локальном поясе, в копии оно в UTC
+			if t, ok := v.(time.Time); ok {
+				values[i] = t.UTC()
+			}
+		}
+
+		dump.Rows = append(dump.Rows, values)
+	}
+
+	return dump, rows.Err()
+}
+
+// Restore восстанавливает таблицы в одной транзакции. Имена таблиц и столбцов
+// из копии сверяются со схемой, прежде чем попасть в запрос.
+func (r *BackupRepository) Restore(ctx context.Context, snapshot *domain.Snapshot) error {
+	tables := make(map[string]domain.TableDump, len(snapshot.Tables))
+	for _, t := range snapshot.Tables {
+		tables[t.Name] = t
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range backupTables {
+		dump, ok := tables[table]
+		if !ok {
+			continue
+		}
+		delete(tables, table)
+
+		if err := restoreTable(ctx, tx, dump); err != nil {
+			return err
+		}
+	}
+
+	for name := range tables {
+		return fmt.Errorf("%w: unknown table %q", domain.ErrUnsupportedBackup, name)
+	}
+
+	return tx.Commit()
+}
+
+func restoreTable(ctx context.Context, tx *sql.Tx, dump domain.TableDump) error {
+	types, err := columnTypes(ctx, tx, dump.Name)
+	if err != nil {
+		return err
+	}
+
+	key, err := primaryKey(ctx, tx, dump.Name)
+	if err != nil {
+		return err
+	}
+
+	set := make([]string, 0, len(dump.Columns))
+	placeholders := make([]string, 0, len(dump.Columns))
+	for i, c := range dump.Columns {
+		if _, ok := types[c]; !ok {
+			return fmt.Errorf("%w: unknown column %s.%s", domain.ErrUnsupportedBackup, dump.Name, c)
+		}
+		set = append(set, fmt.Sprintf("%s = excluded.%s", c, c))
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+
+	// в отличие от SQLite, Postgres требует явно указать ключ конфликта
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		dump.Name,
+		strings.Join(dump.Columns, ", "),
+		strings.Join(placeholders, ", "),
+		strings.Join(key, ", "),
+		strings.Join(set, ", "),
+	)
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare restore of %s: %w", dump.Name, err)
+	}
+	defer stmt.Close()
+
+	for _, row := range dump.Rows {
+		if len(row) != len(dump.Columns) {
+			return fmt.Errorf("%w: malformed row in %s", domain.ErrUnsupportedBackup, dump.Name)
+		}
+
+		args := make([]interface{}, len(row))
+		for i, v := range row {
+			if args[i], err = restoreValue(types[dump.Columns[i]], v); err != nil {
+				return fmt.Errorf("failed to restore %s.%s: %w", dump.Name, dump.Columns[i], err)
+			}
+		}
+
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", dump.Name, err)
+		}
+	}
+
+	// строки вставлены с явными id: счётчик должен продолжиться после них
+	if _, ok := types["id"]; ok {
+		query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), MAX(id)) FROM %s", dump.Name, dump.Name)
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to reset sequence of %s: %w", dump.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// columnTypes типы столбцов таблицы в терминах information_schema.
+func columnTypes(ctx context.Context, tx *sql.Tx, table string) (map[string]string, error) {
+	const query = `
+		SELECT column_name, data_type
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1`
+
+	rows, err := tx.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	types := make(map[string]string)
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, fmt.Errorf("failed to scan columns of %s: %w", table, err)
+		}
+
+		types[name] = typ
+	}
+
+	return types, rows.Err()
+}
+
+// primaryKey столбцы первичного ключа таблицы.
+func primaryKey(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	const query = `
+		SELECT a.attname
+		FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = to_regclass($1) AND i.indisprimary
+		ORDER BY a.attnum`
+
+	rows, err := tx.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary key of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var key []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan primary key of %s: %w", table, err)
+		}
+
+		key = append(key, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("table %s has no primary key", table)
+	}
+
+	return key, nil
+}
+
+// restoreValue даты в копии хранятся строками, а флаги в старых копиях
+// могли сохраниться числами: приводим их к типам столбцов.
+func restoreValue(typ string, v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case string:
+		if strings.HasPrefix(typ, "timestamp") {
+			return time.Parse(time.RFC3339Nano, value)
+		}
+	case int64:
+		if typ == "boolean" {
+			return value != 0, nil
+		}
+	case float64:
+		if typ == "boolean" {
+			return value != 0, nil
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type EventRepository struct {
+	db *sql.DB
+}
+
+func NewEventRepository(db *sql.DB) *EventRepository {
+	return &EventRepository{
+		db: db,
+	}
+}
+
+func (r *EventRepository) Save(ctx context.Context, e domain.Event) (int64, error) {
+	const query = `
+		INSERT INTO events
+			(user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	if e.NotifyMode == "" {
+		e.NotifyMode = domain.NotifyOff
+	}
+
+	var id int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		e.UserID,
+		e.ChatID,
+		e.Title,
+		e.Description,
+		e.PhotoFileID,
+		e.DocumentFileID,
+		e.Date.UTC(),
+		e.NotifyMode,
+		e.RequiresApproval,
+		e.CreatedAt.UTC(),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save event: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *EventRepository) GetByID(ctx context.Context, eventID int64) (*domain.Event, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events
+		WHERE id = $1`
+
+	event, err := scanEvent(conn(ctx, r.db).QueryRowContext(ctx, query, eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrEventNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return &event, nil
+}
+
+func (r *EventRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Event, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events
+		WHERE user_id = $1
+		ORDER BY date DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func (r *EventRepository) GetByChatID(ctx context.Context, chatID int64) ([]domain.Event, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events
+		WHERE chat_id = $1
+		ORDER BY date DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func (r *EventRepository) GetAll(ctx context.Context) ([]domain.Event, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events
+		ORDER BY date DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func (r *EventRepository) Delete(ctx context.Context, eventID int64) error {
+	const query = `
+		DELETE FROM events
+		WHERE id = $1`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// SetApproval ...
+func (r *EventRepository) SetApproval(ctx context.Context, eventID int64, required bool) error {
+	const query = `
+		UPDATE events
+		SET requires_approval = $1
+		WHERE id = $2`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, required, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update approval mode: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// SetNotifyMode ...
+func (r *EventRepository) SetNotifyMode(ctx context.Context, eventID int64, mode domain.NotifyMode) error {
+	const query = `
+		UPDATE events
+		SET notify_mode = $1
+		WHERE id = $2`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, mode, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update notify mode: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEvent даты событий хранятся как UTC: возвращаем их в UTC, как и SQLite,
+// а не в локальном поясе, в котором их отдаёт драйвер.
+func scanEvent(row rowScanner) (domain.Event, error) {
+	var event domain.Event
+	var date, createdAt time.Time
+
+	err := row.Scan(
+		&event.ID,
+		&event.UserID,
+		&event.ChatID,
+		&event.Title,
+		&event.Description,
+		&event.PhotoFileID,
+		&event.DocumentFileID,
+		&date,
+		&event.NotifyMode,
+		&event.RequiresApproval,
+		&createdAt,
+	)
+	if err != nil {
+		return event, err
+	}
+
+	event.Date = date.UTC()
+	event.CreatedAt = createdAt.UTC()
+
+	return event, nil
+}
+
+// scanEvents ...
+func scanEvents(rows *sql.Rows) ([]domain.Event, error) {
+	var events []domain.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/binaryty/evbot/migrations"
+)
+
+// migrateLock ключ advisory-блокировки: экземпляры бота, запущенные
+// одновременно, применяют миграции по очереди.
+const migrateLock = 7_401_270
+
+// migration файл схемы, переводящий базу в версию version.
+type migration struct {
+	version int
+	name    string
+}
+
+// Migrate применяет к базе миграции, которых нет в schema_migrations,
+// каждую в своей транзакции. Первая миграция создаёт таблицы только при их
+// отсутствии, поэтому база, созданная init_postgres.sql до появления
+// миграций, доводится до версии 1 без потери данных.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	list, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		if m.version <= version {
+			continue
+		}
+
+		if err := applyMigration(ctx, db, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadMigrations миграции по возрастанию версии.
+func loadMigrations() ([]migration, error) {
+	names, err := fs.Glob(migrations.Postgres, "postgres/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	list := make([]migration, 0, len(names))
+	for _, name := range names {
+		prefix := strings.SplitN(strings.TrimPrefix(name, "postgres/"), "_", 2)[0]
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("bad migration name %s: %w", name, err)
+		}
+
+		list = append(list, migration{version: version, name: name})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].version < list[j].version
+	})
+
+	return list, nil
+}
+
+// schemaVersion ...
+func schemaVersion(ctx context.Context, q executor) (int, error) {
+	var version int
+	if err := q.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	script, err := fs.ReadFile(migrations.Postgres, m.name)
+	if err != nil {
+		return fmt.Errorf("failed to read migration %s: %w", m.name, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrateLock); err != nil {
+		return fmt.Errorf("failed to lock schema: %w", err)
+	}
+
+	// пока ждали блокировку, миграцию мог применить другой экземпляр
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if version >= m.version {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", m.version); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	list, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+
+	if len(list) == 0 || list[0].version != 1 {
+		t.Fatalf("migrations = %+v, want to start with version 1", list)
+	}
+
+	for i := 1; i < len(list); i++ {
+		if list[i].version != list[i-1].version+1 {
+			t.Errorf("migration %s follows version %d, want consecutive versions", list[i].name, list[i-1].version)
+		}
+	}
+}
+
+func TestMigrateTwice(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	// openTestDB уже применил миграции; повторный запуск ничего не меняет
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	list, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		t.Fatalf("schemaVersion: %v", err)
+	}
+	if want := list[len(list)-1].version; version != want {
+		t.Errorf("schemaVersion = %d, want %d", version, want)
+	}
+
+	var applied int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&applied); err != nil {
+		t.Fatalf("count migrations: %v", err)
+	}
+	if applied != len(list) {
+		t.Errorf("schema_migrations has %d rows, want %d", applied, len(list))
+	}
+}
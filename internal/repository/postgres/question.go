@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type QuestionRepository struct {
+	db *sql.DB
+}
+
+func NewQuestionRepository(db *sql.DB) *QuestionRepository {
+	return &QuestionRepository{
+		db: db,
+	}
+}
+
+func (r *QuestionRepository) SaveQuestions(ctx context.Context, eventID int64, questions []domain.Question) error {
+	const query = `
+		INSERT INTO questions (event_id, position, kind, text, options)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	return inTx(ctx, r.db, func(q executor) error {
+		for i, question := range questions {
+			options, err := json.Marshal(question.Options)
+			if err != nil {
+				return fmt.Errorf("failed to marshal options: %w", err)
+			}
+
+			if _, err := q.ExecContext(ctx, query, eventID, i, question.Kind, question.Text, string(options)); err != nil {
+				return fmt.Errorf("failed to save question: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *QuestionRepository) GetQuestions(ctx context.Context, eventID int64) ([]domain.Question, error) {
+	const query = `
+		SELECT id, event_id, position, kind, text, options
+		FROM questions
+		WHERE event_id = $1
+		ORDER BY position`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query questions: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []domain.Question
+	for rows.Next() {
+		var q domain.Question
+		var options string
+
+		if err := rows.Scan(&q.ID, &q.EventID, &q.Position, &q.Kind, &q.Text, &options); err != nil {
+			return nil, fmt.Errorf("failed to scan question: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(options), &q.Options); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal options: %w", err)
+		}
+
+		questions = append(questions, q)
+	}
+
+	return questions, rows.Err()
+}
+
+// SaveAnswers заменяет ответы участника.
+func (r *QuestionRepository) SaveAnswers(ctx context.Context, eventID int64, userID int64, answers []domain.Answer) error {
+	const query = `
+		INSERT INTO answers (event_id, user_id, question_id, value)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id, question_id) DO UPDATE SET
+			value = excluded.value`
+
+	return inTx(ctx, r.db, func(q executor) error {
+		for _, a := range answers {
+			if _, err := q.ExecContext(ctx, query, eventID, userID, a.QuestionID, a.Value); err != nil {
+				return fmt.Errorf("failed to save answer: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *QuestionRepository) GetAnswers(ctx context.Context, eventID int64) (map[int64][]domain.Answer, error) {
+	const query = `
+		SELECT a.user_id, a.question_id, a.value
+		FROM answers a
+		JOIN questions q ON q.id = a.question_id
+		WHERE a.event_id = $1
+		ORDER BY a.user_id, q.position`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query answers: %w", err)
+	}
+	defer rows.Close()
+
+	answers := make(map[int64][]domain.Answer)
+	for rows.Next() {
+		var userID int64
+		var a domain.Answer
+
+		if err := rows.Scan(&userID, &a.QuestionID, &a.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan answer: %w", err)
+		}
+
+		answers[userID] = append(answers[userID], a)
+	}
+
+	return answers, rows.Err()
+}
+
+func (r *QuestionRepository) DeleteAnswers(ctx context.Context, eventID int64, userID int64) error {
+	const query = `
+		DELETE FROM answers
+		WHERE event_id = $1 AND user_id = $2`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, userID); err != nil {
+		return fmt.Errorf("failed to delete answers: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type RegistrationRepository struct {
+	db *sql.DB
+}
+
+func NewRegistrationRepository(db *sql.DB) *RegistrationRepository {
+	return &RegistrationRepository{
+		db: db,
+	}
+}
+
+func (r *RegistrationRepository) Register(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	ticket string,
+	status domain.RegistrationStatus,
+) error {
+	const query = `
+		INSERT INTO registrations(event_id, user_id, ticket, status, created_at)
+		VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
+		eventID,
+		userID,
+		ticket,
+		status,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("failed to register: %w", domain.ErrAlreadyRegistered)
+	}
+
+	return nil
+}
+
+func (r *RegistrationRepository) Unregister(ctx context.Context, eventID int64, userID int64) error {
+	const query = `
+		DELETE FROM registrations
+		WHERE event_id = $1 AND user_id = $2`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unregister: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrRegistrationNotFound
+	}
+
+	return nil
+}
+
+func (r *RegistrationRepository) Status(ctx context.Context, eventID int64, userID int64) (domain.RegistrationStatus, error) {
+	const query = `
+		SELECT status
+		FROM registrations
+		WHERE event_id = $1 AND user_id = $2`
+
+	var status domain.RegistrationStatus
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, eventID, userID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.RegistrationNone, nil
+		}
+
+		return domain.RegistrationNone, fmt.Errorf("failed to get registration status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (r *RegistrationRepository) Approve(ctx context.Context, eventID int64, userID int64) error {
+	const query = `
+		UPDATE registrations
+		SET status = $1
+		WHERE event_id = $2 AND user_id = $3 AND status = $4`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, domain.RegistrationApproved, eventID, userID, domain.RegistrationPending)
+	if err != nil {
+		return fmt.Errorf("failed to approve registration: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrRegistrationNotFound
+	}
+
+	return nil
+}
+
+func (r *RegistrationRepository) GetParticipants(ctx context.Context, eventID int64) ([]domain.Participant, error) {
+	const query = `
+		SELECT u.user_id, u.first_name, u.username, r.status, r.created_at, r.checked_in_at
+		FROM registrations r
+		JOIN users u ON r.user_id = u.user_id
+		WHERE r.event_id = $1
+		ORDER BY r.created_at`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	defer rows.Close()
+
+	var participants []domain.Participant
+	for rows.Next() {
+		var p domain.Participant
+		var createdAt time.Time
+		var checkedInAt sql.NullTime
+
+		err := rows.Scan(
+			&p.ID,
+			&p.FirstName,
+			&p.UserName,
+			&p.Status,
+			&createdAt,
+			&checkedInAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan participant: %w", err)
+		}
+
+		p.RegisteredAt = createdAt.UTC()
+		if checkedInAt.Valid {
+			p.CheckedInAt = checkedInAt.Time.UTC()
+		}
+		participants = append(participants, p)
+	}
+
+	return participants, rows.Err()
+}
+
+func (r *RegistrationRepository) GetParticipantsPaginated(
+	ctx context.Context,
+	eventID int64,
+	offset int,
+	limit int) ([]domain.Participant, int, error) {
+	const query = `
+		SELECT u.user_id, u.first_name, u.username, r.status, r.created_at, r.checked_in_at
+		FROM registrations r
+		JOIN users u ON r.user_id = u.user_id
+		WHERE r.event_id = $1
+		ORDER BY r.created_at
+		LIMIT $2
+		OFFSET $3`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get participants: %w", err)
+	}
+	defer rows.Close()
+
+	var participants []domain.Participant
+	for rows.Next() {
+		var p domain.Participant
+		var createdAt time.Time
+		var checkedInAt sql.NullTime
+
+		err := rows.Scan(
+			&p.ID,
+			&p.FirstName,
+			&p.UserName,
+			&p.Status,
+			&createdAt,
+			&checkedInAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan participant: %w", err)
+		}
+
+		p.RegisteredAt = createdAt.UTC()
+		if checkedInAt.Valid {
+			p.CheckedInAt = checkedInAt.Time.UTC()
+		}
+		participants = append(participants, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	var total int
+	err = conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM registrations WHERE event_id = $1`,
+		eventID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count participants: %w", err)
+	}
+
+	return participants, total, nil
+}
+
+func (r *RegistrationRepository) IsRegistered(ctx context.Context, eventID int64, userID int64) (bool, error) {
+	const query = `
+		SELECT EXISTS(
+			SELECT 1
+			FROM registrations
+			WHERE event_id = $1 AND user_id = $2
+	)`
+
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, eventID, userID).Scan(&exists)
+
+	return exists, err
+}
+
+func (r *RegistrationRepository) GetTicket(ctx context.Context, eventID int64, userID int64) (*domain.Ticket, error) {
+	const query = `
+		SELECT event_id, user_id, ticket, status, checked_in_at
+		FROM registrations
+		WHERE event_id = $1 AND user_id = $2 AND ticket IS NOT NULL`
+
+	return scanTicket(conn(ctx, r.db).QueryRowContext(ctx, query, eventID, userID))
+}
+
+func (r *RegistrationRepository) GetTicketByToken(ctx context.Context, token string) (*domain.Ticket, error) {
+	const query = `
+		SELECT event_id, user_id, ticket, status, checked_in_at
+		FROM registrations
+		WHERE ticket = $1`
+
+	return scanTicket(conn(ctx, r.db).QueryRowContext(ctx, query, token))
+}
+
+// CheckIn отмечает приход по билету; повторная отметка не меняет время первой.
+func (r *RegistrationRepository) CheckIn(ctx context.Context, token string, at time.Time) error {
+	const query = `
+		UPDATE registrations
+		SET checked_in_at = $1
+		WHERE ticket = $2 AND checked_in_at IS NULL`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, at.UTC(), token)
+	if err != nil {
+		return fmt.Errorf("failed to check in: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		if _, err := r.GetTicketByToken(ctx, token); err != nil {
+			return err
+		}
+
+		return domain.ErrAlreadyCheckedIn
+	}
+
+	return nil
+}
+
+// scanTicket ...
+func scanTicket(row rowScanner) (*domain.Ticket, error) {
+	var t domain.Ticket
+	var checkedInAt sql.NullTime
+
+	err := row.Scan(&t.EventID, &t.UserID, &t.Token, &t.Status, &checkedInAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTicketNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if checkedInAt.Valid {
+		t.CheckedInAt = checkedInAt.Time.UTC()
+	}
+
+	return &t, nil
+}
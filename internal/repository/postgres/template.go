@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type TemplateRepository struct {
+	db *sql.DB
+}
+
+func NewTemplateRepository(db *sql.DB) *TemplateRepository {
+	return &TemplateRepository{
+		db: db,
+	}
+}
+
+func (r *TemplateRepository) Save(ctx context.Context, t domain.Template) (int64, error) {
+	const query = `
+		INSERT INTO templates
+			(user_id, chat_id, title, description, photo_file_id, document_file_id, hour, minute, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	var id int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		t.UserID,
+		t.ChatID,
+		t.Title,
+		t.Description,
+		t.PhotoFileID,
+		t.DocumentFileID,
+		t.Hour,
+		t.Minute,
+		t.CreatedAt.UTC(),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *TemplateRepository) GetByID(ctx context.Context, templateID int64) (*domain.Template, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, hour, minute, created_at
+		FROM templates
+		WHERE id = $1`
+
+	t, err := scanTemplate(conn(ctx, r.db).QueryRowContext(ctx, query, templateID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTemplateNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *TemplateRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Template, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, hour, minute, created_at
+		FROM templates
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []domain.Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+
+		templates = append(templates, t)
+	}
+
+	return templates, rows.Err()
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, templateID int64) error {
+	const query = `
+		DELETE FROM templates
+		WHERE id = $1`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// scanTemplate ...
+func scanTemplate(row rowScanner) (domain.Template, error) {
+	var t domain.Template
+
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.ChatID,
+		&t.Title,
+		&t.Description,
+		&t.PhotoFileID,
+		&t.DocumentFileID,
+		&t.Hour,
+		&t.Minute,
+		&t.CreatedAt,
+	)
+	t.CreatedAt = t.CreatedAt.UTC()
+
+	return t, err
+}
@@ -0,0 +1,78 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testChannels(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	const (
+		chatID  int64 = -42
+		channel int64 = -1001
+		other   int64 = -1002
+	)
+
+	if _, err := s.Channels.GetChannel(ctx, chatID); !errors.Is(err, domain.ErrChannelNotFound) {
+		t.Errorf("Channels.GetChannel of unbound chat error = %v, want ErrChannelNotFound", err)
+	}
+
+	for _, id := range []int64{channel, other} {
+		if err := s.Channels.SetChannel(ctx, chatID, id); err != nil {
+			t.Fatalf("Channels.SetChannel: %v", err)
+		}
+	}
+	if got, err := s.Channels.GetChannel(ctx, chatID); err != nil || got != other {
+		t.Errorf("Channels.GetChannel = %d, %v; want %d", got, err, other)
+	}
+
+	// отвязка идемпотентна
+	for i := 0; i < 2; i++ {
+		if err := s.Channels.DeleteChannel(ctx, chatID); err != nil {
+			t.Fatalf("Channels.DeleteChannel: %v", err)
+		}
+	}
+	if _, err := s.Channels.GetChannel(ctx, chatID); !errors.Is(err, domain.ErrChannelNotFound) {
+		t.Errorf("Channels.GetChannel after DeleteChannel error = %v, want ErrChannelNotFound", err)
+	}
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+	posts := []domain.EventPost{
+		{EventID: eventID, ChannelID: channel, MessageID: 7},
+		{EventID: eventID, ChannelID: other, MessageID: 8},
+		// пост, отправленный в тот же канал заново, заменяет прежний
+		{EventID: eventID, ChannelID: channel, MessageID: 9},
+	}
+	for _, p := range posts {
+		if err := s.Channels.SavePost(ctx, p); err != nil {
+			t.Fatalf("Channels.SavePost: %v", err)
+		}
+	}
+
+	got, err := s.Channels.GetPosts(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Channels.GetPosts: %v", err)
+	}
+	messages := make(map[int64]int)
+	for _, p := range got {
+		if p.EventID != eventID {
+			t.Errorf("post = %+v, want event %d", p, eventID)
+		}
+		messages[p.ChannelID] = p.MessageID
+	}
+	if want := map[int64]int{channel: 9, other: 8}; len(got) != 2 || !reflect.DeepEqual(messages, want) {
+		t.Errorf("Channels.GetPosts = %+v, want messages %v", got, want)
+	}
+
+	if err := s.Channels.DeletePosts(ctx, eventID); err != nil {
+		t.Fatalf("Channels.DeletePosts: %v", err)
+	}
+	if got, err := s.Channels.GetPosts(ctx, eventID); err != nil || len(got) != 0 {
+		t.Errorf("Channels.GetPosts after DeletePosts = %+v, %v; want none", got, err)
+	}
+}
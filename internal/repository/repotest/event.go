@@ -0,0 +1,183 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testEvents(t *testing.T, s Storage) {
+	ctx := context.Background()
+	msk := time.FixedZone("MSK", 3*60*60)
+
+	meetup := domain.Event{
+		UserID:           1,
+		ChatID:           -1,
+		Title:            "Митап",
+		Description:      "Про Go",
+		PhotoFileID:      "photo",
+		DocumentFileID:   "program",
+		Date:             day.In(msk),
+		RequiresApproval: true,
+		CreatedAt:        day.Add(-24 * time.Hour),
+	}
+	meetup.ID = saveEvent(t, s, meetup)
+	// без режима уведомлений событие сохраняется с выключенными
+	meetup.NotifyMode = domain.NotifyOff
+
+	lecture := domain.Event{UserID: 1, ChatID: -2, Title: "Лекция", Date: day.Add(48 * time.Hour), NotifyMode: domain.NotifyDigest}
+	lecture.ID = saveEvent(t, s, lecture)
+	party := domain.Event{UserID: 2, ChatID: -1, Title: "Вечеринка", Date: day.Add(24 * time.Hour)}
+	party.ID = saveEvent(t, s, party)
+
+	if meetup.ID == lecture.ID || lecture.ID == party.ID {
+		t.Fatalf("Events.Save ids = %d, %d, %d, want distinct", meetup.ID, lecture.ID, party.ID)
+	}
+
+	got, err := s.Events.GetByID(ctx, meetup.ID)
+	if err != nil {
+		t.Fatalf("Events.GetByID: %v", err)
+	}
+	checkEvent(t, *got, meetup)
+
+	lists := []struct {
+		name string
+		get  func() ([]domain.Event, error)
+		want []int64
+	}{
+		{"GetByUserID", func() ([]domain.Event, error) { return s.Events.GetByUserID(ctx, 1) }, []int64{lecture.ID, meetup.ID}},
+		{"GetByChatID", func() ([]domain.Event, error) { return s.Events.GetByChatID(ctx, -1) }, []int64{party.ID, meetup.ID}},
+		{"GetAll", func() ([]domain.Event, error) { return s.Events.GetAll(ctx) }, []int64{lecture.ID, party.ID, meetup.ID}},
+		{"GetByChatID of empty chat", func() ([]domain.Event, error) { return s.Events.GetByChatID(ctx, -3) }, []int64{}},
+	}
+	for _, l := range lists {
+		events, err := l.get()
+		if err != nil {
+			t.Fatalf("Events.%s: %v", l.name, err)
+		}
+		// новые события первыми
+		if ids := eventIDs(events); !reflect.DeepEqual(ids, l.want) {
+			t.Errorf("Events.%s = %v, want %v", l.name, ids, l.want)
+		}
+	}
+
+	if err := s.Events.SetNotifyMode(ctx, meetup.ID, domain.NotifyInstant); err != nil {
+		t.Fatalf("Events.SetNotifyMode: %v", err)
+	}
+	if err := s.Events.SetApproval(ctx, meetup.ID, false); err != nil {
+		t.Fatalf("Events.SetApproval: %v", err)
+	}
+	meetup.NotifyMode, meetup.RequiresApproval = domain.NotifyInstant, false
+
+	got, err = s.Events.GetByID(ctx, meetup.ID)
+	if err != nil {
+		t.Fatalf("Events.GetByID: %v", err)
+	}
+	checkEvent(t, *got, meetup)
+
+	if err := s.Events.Delete(ctx, meetup.ID); err != nil {
+		t.Fatalf("Events.Delete: %v", err)
+	}
+
+	const missing = 1000
+	notFound := map[string]error{
+		"GetByID deleted": func() error { _, err := s.Events.GetByID(ctx, meetup.ID); return err }(),
+		"Delete deleted":  s.Events.Delete(ctx, meetup.ID),
+		"SetNotifyMode":   s.Events.SetNotifyMode(ctx, missing, domain.NotifyOff),
+		"SetApproval":     s.Events.SetApproval(ctx, missing, true),
+	}
+	for name, err := range notFound {
+		if !errors.Is(err, domain.ErrEventNotFound) {
+			t.Errorf("Events.%s error = %v, want ErrEventNotFound", name, err)
+		}
+	}
+}
+
+// testEventCascade удаление события удаляет всё, что к нему относится.
+func testEventCascade(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+	otherID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Лекция", Date: day})
+
+	saveUser(t, s, 10, "Анна")
+	for _, id := range []int64{eventID, otherID} {
+		register(t, s, id, 10, fmt.Sprintf("ticket-%d", id), domain.RegistrationApproved)
+
+		if err := s.Questions.SaveQuestions(ctx, id, []domain.Question{{Kind: domain.QuestionText, Text: "Размер футболки"}}); err != nil {
+			t.Fatalf("Questions.SaveQuestions: %v", err)
+		}
+		if err := s.Channels.SavePost(ctx, domain.EventPost{EventID: id, ChannelID: -100, MessageID: 7}); err != nil {
+			t.Fatalf("Channels.SavePost: %v", err)
+		}
+		if err := s.Feedback.SaveRating(ctx, domain.Feedback{EventID: id, User: domain.User{ID: 10}, Rating: 5, CreatedAt: day}); err != nil {
+			t.Fatalf("Feedback.SaveRating: %v", err)
+		}
+		if err := s.Notifications.Enqueue(ctx, domain.RegistrationChange{EventID: id, User: domain.User{ID: 10, FirstName: "Анна"}, Registered: true, CreatedAt: day}); err != nil {
+			t.Fatalf("Notifications.Enqueue: %v", err)
+		}
+	}
+
+	if err := s.Events.Delete(ctx, eventID); err != nil {
+		t.Fatalf("Events.Delete: %v", err)
+	}
+
+	for _, tt := range []struct {
+		id   int64
+		want int
+	}{{eventID, 0}, {otherID, 1}} {
+		participants, err := s.Registrations.GetParticipants(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("Registrations.GetParticipants: %v", err)
+		}
+		questions, err := s.Questions.GetQuestions(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("Questions.GetQuestions: %v", err)
+		}
+		posts, err := s.Channels.GetPosts(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("Channels.GetPosts: %v", err)
+		}
+		feedback, err := s.Feedback.GetByEventID(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("Feedback.GetByEventID: %v", err)
+		}
+
+		counts := []int{len(participants), len(questions), len(posts), len(feedback)}
+		if want := []int{tt.want, tt.want, tt.want, tt.want}; !reflect.DeepEqual(counts, want) {
+			t.Errorf("event %d: participants, questions, posts, feedback = %v, want %v", tt.id, counts, want)
+		}
+	}
+
+	changes, err := s.Notifications.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Notifications.Pending: %v", err)
+	}
+	if len(changes) != 1 || changes[0].EventID != otherID {
+		t.Errorf("Notifications.Pending = %+v, want one change of event %d", changes, otherID)
+	}
+}
+
+func checkEvent(t *testing.T, got, want domain.Event) {
+	t.Helper()
+
+	same := got.ID == want.ID &&
+		got.UserID == want.UserID &&
+		got.ChatID == want.ChatID &&
+		got.Title == want.Title &&
+		got.Description == want.Description &&
+		got.PhotoFileID == want.PhotoFileID &&
+		got.DocumentFileID == want.DocumentFileID &&
+		got.Date.Equal(want.Date) &&
+		got.NotifyMode == want.NotifyMode &&
+		got.RequiresApproval == want.RequiresApproval &&
+		got.CreatedAt.Equal(want.CreatedAt)
+	if !same {
+		t.Errorf("event = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testFeedback(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+	saveUser(t, s, 10, "Анна")
+
+	rate := func(userID int64, rating int, at time.Time) {
+		t.Helper()
+
+		f := domain.Feedback{EventID: eventID, User: domain.User{ID: userID}, Rating: rating, CreatedAt: at}
+		if err := s.Feedback.SaveRating(ctx, f); err != nil {
+			t.Fatalf("Feedback.SaveRating(%d): %v", userID, err)
+		}
+	}
+
+	rate(10, 3, day)
+	if err := s.Feedback.SaveComment(ctx, eventID, 10, "Было интересно"); err != nil {
+		t.Fatalf("Feedback.SaveComment: %v", err)
+	}
+	// повторная оценка заменяет прежнюю, комментарий остаётся
+	rate(10, 5, day.Add(time.Hour))
+	// автор оценки, которого нет среди пользователей, остаётся без имени
+	rate(11, 4, day.Add(time.Minute))
+
+	err := s.Feedback.SaveComment(ctx, eventID, 12, "Без оценки")
+	if !errors.Is(err, domain.ErrFeedbackNotFound) {
+		t.Errorf("Feedback.SaveComment without rating error = %v, want ErrFeedbackNotFound", err)
+	}
+
+	feedback, err := s.Feedback.GetByEventID(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Feedback.GetByEventID: %v", err)
+	}
+
+	// новые отзывы первыми; время отзыва — время первой оценки
+	want := []domain.Feedback{
+		{EventID: eventID, User: domain.User{ID: 11}, Rating: 4, CreatedAt: day.Add(time.Minute)},
+		{EventID: eventID, User: domain.User{ID: 10, FirstName: "Анна", UserName: "userАнна"}, Rating: 5, Comment: "Было интересно", CreatedAt: day},
+	}
+	if len(feedback) != len(want) {
+		t.Fatalf("Feedback.GetByEventID = %+v, want %+v", feedback, want)
+	}
+	for i := range want {
+		got := feedback[i]
+		if got.EventID != want[i].EventID || got.User != want[i].User || got.Rating != want[i].Rating ||
+			got.Comment != want[i].Comment || !got.CreatedAt.Equal(want[i].CreatedAt) {
+			t.Errorf("feedback %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func testSurveys(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	after, before := day.Add(-72*time.Hour), day
+
+	stale := saveEvent(t, s, domain.Event{UserID: 1, Title: "Давнее", Date: after.Add(-time.Minute)})
+	edge := saveEvent(t, s, domain.Event{UserID: 1, Title: "На границе окна", Date: after})
+	older := saveEvent(t, s, domain.Event{UserID: 1, Title: "Вчера", Date: day.Add(-24 * time.Hour)})
+	recent := saveEvent(t, s, domain.Event{UserID: 1, Title: "Только что", Date: before})
+	upcoming := saveEvent(t, s, domain.Event{UserID: 1, Title: "Завтра", Date: day.Add(24 * time.Hour)})
+
+	awaiting := func() []int64 {
+		t.Helper()
+
+		events, err := s.Feedback.EventsAwaitingSurvey(ctx, after, before)
+		if err != nil {
+			t.Fatalf("Feedback.EventsAwaitingSurvey: %v", err)
+		}
+
+		return eventIDs(events)
+	}
+
+	// окно (after, before], опросы рассылаются от старых событий к новым
+	if got, want := awaiting(), []int64{older, recent}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Feedback.EventsAwaitingSurvey = %v, want %v (stale %d, edge %d, upcoming %d)", got, want, stale, edge, upcoming)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := s.Feedback.MarkSurveySent(ctx, older, day); err != nil {
+			t.Fatalf("Feedback.MarkSurveySent: %v", err)
+		}
+	}
+
+	if got, want := awaiting(), []int64{recent}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Feedback.EventsAwaitingSurvey after MarkSurveySent = %v, want %v", got, want)
+	}
+}
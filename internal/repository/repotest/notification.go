@@ -0,0 +1,56 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testNotifications(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+
+	anna := domain.User{ID: 10, FirstName: "Анна", UserName: "anna"}
+	boris := domain.User{ID: 11, FirstName: "Борис"}
+	queued := []domain.RegistrationChange{
+		{EventID: eventID, User: anna, Registered: true, CreatedAt: day},
+		{EventID: eventID, User: boris, Registered: true, CreatedAt: day.Add(time.Minute)},
+		{EventID: eventID, User: anna, Registered: false, CreatedAt: day.Add(2 * time.Minute)},
+	}
+	for _, c := range queued {
+		if err := s.Notifications.Enqueue(ctx, c); err != nil {
+			t.Fatalf("Notifications.Enqueue: %v", err)
+		}
+	}
+
+	pending, err := s.Notifications.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Notifications.Pending: %v", err)
+	}
+	if len(pending) != len(queued) {
+		t.Fatalf("Notifications.Pending = %d changes, want %d", len(pending), len(queued))
+	}
+
+	// изменения в порядке поступления
+	for i, got := range pending {
+		want := queued[i]
+		if got.ID == 0 || got.EventID != want.EventID || got.User != want.User || got.Registered != want.Registered || !got.CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("change %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if err := s.Notifications.Delete(ctx, []int64{pending[0].ID, pending[1].ID}); err != nil {
+		t.Fatalf("Notifications.Delete: %v", err)
+	}
+
+	rest, err := s.Notifications.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Notifications.Pending: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID != pending[2].ID {
+		t.Errorf("Notifications.Pending after Delete = %+v, want change %d", rest, pending[2].ID)
+	}
+}
@@ -0,0 +1,86 @@
+package repotest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testQuestions(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+
+	err := s.Questions.SaveQuestions(ctx, eventID, []domain.Question{
+		{Kind: domain.QuestionText, Text: "Название команды"},
+		{Kind: domain.QuestionSingle, Text: "Размер футболки", Options: []string{"S", "M", "L"}},
+		{Kind: domain.QuestionMulti, Text: "Питание", Options: []string{"Обед", "Ужин"}},
+	})
+	if err != nil {
+		t.Fatalf("Questions.SaveQuestions: %v", err)
+	}
+
+	questions, err := s.Questions.GetQuestions(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Questions.GetQuestions: %v", err)
+	}
+	if len(questions) != 3 {
+		t.Fatalf("Questions.GetQuestions = %d questions, want 3", len(questions))
+	}
+	for i, q := range questions {
+		if q.ID == 0 || q.EventID != eventID || q.Position != i {
+			t.Errorf("question %d = %+v, want id, event %d and position %d", i, q, eventID, i)
+		}
+	}
+	if q := questions[1]; q.Kind != domain.QuestionSingle || q.Text != "Размер футболки" || !reflect.DeepEqual(q.Options, []string{"S", "M", "L"}) {
+		t.Errorf("question 1 = %+v", q)
+	}
+	if q := questions[0]; len(q.Options) != 0 {
+		t.Errorf("text question options = %v, want none", q.Options)
+	}
+
+	team, size, food := questions[0].ID, questions[1].ID, questions[2].ID
+
+	save := func(userID int64, answers ...domain.Answer) {
+		t.Helper()
+
+		if err := s.Questions.SaveAnswers(ctx, eventID, userID, answers); err != nil {
+			t.Fatalf("Questions.SaveAnswers(%d): %v", userID, err)
+		}
+	}
+
+	// ответы упорядочены по вопросам, а не по сохранению
+	save(10, domain.Answer{QuestionID: food, Value: "Обед"}, domain.Answer{QuestionID: team, Value: "Гоферы"})
+	save(10, domain.Answer{QuestionID: size, Value: "M"})
+	// новый ответ на тот же вопрос заменяет прежний
+	save(10, domain.Answer{QuestionID: food, Value: "Обед; Ужин"})
+	save(11, domain.Answer{QuestionID: size, Value: "L"})
+
+	want := map[int64][]domain.Answer{
+		10: {{QuestionID: team, Value: "Гоферы"}, {QuestionID: size, Value: "M"}, {QuestionID: food, Value: "Обед; Ужин"}},
+		11: {{QuestionID: size, Value: "L"}},
+	}
+
+	answers, err := s.Questions.GetAnswers(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Questions.GetAnswers: %v", err)
+	}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("Questions.GetAnswers = %v, want %v", answers, want)
+	}
+
+	if err := s.Questions.DeleteAnswers(ctx, eventID, 10); err != nil {
+		t.Fatalf("Questions.DeleteAnswers: %v", err)
+	}
+	delete(want, 10)
+
+	answers, err = s.Questions.GetAnswers(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Questions.GetAnswers: %v", err)
+	}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("Questions.GetAnswers after DeleteAnswers = %v, want %v", answers, want)
+	}
+}
@@ -0,0 +1,171 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testUsers(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	if _, err := s.Users.GetByID(ctx, 10); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("Users.GetByID of unknown user error = %v, want ErrUserNotFound", err)
+	}
+
+	saveUser(t, s, 10, "Анна")
+	// имя в Telegram меняется — запись обновляется
+	renamed := domain.User{ID: 10, FirstName: "Аня", UserName: "anya"}
+	if err := s.Users.CreateOrUpdate(ctx, &renamed); err != nil {
+		t.Fatalf("Users.CreateOrUpdate: %v", err)
+	}
+
+	got, err := s.Users.GetByID(ctx, 10)
+	if err != nil {
+		t.Fatalf("Users.GetByID: %v", err)
+	}
+	if *got != renamed {
+		t.Errorf("Users.GetByID = %+v, want %+v", *got, renamed)
+	}
+}
+
+func testRegistrations(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+	saveUser(t, s, 10, "Анна")
+	saveUser(t, s, 11, "Борис")
+
+	register(t, s, eventID, 10, "AAAA", domain.RegistrationApproved)
+	register(t, s, eventID, 11, "BBBB", domain.RegistrationPending)
+	// пользователь, о котором бот ничего не знает, в списки участников не попадает
+	register(t, s, eventID, 12, "CCCC", domain.RegistrationApproved)
+
+	// повторная регистрация не меняет первую
+	err := s.Registrations.Register(ctx, eventID, 10, "DDDD", domain.RegistrationPending)
+	if !errors.Is(err, domain.ErrAlreadyRegistered) {
+		t.Errorf("Registrations.Register twice error = %v, want ErrAlreadyRegistered", err)
+	}
+
+	statuses := map[int64]domain.RegistrationStatus{
+		10: domain.RegistrationApproved,
+		11: domain.RegistrationPending,
+		99: domain.RegistrationNone,
+	}
+	for userID, want := range statuses {
+		got, err := s.Registrations.Status(ctx, eventID, userID)
+		if err != nil {
+			t.Fatalf("Registrations.Status(%d): %v", userID, err)
+		}
+		if got != want {
+			t.Errorf("Registrations.Status(%d) = %q, want %q", userID, got, want)
+		}
+
+		registered, err := s.Registrations.IsRegistered(ctx, eventID, userID)
+		if err != nil {
+			t.Fatalf("Registrations.IsRegistered(%d): %v", userID, err)
+		}
+		if registered != (want != domain.RegistrationNone) {
+			t.Errorf("Registrations.IsRegistered(%d) = %v", userID, registered)
+		}
+	}
+
+	participants, err := s.Registrations.GetParticipants(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Registrations.GetParticipants: %v", err)
+	}
+	// участники в порядке регистрации
+	if ids := participantIDs(participants); !reflect.DeepEqual(ids, []int64{10, 11}) {
+		t.Fatalf("Registrations.GetParticipants = %v, want [10 11]", ids)
+	}
+	if p := participants[1]; p.FirstName != "Борис" || p.UserName != "userБорис" || p.Status != domain.RegistrationPending || p.RegisteredAt.IsZero() {
+		t.Errorf("participant = %+v, want pending Борис", p)
+	}
+
+	page, total, err := s.Registrations.GetParticipantsPaginated(ctx, eventID, 1, 1)
+	if err != nil {
+		t.Fatalf("Registrations.GetParticipantsPaginated: %v", err)
+	}
+	if ids := participantIDs(page); !reflect.DeepEqual(ids, []int64{11}) || total != 3 {
+		t.Errorf("Registrations.GetParticipantsPaginated(1, 1) = %v of %d, want [11] of 3", ids, total)
+	}
+	if len(page) == 1 && page[0].Status != domain.RegistrationPending {
+		t.Errorf("paginated participant status = %q, want %q", page[0].Status, domain.RegistrationPending)
+	}
+
+	if err := s.Registrations.Approve(ctx, eventID, 11); err != nil {
+		t.Fatalf("Registrations.Approve: %v", err)
+	}
+	if status, _ := s.Registrations.Status(ctx, eventID, 11); status != domain.RegistrationApproved {
+		t.Errorf("status after Approve = %q, want %q", status, domain.RegistrationApproved)
+	}
+
+	if err := s.Registrations.Unregister(ctx, eventID, 10); err != nil {
+		t.Fatalf("Registrations.Unregister: %v", err)
+	}
+	if registered, _ := s.Registrations.IsRegistered(ctx, eventID, 10); registered {
+		t.Error("Registrations.IsRegistered after Unregister = true")
+	}
+	// после отмены можно зарегистрироваться снова
+	register(t, s, eventID, 10, "EEEE", domain.RegistrationApproved)
+
+	notFound := map[string]error{
+		"Approve approved":     s.Registrations.Approve(ctx, eventID, 11),
+		"Approve unregistered": s.Registrations.Approve(ctx, eventID, 99),
+		"Unregister unknown":   s.Registrations.Unregister(ctx, eventID, 99),
+	}
+	for name, err := range notFound {
+		if !errors.Is(err, domain.ErrRegistrationNotFound) {
+			t.Errorf("Registrations.%s error = %v, want ErrRegistrationNotFound", name, err)
+		}
+	}
+}
+
+func testTickets(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	eventID := saveEvent(t, s, domain.Event{UserID: 1, Title: "Митап", Date: day})
+	register(t, s, eventID, 10, "AAAA", domain.RegistrationApproved)
+	register(t, s, eventID, 11, "BBBB", domain.RegistrationPending)
+
+	ticket, err := s.Registrations.GetTicket(ctx, eventID, 11)
+	if err != nil {
+		t.Fatalf("Registrations.GetTicket: %v", err)
+	}
+	want := domain.Ticket{EventID: eventID, UserID: 11, Token: "BBBB", Status: domain.RegistrationPending}
+	if *ticket != want {
+		t.Errorf("Registrations.GetTicket = %+v, want %+v", *ticket, want)
+	}
+
+	arrived := day.Add(-10 * time.Minute)
+	if err := s.Registrations.CheckIn(ctx, "AAAA", arrived); err != nil {
+		t.Fatalf("Registrations.CheckIn: %v", err)
+	}
+	// повторная отметка не меняет время первой
+	if err := s.Registrations.CheckIn(ctx, "AAAA", day); !errors.Is(err, domain.ErrAlreadyCheckedIn) {
+		t.Errorf("Registrations.CheckIn twice error = %v, want ErrAlreadyCheckedIn", err)
+	}
+
+	ticket, err = s.Registrations.GetTicketByToken(ctx, "AAAA")
+	if err != nil {
+		t.Fatalf("Registrations.GetTicketByToken: %v", err)
+	}
+	if ticket.EventID != eventID || ticket.UserID != 10 || ticket.Status != domain.RegistrationApproved || !ticket.CheckedInAt.Equal(arrived) {
+		t.Errorf("Registrations.GetTicketByToken = %+v, want user 10 checked in at %v", *ticket, arrived)
+	}
+
+	notFound := map[string]error{
+		"GetTicket":        func() error { _, err := s.Registrations.GetTicket(ctx, eventID, 99); return err }(),
+		"GetTicketByToken": func() error { _, err := s.Registrations.GetTicketByToken(ctx, "ZZZZ"); return err }(),
+		"CheckIn":          s.Registrations.CheckIn(ctx, "ZZZZ", day),
+	}
+	for name, err := range notFound {
+		if !errors.Is(err, domain.ErrTicketNotFound) {
+			t.Errorf("Registrations.%s of unknown ticket error = %v, want ErrTicketNotFound", name, err)
+		}
+	}
+}
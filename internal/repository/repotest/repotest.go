@@ -0,0 +1,113 @@
+// Package repotest общие проверки репозиториев: хранилища SQLite, Postgres
+// и память должны вести себя одинаково, иначе демо-режим и тесты usecase'ов
+// на памяти расходятся с работой бота на настоящей базе.
+//
+// Тесты пакета хранилища передают в Run конструктор пустого хранилища:
+//
+//	func TestContract(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repotest.Storage { ... })
+//	}
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+// Storage репозитории одного хранилища с общими данными.
+type Storage struct {
+	Events        repository.EventRepository
+	Users         repository.UserRepository
+	States        repository.StateRepository
+	Registrations repository.RegistrationRepository
+	Channels      repository.ChannelRepository
+	Templates     repository.TemplateRepository
+	Notifications repository.NotificationRepository
+	Feedback      repository.FeedbackRepository
+	Questions     repository.QuestionRepository
+	Transactor    repository.Transactor
+}
+
+// Run прогоняет проверки; open вызывается для каждой и возвращает пустое хранилище.
+func Run(t *testing.T, open func(t *testing.T) Storage) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s Storage)
+	}{
+		{name: "events", fn: testEvents},
+		{name: "event cascade", fn: testEventCascade},
+		{name: "users", fn: testUsers},
+		{name: "registrations", fn: testRegistrations},
+		{name: "tickets", fn: testTickets},
+		{name: "questions", fn: testQuestions},
+		{name: "notifications", fn: testNotifications},
+		{name: "feedback", fn: testFeedback},
+		{name: "surveys", fn: testSurveys},
+		{name: "channels", fn: testChannels},
+		{name: "templates", fn: testTemplates},
+		{name: "states", fn: testStates},
+		{name: "transactor", fn: testTransactor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, open(t))
+		})
+	}
+}
+
+// day опорный момент проверок. Моменты без долей секунды одинаково хранят
+// все хранилища: Postgres округляет время до микросекунд.
+var day = time.Date(2026, time.November, 20, 19, 0, 0, 0, time.UTC)
+
+func saveEvent(t *testing.T, s Storage, e domain.Event) int64 {
+	t.Helper()
+
+	id, err := s.Events.Save(context.Background(), e)
+	if err != nil {
+		t.Fatalf("Events.Save(%q): %v", e.Title, err)
+	}
+
+	return id
+}
+
+func saveUser(t *testing.T, s Storage, id int64, name string) {
+	t.Helper()
+
+	user := domain.User{ID: id, FirstName: name, UserName: "user" + name}
+	if err := s.Users.CreateOrUpdate(context.Background(), &user); err != nil {
+		t.Fatalf("Users.CreateOrUpdate(%d): %v", id, err)
+	}
+}
+
+func register(t *testing.T, s Storage, eventID int64, userID int64, ticket string, status domain.RegistrationStatus) {
+	t.Helper()
+
+	if err := s.Registrations.Register(context.Background(), eventID, userID, ticket, status); err != nil {
+		t.Fatalf("Registrations.Register(%d, %d): %v", eventID, userID, err)
+	}
+}
+
+// eventIDs идентификаторы событий в порядке списка.
+func eventIDs(events []domain.Event) []int64 {
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	return ids
+}
+
+// participantIDs идентификаторы участников в порядке списка.
+func participantIDs(participants []domain.Participant) []int64 {
+	ids := make([]int64, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, p.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,177 @@
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+func testStates(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	const (
+		userID int64 = 10
+		other  int64 = 11
+	)
+
+	// current проверяет, какое состояние сейчас видит пользователь.
+	current := func(step string, wantFlow, wantTitle string) {
+		t.Helper()
+
+		state, err := s.States.GetState(ctx, userID)
+		if err != nil {
+			t.Fatalf("%s: States.GetState: %v", step, err)
+		}
+		if state.Flow != wantFlow || state.TempEvent.Title != wantTitle || state.CreatedAt.IsZero() {
+			t.Errorf("%s: state = flow %q, title %q, saved at %v; want flow %q, title %q",
+				step, state.Flow, state.TempEvent.Title, state.CreatedAt, wantFlow, wantTitle)
+		}
+	}
+
+	none := func(step string) {
+		t.Helper()
+
+		if _, err := s.States.GetState(ctx, userID); !errors.Is(err, repository.ErrStateNotFound) {
+			t.Fatalf("%s: States.GetState error = %v, want ErrStateNotFound", step, err)
+		}
+	}
+
+	none("empty")
+
+	// без активного черновика SaveState мастера создаёт новый
+	meetup := domain.EventState{Step: domain.StepTitle, TempEvent: domain.Event{Title: "Митап"}}
+	if err := s.States.SaveState(ctx, userID, meetup); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+	current("draft", domain.FlowEvent, "Митап")
+
+	// служебный диалог перекрывает черновик, пока не завершится
+	checkIn := domain.EventState{Flow: domain.FlowCheckIn, Step: "ticket", Payload: json.RawMessage(`{"EventID":7}`)}
+	if err := s.States.SaveState(ctx, userID, checkIn); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+	current("dialog", domain.FlowCheckIn, "")
+	state, err := s.States.GetState(ctx, userID)
+	if err != nil {
+		t.Fatalf("States.GetState: %v", err)
+	}
+	if state.Step != checkIn.Step || !bytes.Equal(state.Payload, checkIn.Payload) {
+		t.Errorf("dialog = step %q, payload %s; want step %q, payload %s",
+			state.Step, state.Payload, checkIn.Step, checkIn.Payload)
+	}
+	if err := s.States.DeleteState(ctx, userID); err != nil {
+		t.Fatalf("States.DeleteState: %v", err)
+	}
+	current("dialog finished", domain.FlowEvent, "Митап")
+
+	// новый черновик становится активным и прерывает диалог
+	if err := s.States.SaveState(ctx, userID, checkIn); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+	lectureID, err := s.States.CreateDraft(ctx, userID, domain.EventState{Step: domain.StepTitle})
+	if err != nil {
+		t.Fatalf("States.CreateDraft: %v", err)
+	}
+	current("second draft", domain.FlowEvent, "")
+
+	// сохранение обновляет активный черновик, а не создаёт ещё один
+	lecture := domain.EventState{Step: domain.StepDescription, TempEvent: domain.Event{Title: "Лекция"}}
+	if err := s.States.SaveState(ctx, userID, lecture); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+	current("second draft saved", domain.FlowEvent, "Лекция")
+
+	drafts, err := s.States.ListDrafts(ctx, userID)
+	if err != nil {
+		t.Fatalf("States.ListDrafts: %v", err)
+	}
+	if len(drafts) != 2 {
+		t.Fatalf("States.ListDrafts = %d drafts, want 2", len(drafts))
+	}
+	// последний изменённый черновик первым
+	if d := drafts[0]; d.ID != lectureID || d.UserID != userID || d.Name != "Лекция" || !d.Active || d.State.Step != domain.StepDescription {
+		t.Errorf("draft 0 = %+v, want active «Лекция» %d", d, lectureID)
+	}
+	meetupID := drafts[1].ID
+	if d := drafts[1]; d.Name != "Митап" || d.Active {
+		t.Errorf("draft 1 = %+v, want inactive «Митап»", d)
+	}
+
+	// имя, заданное пользователем, сохраняется при дальнейшем редактировании
+	if err := s.States.RenameDraft(ctx, userID, lectureID, "Осенняя лекция"); err != nil {
+		t.Fatalf("States.RenameDraft: %v", err)
+	}
+	state, err = s.States.GetState(ctx, userID)
+	if err != nil {
+		t.Fatalf("States.GetState: %v", err)
+	}
+	state.TempEvent.Title = "Лекция о Go"
+	if err := s.States.SaveState(ctx, userID, *state); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+	drafts, err = s.States.ListDrafts(ctx, userID)
+	if err != nil {
+		t.Fatalf("States.ListDrafts: %v", err)
+	}
+	if d := drafts[0]; d.ID != lectureID || d.Name != "Осенняя лекция" || d.State.TempEvent.Title != "Лекция о Go" {
+		t.Errorf("renamed draft = %+v, want «Осенняя лекция» %d", d, lectureID)
+	}
+
+	if err := s.States.ActivateDraft(ctx, userID, meetupID); err != nil {
+		t.Fatalf("States.ActivateDraft: %v", err)
+	}
+	current("first draft activated", domain.FlowEvent, "Митап")
+
+	// чужие черновики недоступны
+	notFound := map[string]error{
+		"ActivateDraft of other user": s.States.ActivateDraft(ctx, other, lectureID),
+		"DeleteDraft of other user":   s.States.DeleteDraft(ctx, other, lectureID),
+		"ActivateDraft unknown":       s.States.ActivateDraft(ctx, userID, 1000),
+		"DeleteDraft unknown":         s.States.DeleteDraft(ctx, userID, 1000),
+		"RenameDraft of other user":   s.States.RenameDraft(ctx, other, lectureID, "Чужой"),
+		"RenameDraft unknown":         s.States.RenameDraft(ctx, userID, 1000, "Нет"),
+	}
+	for name, err := range notFound {
+		if !errors.Is(err, repository.ErrStateNotFound) {
+			t.Errorf("States.%s error = %v, want ErrStateNotFound", name, err)
+		}
+	}
+
+	// DeleteState без диалога удаляет активный черновик; другой активным не становится
+	if err := s.States.DeleteState(ctx, userID); err != nil {
+		t.Fatalf("States.DeleteState: %v", err)
+	}
+	none("active draft deleted")
+
+	if err := s.States.DeleteDraft(ctx, userID, lectureID); err != nil {
+		t.Fatalf("States.DeleteDraft: %v", err)
+	}
+	if drafts, err := s.States.ListDrafts(ctx, userID); err != nil || len(drafts) != 0 {
+		t.Errorf("States.ListDrafts after DeleteDraft = %+v, %v; want none", drafts, err)
+	}
+
+	// устаревшие черновики и диалоги удаляются вместе
+	if err := s.States.SaveState(ctx, userID, meetup); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+	if err := s.States.SaveState(ctx, other, checkIn); err != nil {
+		t.Fatalf("States.SaveState: %v", err)
+	}
+
+	if n, err := s.States.DeleteExpired(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("States.DeleteExpired(hour ago) = %d, %v; want 0", n, err)
+	}
+	if n, err := s.States.DeleteExpired(ctx, time.Now().Add(time.Hour)); err != nil || n != 2 {
+		t.Errorf("States.DeleteExpired(in an hour) = %d, %v; want 2", n, err)
+	}
+	none("expired")
+	if _, err := s.States.GetState(ctx, other); !errors.Is(err, repository.ErrStateNotFound) {
+		t.Errorf("States.GetState of expired dialog error = %v, want ErrStateNotFound", err)
+	}
+}
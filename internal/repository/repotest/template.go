@@ -0,0 +1,71 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+func testTemplates(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	weekly := domain.Template{
+		UserID:         1,
+		ChatID:         -42,
+		Title:          "Еженедельный митап",
+		Description:    "Про Go",
+		PhotoFileID:    "photo",
+		DocumentFileID: "program",
+		Hour:           19,
+		Minute:         30,
+		CreatedAt:      day,
+	}
+	monthly := domain.Template{UserID: 1, Title: "Ежемесячная встреча", Hour: 9, CreatedAt: day.Add(time.Hour)}
+	foreign := domain.Template{UserID: 2, Title: "Чужой шаблон", CreatedAt: day}
+
+	for _, tmpl := range []*domain.Template{&weekly, &monthly, &foreign} {
+		id, err := s.Templates.Save(ctx, *tmpl)
+		if err != nil {
+			t.Fatalf("Templates.Save: %v", err)
+		}
+		tmpl.ID = id
+	}
+
+	got, err := s.Templates.GetByID(ctx, weekly.ID)
+	if err != nil {
+		t.Fatalf("Templates.GetByID: %v", err)
+	}
+	if !got.CreatedAt.Equal(weekly.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, weekly.CreatedAt)
+	}
+	got.CreatedAt = weekly.CreatedAt
+	if *got != weekly {
+		t.Errorf("Templates.GetByID = %+v, want %+v", *got, weekly)
+	}
+
+	list, err := s.Templates.GetByUserID(ctx, 1)
+	if err != nil {
+		t.Fatalf("Templates.GetByUserID: %v", err)
+	}
+	// новые шаблоны первыми
+	if len(list) != 2 || list[0].ID != monthly.ID || list[1].ID != weekly.ID {
+		t.Errorf("Templates.GetByUserID = %+v, want templates %d and %d", list, monthly.ID, weekly.ID)
+	}
+
+	if err := s.Templates.Delete(ctx, weekly.ID); err != nil {
+		t.Fatalf("Templates.Delete: %v", err)
+	}
+
+	notFound := map[string]error{
+		"GetByID deleted": func() error { _, err := s.Templates.GetByID(ctx, weekly.ID); return err }(),
+		"Delete deleted":  s.Templates.Delete(ctx, weekly.ID),
+	}
+	for name, err := range notFound {
+		if !errors.Is(err, domain.ErrTemplateNotFound) {
+			t.Errorf("Templates.%s error = %v, want ErrTemplateNotFound", name, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// testTransactor изменения внутри транзакции видны после её фиксации, а при
+// ошибке fn откатываются, и ошибка возвращается вызывающему.
+func testTransactor(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	var ids []int64
+	err := s.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for _, title := range []string{"Митап", "Лекция"} {
+			id, err := s.Events.Save(ctx, domain.Event{UserID: 1, Title: title, Date: day})
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		// вложенная транзакция присоединяется к внешней и видит её изменения
+		return s.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := s.Events.GetByID(ctx, ids[0])
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("Transactor.WithinTx: %v", err)
+	}
+
+	events, err := s.Events.GetByUserID(ctx, 1)
+	if err != nil {
+		t.Fatalf("Events.GetByUserID: %v", err)
+	}
+	if len(events) != len(ids) {
+		t.Errorf("events after commit = %d, want %d", len(events), len(ids))
+	}
+
+	errStop := errors.New("stop")
+	err = s.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := s.Events.Save(ctx, domain.Event{UserID: 2, Title: "Отменённое", Date: day})
+		if err != nil {
+			return err
+		}
+		if err := s.Registrations.Register(ctx, ids[0], 10, "ROLLBACK", domain.RegistrationApproved); err != nil {
+			return err
+		}
+		if err := s.Events.Delete(ctx, ids[1]); err != nil {
+			return err
+		}
+
+		// изменения видны внутри транзакции до отката
+		if _, err := s.Events.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Transactor.WithinTx error = %v, want %v", err, errStop)
+	}
+
+	if events, err := s.Events.GetByUserID(ctx, 2); err != nil || len(events) != 0 {
+		t.Errorf("events saved in rolled back tx = %+v, %v; want none", events, err)
+	}
+	if registered, err := s.Registrations.IsRegistered(ctx, ids[0], 10); err != nil || registered {
+		t.Errorf("Registrations.IsRegistered after rollback = %v, %v; want false", registered, err)
+	}
+	if _, err := s.Events.GetByID(ctx, ids[1]); err != nil {
+		t.Errorf("Events.GetByID of event deleted in rolled back tx: %v", err)
+	}
+}
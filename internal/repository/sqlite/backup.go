@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// backupTables таблицы копии в порядке восстановления: сначала те,
+// на которые ссылаются остальные.
+var backupTables = []string{
+	"users",
+	"events",
+	"registrations",
+	"questions",
+	"answers",
+	"channels",
+	"event_posts",
+	"templates",
+	"surveys",
+	"feedback",
+}
+
+type BackupRepository struct {
+	db *sql.DB
+}
+
+func NewBackupRepository(db *sql.DB) *BackupRepository {
+	return &BackupRepository{
+		db: db,
+	}
+}
+
+// Dump читает все таблицы в одной транзакции: бот может продолжать работу.
+func (r *BackupRepository) Dump(ctx context.Context) (*domain.Snapshot, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	snapshot := &domain.Snapshot{CreatedAt: time.Now().UTC()}
+
+	for _, table := range backupTables {
+		dump, err := dumpTable(ctx, tx, table)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Tables = append(snapshot.Tables, dump)
+	}
+
+	return snapshot, nil
+}
+
+func dumpTable(ctx context.Context, tx *sql.Tx, table string) (domain.TableDump, error) {
+	dump := domain.TableDump{Name: table}
+
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+table)
+	if err != nil {
+		return dump, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	if dump.Columns, err = rows.Columns(); err != nil {
+		return dump, fmt.Errorf("failed to get columns of %s: %w", table, err)
+	}
+
+	for rows.Next() {
+		values := make([]interface{}, len(dump.Columns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return dump, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+
+		for i, v := range values {
+			// TEXT без объявленного типа драйвер отдаёт байтами
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+
+		dump.Rows = append(dump.Rows, values)
+	}
+
+	return dump, rows.Err()
+}
+
+// Restore восстанавливает таблицы в одной транзакции. Имена таблиц и столбцов
+// из копии сверяются со схемой, прежде чем попасть в запрос.
+func (r *BackupRepository) Restore(ctx context.Context, snapshot *domain.Snapshot) error {
+	tables := make(map[string]domain.TableDump, len(snapshot.Tables))
+	for _, t := range snapshot.Tables {
+		tables[t.Name] = t
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range backupTables {
+		dump, ok := tables[table]
+		if !ok {
+			continue
+		}
+		delete(tables, table)
+
+		if err := restoreTable(ctx, tx, dump); err != nil {
+			return err
+		}
+	}
+
+	for name := range tables {
+		return fmt.Errorf("%w: unknown table %q", domain.ErrUnsupportedBackup, name)
+	}
+
+	return tx.Commit()
+}
+
+func restoreTable(ctx context.Context, tx *sql.Tx, dump domain.TableDump) error {
+	types, err := columnTypes(ctx, tx, dump.Name)
+	if err != nil {
+		return err
+	}
+
+	set := make([]string, 0, len(dump.Columns))
+	for _, c := range dump.Columns {
+		if _, ok := types[c]; !ok {
+			return fmt.Errorf("%w: unknown column %s.%s", domain.ErrUnsupportedBackup, dump.Name, c)
+		}
+		set = append(set, fmt.Sprintf("%s = excluded.%s", c, c))
+	}
+
+	// upsert, а не INSERT OR REPLACE: замена удалила бы строку
+	// и каскадом — зависящие от неё записи
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT DO UPDATE SET %s",
+		dump.Name,
+		strings.Join(dump.Columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(dump.Columns)), ", "),
+		strings.Join(set, ", "),
+	)
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare restore of %s: %w", dump.Name, err)
+	}
+	defer stmt.Close()
+
+	for _, row := range dump.Rows {
+		if len(row) != len(dump.Columns) {
+			return fmt.Errorf("%w: malformed row in %s", domain.ErrUnsupportedBackup, dump.Name)
+		}
+
+		args := make([]interface{}, len(row))
+		for i, v := range row {
+			if args[i], err = restoreValue(types[dump.Columns[i]], v); err != nil {
+				return fmt.Errorf("failed to restore %s.%s: %w", dump.Name, dump.Columns[i], err)
+			}
+		}
+
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", dump.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// columnTypes объявленные типы столбцов таблицы в верхнем регистре.
+func columnTypes(ctx context.Context, tx *sql.Tx, table string) (map[string]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name, type FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	types := make(map[string]string)
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, fmt.Errorf("failed to scan columns of %s: %w", table, err)
+		}
+
+		types[name] = strings.ToUpper(typ)
+	}
+
+	return types, rows.Err()
+}
+
+// restoreValue даты в копии хранятся строками: их нужно вернуть драйверу
+// как time.Time, чтобы он записал их в своём формате.
+func restoreValue(typ string, v interface{}) (interface{}, error) {
+	if value, ok := v.(string); ok && (typ == "DATETIME" || typ == "TIMESTAMP") {
+		return time.Parse(time.RFC3339Nano, value)
+	}
+
+	return v, nil
+}
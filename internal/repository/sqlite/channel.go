@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type ChannelRepository struct {
+	db *sql.DB
+}
+
+func NewChannelRepository(db *sql.DB) *ChannelRepository {
+	return &ChannelRepository{
+		db: db,
+	}
+}
+
+func (r *ChannelRepository) SetChannel(ctx context.Context, chatID int64, channelID int64) error {
+	const query = `
+		INSERT INTO channels (chat_id, channel_id)
+		VALUES (?, ?)
+		ON CONFLICT(chat_id) DO UPDATE SET
+			channel_id = excluded.channel_id`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, chatID, channelID); err != nil {
+		return fmt.Errorf("failed to set channel: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChannelRepository) GetChannel(ctx context.Context, chatID int64) (int64, error) {
+	const query = `
+		SELECT channel_id
+		FROM channels
+		WHERE chat_id = ?`
+
+	var channelID int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, chatID).Scan(&channelID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrChannelNotFound
+		}
+
+		return 0, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	return channelID, nil
+}
+
+func (r *ChannelRepository) DeleteChannel(ctx context.Context, chatID int64) error {
+	const query = `
+		DELETE FROM channels
+		WHERE chat_id = ?`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, chatID); err != nil {
+		return fmt.Errorf("failed to delete channel: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChannelRepository) SavePost(ctx context.Context, post domain.EventPost) error {
+	const query = `
+		INSERT INTO event_posts (event_id, channel_id, message_id)
+		VALUES (?, ?, ?)
+		ON CONFLICT(event_id, channel_id) DO UPDATE SET
+			message_id = excluded.message_id`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		post.EventID,
+		post.ChannelID,
+		post.MessageID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save post: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChannelRepository) GetPosts(ctx context.Context, eventID int64) ([]domain.EventPost, error) {
+	const query = `
+		SELECT event_id, channel_id, message_id
+		FROM event_posts
+		WHERE event_id = ?`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []domain.EventPost
+	for rows.Next() {
+		var p domain.EventPost
+		if err := rows.Scan(&p.EventID, &p.ChannelID, &p.MessageID); err != nil {
+			return nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+
+		posts = append(posts, p)
+	}
+
+	return posts, rows.Err()
+}
+
+func (r *ChannelRepository) DeletePosts(ctx context.Context, eventID int64) error {
+	const query = `
+		DELETE FROM event_posts
+		WHERE event_id = ?`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID); err != nil {
+		return fmt.Errorf("failed to delete posts: %w", err)
+	}
+
+	return nil
+}
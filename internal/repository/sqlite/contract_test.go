@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/binaryty/evbot/internal/repository/repotest"
+)
+
+func TestContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Storage {
+		db := openTestDB(t, DataSource(filepath.Join(t.TempDir(), "bot.db")))
+		if err := Migrate(context.Background(), db); err != nil {
+			t.Fatalf("Migrate: %v", err)
+		}
+
+		return repotest.Storage{
+			Events:        NewEventRepository(db),
+			Users:         NewUserRepository(db),
+			States:        NewStateRepository(db),
+			Registrations: NewRegistrationRepository(db),
+			Channels:      NewChannelRepository(db),
+			Templates:     NewTemplateRepository(db),
+			Notifications: NewNotificationRepository(db),
+			Feedback:      NewFeedbackRepository(db),
+			Questions:     NewQuestionRepository(db),
+			Transactor:    NewTransactor(db),
+		}
+	})
+}
@@ -23,14 +23,23 @@ func NewEventRepository(db *sql.DB) *EventRepository {
 func (r *EventRepository) Save(ctx context.Context, e domain.Event) (int64, error) {
 	const query = `
 		INSERT INTO events
-			(user_id, title, description, date, created_at)
-		VALUES (?, ?, ?, ?, ?) RETURNING id`
+			(user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
 
-	res, err := r.db.ExecContext(ctx, query,
+	if e.NotifyMode == "" {
+		e.NotifyMode = domain.NotifyOff
+	}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		e.UserID,
+		e.ChatID,
 		e.Title,
 		e.Description,
+		e.PhotoFileID,
+		e.DocumentFileID,
 		e.Date.UTC(),
+		e.NotifyMode,
+		e.RequiresApproval,
 		e.CreatedAt.UTC(),
 	)
 	if err != nil {
@@ -46,22 +55,11 @@ func (r *EventRepository) Save(ctx context.Context, e domain.Event) (int64, erro
 
 func (r *EventRepository) GetByID(ctx context.Context, eventID int64) (*domain.Event, error) {
 	const query = `
-		SELECT id, user_id, title, description, date, created_at
-		FROM events 
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events
 		WHERE id = ?`
 
-	var event domain.Event
-	var dateStr, createdAtStr string
-
-	err := r.db.QueryRowContext(ctx, query, eventID).Scan(
-		&event.ID,
-		&event.UserID,
-		&event.Title,
-		&event.Description,
-		&dateStr,
-		&createdAtStr,
-	)
-
+	event, err := scanEvent(conn(ctx, r.db).QueryRowContext(ctx, query, eventID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrEventNotFound
@@ -70,71 +68,48 @@ func (r *EventRepository) GetByID(ctx context.Context, eventID int64) (*domain.E
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	event.Date, err = time.Parse(time.RFC3339, dateStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse date: %w", err)
-	}
-
-	event.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse date: %w", err)
-	}
-
 	return &event, nil
 }
 
 func (r *EventRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Event, error) {
 	const query = `
-		SELECT id, user_id, title, description, date, created_at
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
 		FROM events
 		WHERE user_id = ?
 		ORDER BY date DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query events: %w", err)
 	}
 	defer rows.Close()
 
-	var events []domain.Event
-	for rows.Next() {
-		var event domain.Event
-		var dateStr, createdAtStr string
-
-		if err := rows.Scan(
-			&event.ID,
-			&event.UserID,
-			&event.Title,
-			&event.Description,
-			&dateStr,
-			&createdAtStr,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
-		}
-
-		event.Date, err = time.Parse(time.RFC3339, dateStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
-		}
+	return scanEvents(rows)
+}
 
-		event.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
-		}
+func (r *EventRepository) GetByChatID(ctx context.Context, chatID int64) ([]domain.Event, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events
+		WHERE chat_id = ?
+		ORDER BY date DESC`
 
-		events = append(events, event)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
 	}
+	defer rows.Close()
 
-	return events, nil
+	return scanEvents(rows)
 }
 
 func (r *EventRepository) GetAll(ctx context.Context) ([]domain.Event, error) {
 	const query = `
-		SELECT id, user_id, title, description, date, created_at
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
 		FROM events
 		ORDER BY date DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrEventNotFound
@@ -144,34 +119,7 @@ func (r *EventRepository) GetAll(ctx context.Context) ([]domain.Event, error) {
 	}
 	defer rows.Close()
 
-	var events []domain.Event
-	for rows.Next() {
-		var event domain.Event
-		var dateStr, createdAtStr string
-
-		if err := rows.Scan(
-			&event.ID,
-			&event.UserID,
-			&event.Title,
-			&event.Description,
-			&dateStr,
-			&createdAtStr,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
-		}
-		event.Date, err = time.Parse(time.RFC3339, dateStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
-		}
-		event.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse date: %w", err)
-		}
-
-		events = append(events, event)
-	}
-
-	return events, nil
+	return scanEvents(rows)
 }
 
 func (r *EventRepository) Delete(ctx context.Context, eventID int64) error {
@@ -179,7 +127,7 @@ func (r *EventRepository) Delete(ctx context.Context, eventID int64) error {
 		DELETE FROM events
 		WHERE id = ?`
 
-	res, err := r.db.ExecContext(ctx, query, eventID)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, eventID)
 	if err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
@@ -191,3 +139,98 @@ func (r *EventRepository) Delete(ctx context.Context, eventID int64) error {
 
 	return err
 }
+
+// SetApproval ...
+func (r *EventRepository) SetApproval(ctx context.Context, eventID int64, required bool) error {
+	const query = `
+		UPDATE events
+		SET requires_approval = ?
+		WHERE id = ?`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, required, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update approval mode: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// SetNotifyMode ...
+func (r *EventRepository) SetNotifyMode(ctx context.Context, eventID int64, mode domain.NotifyMode) error {
+	const query = `
+		UPDATE events
+		SET notify_mode = ?
+		WHERE id = ?`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, mode, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update notify mode: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEvent ...
+func scanEvent(row rowScanner) (domain.Event, error) {
+	var event domain.Event
+	var dateStr, createdAtStr string
+
+	err := row.Scan(
+		&event.ID,
+		&event.UserID,
+		&event.ChatID,
+		&event.Title,
+		&event.Description,
+		&event.PhotoFileID,
+		&event.DocumentFileID,
+		&dateStr,
+		&event.NotifyMode,
+		&event.RequiresApproval,
+		&createdAtStr,
+	)
+	if err != nil {
+		return event, err
+	}
+
+	event.Date, err = time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return event, fmt.Errorf("failed to parse date: %w", err)
+	}
+
+	event.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		return event, fmt.Errorf("failed to parse date: %w", err)
+	}
+
+	return event, nil
+}
+
+// scanEvents ...
+func scanEvents(rows *sql.Rows) ([]domain.Event, error) {
+	var events []domain.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type FeedbackRepository struct {
+	db *sql.DB
+}
+
+func NewFeedbackRepository(db *sql.DB) *FeedbackRepository {
+	return &FeedbackRepository{
+		db: db,
+	}
+}
+
+// SaveRating сохраняет оценку; повторная оценка заменяет прежнюю, комментарий остаётся.
+func (r *FeedbackRepository) SaveRating(ctx context.Context, f domain.Feedback) error {
+	const query = `
+		INSERT INTO feedback (event_id, user_id, rating, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (event_id, user_id) DO UPDATE SET rating = excluded.rating`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		f.EventID,
+		f.User.ID,
+		f.Rating,
+		f.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save rating: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FeedbackRepository) SaveComment(ctx context.Context, eventID int64, userID int64, comment string) error {
+	const query = `
+		UPDATE feedback
+		SET comment = ?
+		WHERE event_id = ? AND user_id = ?`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, comment, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to save comment: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrFeedbackNotFound
+	}
+
+	return nil
+}
+
+func (r *FeedbackRepository) GetByEventID(ctx context.Context, eventID int64) ([]domain.Feedback, error) {
+	const query = `
+		SELECT f.event_id, f.user_id, COALESCE(u.first_name, ''), COALESCE(u.username, ''), f.rating, f.comment, f.created_at
+		FROM feedback f
+		LEFT JOIN users u ON f.user_id = u.user_id
+		WHERE f.event_id = ?
+		ORDER BY f.created_at DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var feedback []domain.Feedback
+	for rows.Next() {
+		var f domain.Feedback
+		var createdAt time.Time
+
+		err := rows.Scan(
+			&f.EventID,
+			&f.User.ID,
+			&f.User.FirstName,
+			&f.User.UserName,
+			&f.Rating,
+			&f.Comment,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feedback: %w", err)
+		}
+
+		f.CreatedAt = createdAt
+		feedback = append(feedback, f)
+	}
+
+	return feedback, rows.Err()
+}
+
+func (r *FeedbackRepository) EventsAwaitingSurvey(ctx context.Context, after, before time.Time) ([]domain.Event, error) {
+	const query = `
+		SELECT id, user_id, chat_id, title, description, photo_file_id, document_file_id, date, notify_mode, requires_approval, created_at
+		FROM events e
+		WHERE date > ? AND date <= ? AND NOT EXISTS (SELECT 1 FROM surveys s WHERE s.event_id = e.id)
+		ORDER BY date`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, after.UTC(), before.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func (r *FeedbackRepository) MarkSurveySent(ctx context.Context, eventID int64, at time.Time) error {
+	const query = `
+		INSERT OR IGNORE INTO surveys (event_id, sent_at)
+		VALUES (?, ?)`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, at.UTC()); err != nil {
+		return fmt.Errorf("failed to mark survey sent: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/binaryty/evbot/migrations"
+)
+
+// migration файл схемы, переводящий базу в версию version.
+type migration struct {
+	version int
+	name    string
+}
+
+// Migrate применяет к базе миграции новее её версии (PRAGMA user_version),
+// каждую в своей транзакции. База без версии, в которой уже есть таблицы,
+// создана по исходной схеме до появления миграций и считается версией 1.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	list, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		if m.version <= version {
+			continue
+		}
+
+		if err := applyMigration(ctx, db, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadMigrations миграции по возрастанию версии.
+func loadMigrations() ([]migration, error) {
+	names, err := fs.Glob(migrations.SQLite, "sqlite/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	list := make([]migration, 0, len(names))
+	for _, name := range names {
+		prefix := strings.SplitN(strings.TrimPrefix(name, "sqlite/"), "_", 2)[0]
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("bad migration name %s: %w", name, err)
+		}
+
+		list = append(list, migration{version: version, name: name})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].version < list[j].version
+	})
+
+	return list, nil
+}
+
+// schemaVersion ...
+func schemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	if version > 0 {
+		return version, nil
+	}
+
+	var tables int
+	err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'events'`,
+	).Scan(&tables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect schema: %w", err)
+	}
+
+	if tables > 0 {
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	script, err := fs.ReadFile(migrations.SQLite, m.name)
+	if err != nil {
+		return fmt.Errorf("failed to read migration %s: %w", m.name, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+	}
+
+	// PRAGMA не принимает параметров; версия — число из имени файла
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", m.version)); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// baselineSchema схема базы до появления миграций.
+const baselineSchema = `
+CREATE TABLE events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    date DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE users (
+    user_id INTEGER PRIMARY KEY,
+    first_name TEXT NOT NULL,
+    username TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE registrations (
+    event_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+CREATE TABLE user_states (
+    user_id INTEGER PRIMARY KEY,
+    state_data TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);`
+
+func openTestDB(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func latestVersion(t *testing.T) int {
+	t.Helper()
+
+	list, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+
+	return list[len(list)-1].version
+}
+
+func TestMigrateFreshDatabase(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t, DataSource(filepath.Join(t.TempDir(), "events.db")))
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	version, err := schemaVersion(ctx, db)
+	if err != nil {
+		t.Fatalf("schemaVersion: %v", err)
+	}
+	if want := latestVersion(t); version != want {
+		t.Errorf("version = %d, want %d", version, want)
+	}
+
+	// повторный запуск ничего не меняет
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+}
+
+func TestMigrateBaselineDatabase(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	// старая база заполнялась без проверки внешних ключей
+	db := openTestDB(t, path)
+
+	if _, err := db.Exec(baselineSchema); err != nil {
+		t.Fatalf("baseline schema: %v", err)
+	}
+
+	date := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+	if _, err := db.Exec(`INSERT INTO events (user_id, title, description, date) VALUES (1, 'Митап', '', ?)`, date); err != nil {
+		t.Fatalf("insert event: %v", err)
+	}
+	// регистрация удалённого события: без внешних ключей она оставалась в базе
+	if _, err := db.Exec(`INSERT INTO registrations (event_id, user_id) VALUES (1, 2), (7, 2)`); err != nil {
+		t.Fatalf("insert registrations: %v", err)
+	}
+
+	state := `{"Step":"description","TempEvent":{"Title":"Субботник"}}`
+	if _, err := db.Exec(`INSERT INTO user_states (user_id, state_data, created_at) VALUES (3, ?, ?)`, state, time.Now().UTC()); err != nil {
+		t.Fatalf("insert state: %v", err)
+	}
+
+	db.Close()
+	db = openTestDB(t, DataSource(path))
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	event, err := NewEventRepository(db).GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if event.Title != "Митап" || event.ChatID != 0 || event.PhotoFileID != "" {
+		t.Errorf("event = %+v", event)
+	}
+
+	status, err := NewRegistrationRepository(db).Status(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if status != domain.RegistrationApproved {
+		t.Errorf("status = %q, want approved", status)
+	}
+
+	// незавершённый мастер сохранился как активный черновик
+	states := NewStateRepository(db)
+
+	got, err := states.GetState(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetState: %v", err)
+	}
+	if got.Step != "description" || got.TempEvent.Title != "Субботник" {
+		t.Errorf("state = %+v", got)
+	}
+
+	drafts, err := states.ListDrafts(ctx, 3)
+	if err != nil {
+		t.Fatalf("ListDrafts: %v", err)
+	}
+	if len(drafts) != 1 || drafts[0].Name != "Субботник" || !drafts[0].Active {
+		t.Errorf("drafts = %+v", drafts)
+	}
+
+	if n := count(t, db, `SELECT COUNT(*) FROM sqlite_master WHERE name = 'user_states'`); n != 0 {
+		t.Error("user_states was not dropped")
+	}
+
+	if n := count(t, db, `SELECT COUNT(*) FROM registrations WHERE event_id = 7`); n != 0 {
+		t.Errorf("orphan registrations = %d, want 0", n)
+	}
+
+	// удаление события каскадом удаляет регистрации и анонсы
+	if err := NewChannelRepository(db).SavePost(ctx, domain.EventPost{EventID: 1, ChannelID: -100, MessageID: 5}); err != nil {
+		t.Fatalf("SavePost: %v", err)
+	}
+	if err := NewEventRepository(db).Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if n := count(t, db, `SELECT COUNT(*) FROM registrations`); n != 0 {
+		t.Errorf("registrations after delete = %d, want 0", n)
+	}
+	if n := count(t, db, `SELECT COUNT(*) FROM event_posts`); n != 0 {
+		t.Errorf("event_posts after delete = %d, want 0", n)
+	}
+}
+
+func count(t *testing.T, db *sql.DB, query string) int {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRow(query).Scan(&n); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+
+	return n
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationRepository) Enqueue(ctx context.Context, c domain.RegistrationChange) error {
+	const query = `
+		INSERT INTO registration_changes
+			(event_id, user_id, first_name, username, registered, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		c.EventID,
+		c.User.ID,
+		c.User.FirstName,
+		c.User.UserName,
+		c.Registered,
+		c.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue registration change: %w", err)
+	}
+
+	return nil
+}
+
+// Pending возвращает накопленные изменения в порядке поступления.
+func (r *NotificationRepository) Pending(ctx context.Context) ([]domain.RegistrationChange, error) {
+	const query = `
+		SELECT id, event_id, user_id, first_name, COALESCE(username, ''), registered, created_at
+		FROM registration_changes
+		ORDER BY id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registration changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.RegistrationChange
+	for rows.Next() {
+		var c domain.RegistrationChange
+		var createdAt time.Time
+
+		err := rows.Scan(
+			&c.ID,
+			&c.EventID,
+			&c.User.ID,
+			&c.User.FirstName,
+			&c.User.UserName,
+			&c.Registered,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan registration change: %w", err)
+		}
+
+		c.CreatedAt = createdAt
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+func (r *NotificationRepository) Delete(ctx context.Context, ids []int64) error {
+	return inTx(ctx, r.db, func(q executor) error {
+		for _, id := range ids {
+			if _, err := q.ExecContext(ctx, `DELETE FROM registration_changes WHERE id = ?`, id); err != nil {
+				return fmt.Errorf("failed to delete registration change: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
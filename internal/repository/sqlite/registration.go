@@ -20,20 +20,33 @@ func NewRegistrationRepository(db *sql.DB) *RegistrationRepository {
 	}
 }
 
-func (r *RegistrationRepository) Register(ctx context.Context, eventID int64, userID int64) error {
+func (r *RegistrationRepository) Register(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	ticket string,
+	status domain.RegistrationStatus,
+) error {
 	const query = `
-		INSERT INTO registrations(event_id, user_id, created_at)
-		VALUES(?, ?, ?)`
+		INSERT INTO registrations(event_id, user_id, ticket, status, created_at)
+		VALUES(?, ?, ?, ?, ?)
+		ON CONFLICT (event_id, user_id) DO NOTHING`
 
-	_, err := r.db.ExecContext(ctx, query,
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		eventID,
 		userID,
+		ticket,
+		status,
 		time.Now().UTC(),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to register: %w", err)
 	}
 
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("failed to register: %w", domain.ErrAlreadyRegistered)
+	}
+
 	return nil
 }
 
@@ -42,7 +55,7 @@ func (r *RegistrationRepository) Unregister(ctx context.Context, eventID int64,
 		DELETE FROM registrations
 		WHERE event_id = ? AND user_id = ?`
 
-	res, err := r.db.ExecContext(ctx, query, eventID, userID)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to unregister: %w", err)
 	}
@@ -54,14 +67,54 @@ func (r *RegistrationRepository) Unregister(ctx context.Context, eventID int64,
 
 	return nil
 }
+
+func (r *RegistrationRepository) Status(ctx context.Context, eventID int64, userID int64) (domain.RegistrationStatus, error) {
+	const query = `
+		SELECT status
+		FROM registrations
+		WHERE event_id = ? AND user_id = ?`
+
+	var status domain.RegistrationStatus
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, eventID, userID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.RegistrationNone, nil
+		}
+
+		return domain.RegistrationNone, fmt.Errorf("failed to get registration status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (r *RegistrationRepository) Approve(ctx context.Context, eventID int64, userID int64) error {
+	const query = `
+		UPDATE registrations
+		SET status = ?
+		WHERE event_id = ? AND user_id = ? AND status = ?`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, domain.RegistrationApproved, eventID, userID, domain.RegistrationPending)
+	if err != nil {
+		return fmt.Errorf("failed to approve registration: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domain.ErrRegistrationNotFound
+	}
+
+	return nil
+}
+
 func (r *RegistrationRepository) GetParticipants(ctx context.Context, eventID int64) ([]domain.Participant, error) {
 	const query = `
-		SELECT u.user_id, u.first_name, u.username, r.created_at
+		SELECT u.user_id, u.first_name, u.username, r.status, r.created_at, r.checked_in_at
 		FROM registrations r
 		JOIN users u ON r.user_id = u.user_id
-		WHERE r.event_id = ?`
+		WHERE r.event_id = ?
+		ORDER BY r.created_at`
 
-	rows, err := r.db.QueryContext(ctx, query, eventID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrParticipantNotFound
@@ -75,18 +128,22 @@ func (r *RegistrationRepository) GetParticipants(ctx context.Context, eventID in
 	for rows.Next() {
 		var p domain.Participant
 		var createdAt time.Time
+		var checkedInAt sql.NullTime
 
 		err := rows.Scan(
 			&p.ID,
 			&p.FirstName,
 			&p.UserName,
+			&p.Status,
 			&createdAt,
+			&checkedInAt,
 		)
 		if err != nil {
 			continue
 		}
 
 		p.RegisteredAt = createdAt
+		p.CheckedInAt = checkedInAt.Time
 		participants = append(participants, p)
 	}
 
@@ -99,14 +156,15 @@ func (r *RegistrationRepository) GetParticipantsPaginated(
 	offset int,
 	limit int) ([]domain.Participant, int, error) {
 	const query = `
-		SELECT u.user_id, u.first_name, u.username, r.created_at
+		SELECT u.user_id, u.first_name, u.username, r.status, r.created_at, r.checked_in_at
 		FROM registrations r
 		JOIN users u ON r.user_id = u.user_id
 		WHERE r.event_id = ?
+		ORDER BY r.created_at
 		LIMIT ?
 		OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, eventID, limit, offset)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID, limit, offset)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, 0, domain.ErrParticipantNotFound
@@ -120,23 +178,27 @@ func (r *RegistrationRepository) GetParticipantsPaginated(
 	for rows.Next() {
 		var p domain.Participant
 		var createdAt time.Time
+		var checkedInAt sql.NullTime
 
 		err := rows.Scan(
 			&p.ID,
 			&p.FirstName,
 			&p.UserName,
+			&p.Status,
 			&createdAt,
+			&checkedInAt,
 		)
 		if err != nil {
 			continue
 		}
 
 		p.RegisteredAt = createdAt
+		p.CheckedInAt = checkedInAt.Time
 		participants = append(participants, p)
 	}
 
 	var total int
-	err = r.db.QueryRowContext(ctx,
+	err = conn(ctx, r.db).QueryRowContext(ctx,
 		`SELECT COUNT(*) FROM registrations WHERE event_id = ?`,
 		eventID,
 	).Scan(&total)
@@ -153,7 +215,68 @@ func (r *RegistrationRepository) IsRegistered(ctx context.Context, eventID int64
 	)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, eventID, userID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, eventID, userID).Scan(&exists)
 
 	return exists, err
 }
+
+func (r *RegistrationRepository) GetTicket(ctx context.Context, eventID int64, userID int64) (*domain.Ticket, error) {
+	const query = `
+		SELECT event_id, user_id, ticket, status, checked_in_at
+		FROM registrations
+		WHERE event_id = ? AND user_id = ? AND ticket IS NOT NULL`
+
+	return scanTicket(conn(ctx, r.db).QueryRowContext(ctx, query, eventID, userID))
+}
+
+func (r *RegistrationRepository) GetTicketByToken(ctx context.Context, token string) (*domain.Ticket, error) {
+	const query = `
+		SELECT event_id, user_id, ticket, status, checked_in_at
+		FROM registrations
+		WHERE ticket = ?`
+
+	return scanTicket(conn(ctx, r.db).QueryRowContext(ctx, query, token))
+}
+
+// CheckIn отмечает приход по билету; повторная отметка не меняет время первой.
+func (r *RegistrationRepository) CheckIn(ctx context.Context, token string, at time.Time) error {
+	const query = `
+		UPDATE registrations
+		SET checked_in_at = ?
+		WHERE ticket = ? AND checked_in_at IS NULL`
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, at.UTC(), token)
+	if err != nil {
+		return fmt.Errorf("failed to check in: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		if _, err := r.GetTicketByToken(ctx, token); err != nil {
+			return err
+		}
+
+		return domain.ErrAlreadyCheckedIn
+	}
+
+	return nil
+}
+
+// scanTicket ...
+func scanTicket(row rowScanner) (*domain.Ticket, error) {
+	var t domain.Ticket
+	var checkedInAt sql.NullTime
+
+	err := row.Scan(&t.EventID, &t.UserID, &t.Token, &t.Status, &checkedInAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTicketNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	t.CheckedInAt = checkedInAt.Time
+
+	return &t, nil
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/binaryty/evbot/internal/repository"
 )
 
+// StateRepository хранит состояния диалогов: черновики мастера событий,
+// из которых один активный, и служебный диалог пользователя, который
+// перекрывает активный черновик, пока не завершится.
 type StateRepository struct {
 	db *sql.DB
 }
@@ -24,16 +27,24 @@ func NewStateRepository(db *sql.DB) *StateRepository {
 
 func (r *StateRepository) GetState(ctx context.Context, userID int64) (*domain.EventState, error) {
 	const query = `
-		SELECT state_data, created_at 
-		FROM user_states 
-		WHERE user_id = ?`
+		SELECT state_data, updated_at, 0 AS priority
+		FROM dialogs
+		WHERE user_id = ?
+		UNION ALL
+		SELECT d.state_data, d.updated_at, 1 AS priority
+		FROM active_drafts a
+		JOIN drafts d ON d.id = a.draft_id
+		WHERE a.user_id = ?
+		ORDER BY priority
+		LIMIT 1`
 
 	var (
 		stateData []byte
-		createdAt time.Time
+		updatedAt time.Time
+		priority  int
 	)
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&stateData, &createdAt)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, userID).Scan(&stateData, &updatedAt, &priority)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, repository.ErrStateNotFound
 	}
@@ -45,36 +56,333 @@ func (r *StateRepository) GetState(ctx context.Context, userID int64) (*domain.E
 	if err := json.Unmarshal(stateData, &state); err != nil {
 		return nil, fmt.Errorf("unmarshal error: %w", err)
 	}
+	state.CreatedAt = updatedAt
 
 	return &state, nil
 }
 
+// SaveState сохраняет служебный диалог или обновляет активный черновик,
+// а если его нет — создаёт новый.
 func (r *StateRepository) SaveState(ctx context.Context, userID int64, state domain.EventState) error {
-	const query = ` INSERT INTO user_states	(user_id, state_data, created_at)
-		VALUES(?, ?, ?)
-		ON CONFLICT(user_id) DO UPDATE SET
-			state_data = excluded.state_data,
-			created_at = excluded.created_at`
+	const (
+		dialogQuery = `
+			INSERT INTO dialogs (user_id, state_data, updated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT(user_id) DO UPDATE SET
+				state_data = excluded.state_data,
+				updated_at = excluded.updated_at`
+		draftQuery = `
+			UPDATE drafts
+			SET name = ?, state_data = ?, updated_at = ?
+			WHERE id = (SELECT draft_id FROM active_drafts WHERE user_id = ?)`
+	)
 
 	stateData, err := json.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
-	_, err = r.db.ExecContext(ctx, query,
-		userID,
+	if !state.IsDraft() {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, dialogQuery, userID, stateData, time.Now().UTC()); err != nil {
+			return fmt.Errorf("failed to save dialog: %w", err)
+		}
+
+		return nil
+	}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, draftQuery,
+		draftName(state),
 		stateData,
 		time.Now().UTC(),
+		userID,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows > 0 {
+		return nil
+	}
+
+	_, err = r.CreateDraft(ctx, userID, state)
 
 	return err
 }
 
+// CreateDraft сохраняет новый черновик и делает его активным;
+// прежний активный черновик остаётся в списке.
+func (r *StateRepository) CreateDraft(ctx context.Context, userID int64, state domain.EventState) (int64, error) {
+	const (
+		insertQuery = `
+			INSERT INTO drafts (user_id, name, state_data, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?)`
+		activateQuery = `
+			INSERT INTO active_drafts (user_id, draft_id)
+			VALUES (?, ?)
+			ON CONFLICT(user_id) DO UPDATE SET
+				draft_id = excluded.draft_id`
+	)
+
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	var draftID int64
+	err = inTx(ctx, r.db, func(q executor) error {
+		now := time.Now().UTC()
+
+		res, err := q.ExecContext(ctx, insertQuery,
+			userID,
+			draftName(state),
+			stateData,
+			now,
+			now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create draft: %w", err)
+		}
+
+		if draftID, err = res.LastInsertId(); err != nil {
+			return err
+		}
+
+		if _, err := q.ExecContext(ctx, activateQuery, userID, draftID); err != nil {
+			return fmt.Errorf("failed to activate draft: %w", err)
+		}
+
+		return deleteDialog(ctx, q, userID)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return draftID, nil
+}
+
+// DeleteState завершает служебный диалог, а если его нет — удаляет активный черновик.
 func (r *StateRepository) DeleteState(ctx context.Context, userID int64) error {
+	const (
+		deleteDialogQuery = `
+			DELETE FROM dialogs
+			WHERE user_id = ?`
+		deleteDraftQuery = `
+			DELETE FROM drafts
+			WHERE id = (SELECT draft_id FROM active_drafts WHERE user_id = ?)`
+		deletePointerQuery = `
+			DELETE FROM active_drafts
+			WHERE user_id = ?`
+	)
+
+	return inTx(ctx, r.db, func(q executor) error {
+		res, err := q.ExecContext(ctx, deleteDialogQuery, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete dialog: %w", err)
+		}
+
+		// черновик под завершённым диалогом снова становится текущим
+		if rows, _ := res.RowsAffected(); rows > 0 {
+			return nil
+		}
+
+		if _, err := q.ExecContext(ctx, deleteDraftQuery, userID); err != nil {
+			return fmt.Errorf("failed to delete draft: %w", err)
+		}
+
+		if _, err := q.ExecContext(ctx, deletePointerQuery, userID); err != nil {
+			return fmt.Errorf("failed to delete active draft: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteExpired удаляет черновики и служебные диалоги, не обновлявшиеся с момента before.
+func (r *StateRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	const (
+		deleteDraftsQuery = `
+			DELETE FROM drafts
+			WHERE updated_at < ?`
+		deleteDialogsQuery = `
+			DELETE FROM dialogs
+			WHERE updated_at < ?`
+		deletePointersQuery = `
+			DELETE FROM active_drafts
+			WHERE draft_id NOT IN (SELECT id FROM drafts)`
+	)
+
+	var n int64
+	err := inTx(ctx, r.db, func(q executor) error {
+		for _, query := range []string{deleteDraftsQuery, deleteDialogsQuery} {
+			res, err := q.ExecContext(ctx, query, before.UTC())
+			if err != nil {
+				return fmt.Errorf("failed to delete expired states: %w", err)
+			}
+
+			rows, _ := res.RowsAffected()
+			n += rows
+		}
+
+		if _, err := q.ExecContext(ctx, deletePointersQuery); err != nil {
+			return fmt.Errorf("failed to delete dangling active drafts: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (r *StateRepository) ListDrafts(ctx context.Context, userID int64) ([]domain.Draft, error) {
 	const query = `
-		DELETE FROM user_states 
-		WHERE user_id = ?`
-	_, err := r.db.ExecContext(ctx, query, userID)
+		SELECT d.id, d.user_id, d.name, d.state_data, d.updated_at, a.draft_id IS NOT NULL
+		FROM drafts d
+		LEFT JOIN active_drafts a ON a.draft_id = d.id
+		WHERE d.user_id = ?
+		ORDER BY d.updated_at DESC, d.id DESC`
 
-	return err
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query drafts: %w", err)
+	}
+	defer rows.Close()
+
+	var drafts []domain.Draft
+	for rows.Next() {
+		var (
+			d         domain.Draft
+			stateData []byte
+		)
+
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Name, &stateData, &d.UpdatedAt, &d.Active); err != nil {
+			return nil, fmt.Errorf("failed to scan draft: %w", err)
+		}
+
+		if err := json.Unmarshal(stateData, &d.State); err != nil {
+			return nil, fmt.Errorf("unmarshal error: %w", err)
+		}
+		d.State.CreatedAt = d.UpdatedAt
+
+		drafts = append(drafts, d)
+	}
+
+	return drafts, rows.Err()
+}
+
+// ActivateDraft делает черновик пользователя активным и прерывает служебный диалог.
+func (r *StateRepository) ActivateDraft(ctx context.Context, userID int64, draftID int64) error {
+	const query = `
+		INSERT INTO active_drafts (user_id, draft_id)
+		SELECT user_id, id FROM drafts WHERE id = ? AND user_id = ?
+		ON CONFLICT(user_id) DO UPDATE SET
+			draft_id = excluded.draft_id`
+
+	return inTx(ctx, r.db, func(q executor) error {
+		res, err := q.ExecContext(ctx, query, draftID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to activate draft: %w", err)
+		}
+
+		if rows, _ := res.RowsAffected(); rows == 0 {
+			return repository.ErrStateNotFound
+		}
+
+		return deleteDialog(ctx, q, userID)
+	})
+}
+
+// DeleteDraft удаляет черновик пользователя, в том числе активный.
+func (r *StateRepository) DeleteDraft(ctx context.Context, userID int64, draftID int64) error {
+	const (
+		deleteDraftQuery = `
+			DELETE FROM drafts
+			WHERE id = ? AND user_id = ?`
+		deletePointerQuery = `
+			DELETE FROM active_drafts
+			WHERE user_id = ? AND draft_id = ?`
+	)
+
+	return inTx(ctx, r.db, func(q executor) error {
+		res, err := q.ExecContext(ctx, deleteDraftQuery, draftID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete draft: %w", err)
+		}
+
+		if rows, _ := res.RowsAffected(); rows == 0 {
+			return repository.ErrStateNotFound
+		}
+
+		if _, err := q.ExecContext(ctx, deletePointerQuery, userID, draftID); err != nil {
+			return fmt.Errorf("failed to delete active draft: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RenameDraft задаёт имя черновика пользователя; имя хранится и в самом
+// состоянии, чтобы SaveState его не перезаписал.
+func (r *StateRepository) RenameDraft(ctx context.Context, userID int64, draftID int64, name string) error {
+	const (
+		selectQuery = `
+			SELECT state_data
+			FROM drafts
+			WHERE id = ? AND user_id = ?`
+		updateQuery = `
+			UPDATE drafts
+			SET name = ?, state_data = ?, updated_at = ?
+			WHERE id = ?`
+	)
+
+	return inTx(ctx, r.db, func(q executor) error {
+		var stateData []byte
+		err := q.QueryRowContext(ctx, selectQuery, draftID, userID).Scan(&stateData)
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrStateNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get draft: %w", err)
+		}
+
+		var state domain.EventState
+		if err := json.Unmarshal(stateData, &state); err != nil {
+			return fmt.Errorf("unmarshal error: %w", err)
+		}
+		state.DraftName = name
+
+		if stateData, err = json.Marshal(state); err != nil {
+			return fmt.Errorf("failed to marshal state: %w", err)
+		}
+
+		if _, err := q.ExecContext(ctx, updateQuery, name, stateData, time.Now().UTC(), draftID); err != nil {
+			return fmt.Errorf("failed to rename draft: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// draftName ...
+func draftName(state domain.EventState) string {
+	if state.DraftName != "" {
+		return state.DraftName
+	}
+
+	if state.TempEvent.Title != "" {
+		return state.TempEvent.Title
+	}
+
+	return "Без названия"
+}
+
+// deleteDialog прерывает служебный диалог пользователя.
+func deleteDialog(ctx context.Context, q executor, userID int64) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM dialogs WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to delete dialog: %w", err)
+	}
+
+	return nil
 }
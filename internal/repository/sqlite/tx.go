@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// txKey ключ транзакции в контексте.
+type txKey struct{}
+
+// executor общее у *sql.DB и *sql.Tx.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor открывает транзакции, общие для всех репозиториев этой базы.
+type Transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{
+		db: db,
+	}
+}
+
+// WithinTx выполняет fn в транзакции и фиксирует её, если fn не вернула ошибку.
+// Вложенный вызов присоединяется к внешней транзакции.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// conn транзакция из контекста, а вне транзакции — пул соединений.
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// inTx выполняет несколько запросов атомарно: в транзакции из контекста,
+// а если её нет — в собственной.
+func inTx(ctx context.Context, db *sql.DB, fn func(q executor) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DataSource строка подключения к файлу базы. Внешние ключи SQLite проверяет
+// (и каскадно удаляет связанные записи), только если включить их для каждого
+// соединения. Транзакции сразу берут блокировку на запись (BEGIN IMMEDIATE):
+// иначе две одновременные регистрации упираются в SQLITE_BUSY при повышении
+// блокировки.
+func DataSource(path string) string {
+	const params = "_foreign_keys=on&_txlock=immediate"
+
+	if strings.Contains(path, "?") {
+		return path + "&" + params
+	}
+
+	return path + "?" + params
+}
@@ -28,7 +28,7 @@ func (r *UserRepository) CreateOrUpdate(ctx context.Context, user *domain.User)
 			username = excluded.username,
 			updated_at = CURRENT_TIMESTAMP`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		user.ID,
 		user.FirstName,
 		user.UserName,
@@ -47,7 +47,7 @@ func (r *UserRepository) GetByID(ctx context.Context, userID int64) (*domain.Use
 		WHERE user_id = ?`
 
 	var user domain.User
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
 		&user.ID,
 		&user.FirstName,
 		&user.UserName,
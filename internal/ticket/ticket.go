@@ -0,0 +1,48 @@
+// Package ticket выпускает коды билетов и находит их в тексте:
+// 16 символов base32, для удобства чтения показываются группами по четыре.
+package ticket
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tokenBytes 80 случайных бит: подобрать чужой билет перебором нереально.
+const tokenBytes = 10
+
+var tokenRe = regexp.MustCompile(`\b([A-Z2-7]{4})-?([A-Z2-7]{4})-?([A-Z2-7]{4})-?([A-Z2-7]{4})\b`)
+
+// NewToken новый случайный код билета.
+func NewToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate ticket token: %w", err)
+	}
+
+	return base32.StdEncoding.EncodeToString(b), nil
+}
+
+// Format код для показа человеку: ABCD-EFGH-IJKL-MNOP.
+func Format(token string) string {
+	var parts []string
+	for len(token) > 4 {
+		parts = append(parts, token[:4])
+		token = token[4:]
+	}
+
+	return strings.Join(append(parts, token), "-")
+}
+
+// Find ищет код билета в произвольном тексте: подписи к фото, пересланном
+// сообщении или введённом вручную коде, с дефисами или без, в любом регистре.
+func Find(text string) (string, bool) {
+	m := tokenRe.FindStringSubmatch(strings.ToUpper(text))
+	if m == nil {
+		return "", false
+	}
+
+	return m[1] + m[2] + m[3] + m[4], true
+}
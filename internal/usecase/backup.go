@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+// backupVersion версия формата резервной копии; меняется при несовместимых
+// изменениях, копии других версий не восстанавливаются.
+const backupVersion = 1
+
+type BackupUseCase struct {
+	repo repository.BackupRepository
+}
+
+func NewBackupUseCase(repo repository.BackupRepository) *BackupUseCase {
+	return &BackupUseCase{
+		repo: repo,
+	}
+}
+
+// Backup записывает резервную копию в JSON.
+func (uc *BackupUseCase) Backup(ctx context.Context, w io.Writer) error {
+	snapshot, err := uc.repo.Dump(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot.Version = backupVersion
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+
+	if err := enc.Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to encode backup: %w", err)
+	}
+
+	return nil
+}
+
+// Restore восстанавливает данные из JSON-копии и возвращает количество строк.
+func (uc *BackupUseCase) Restore(ctx context.Context, r io.Reader) (int, error) {
+	var snapshot domain.Snapshot
+
+	dec := json.NewDecoder(r)
+	// ID чатов и пользователей не должны терять точность во float64
+	dec.UseNumber()
+
+	if err := dec.Decode(&snapshot); err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrUnsupportedBackup, err)
+	}
+
+	if snapshot.Version != backupVersion {
+		return 0, fmt.Errorf("%w: version %d", domain.ErrUnsupportedBackup, snapshot.Version)
+	}
+
+	count := 0
+	for _, table := range snapshot.Tables {
+		for _, row := range table.Rows {
+			for i, v := range row {
+				if n, ok := v.(json.Number); ok {
+					row[i] = number(n)
+				}
+			}
+			count++
+		}
+	}
+
+	if err := uc.repo.Restore(ctx, &snapshot); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// number целое как int64, иначе float64.
+func number(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+
+	f, _ := n.Float64()
+
+	return f
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+type ChannelUseCase struct {
+	repo           repository.ChannelRepository
+	defaultChannel int64
+}
+
+// NewChannelUseCase defaultChannel используется для общего пространства,
+// если канал не привязан командой.
+func NewChannelUseCase(repo repository.ChannelRepository, defaultChannel int64) *ChannelUseCase {
+	return &ChannelUseCase{
+		repo:           repo,
+		defaultChannel: defaultChannel,
+	}
+}
+
+func (uc *ChannelUseCase) Bind(ctx context.Context, chatID int64, channelID int64) error {
+	return uc.repo.SetChannel(ctx, chatID, channelID)
+}
+
+// Unbind отключает публикацию анонсов. Отключение хранится явно (канал 0):
+// если просто удалить привязку, общее пространство вернётся к каналу
+// из конфигурации.
+func (uc *ChannelUseCase) Unbind(ctx context.Context, chatID int64) error {
+	return uc.repo.SetChannel(ctx, chatID, 0)
+}
+
+// ChannelFor возвращает канал для публикации событий пространства chatID
+// или 0, если публиковать некуда.
+func (uc *ChannelUseCase) ChannelFor(ctx context.Context, chatID int64) (int64, error) {
+	channelID, err := uc.repo.GetChannel(ctx, chatID)
+	if err == nil {
+		return channelID, nil
+	}
+
+	if !errors.Is(err, domain.ErrChannelNotFound) {
+		return 0, err
+	}
+
+	if chatID == domain.GlobalSpace {
+		return uc.defaultChannel, nil
+	}
+
+	return 0, nil
+}
+
+func (uc *ChannelUseCase) AddPost(ctx context.Context, post domain.EventPost) error {
+	return uc.repo.SavePost(ctx, post)
+}
+
+func (uc *ChannelUseCase) Posts(ctx context.Context, eventID int64) ([]domain.EventPost, error) {
+	return uc.repo.GetPosts(ctx, eventID)
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository/memory"
+)
+
+func TestChannelFor(t *testing.T) {
+	const (
+		defaultChannel int64 = -1001
+		boundChannel   int64 = -1002
+		groupChat      int64 = -42
+	)
+
+	ctx := context.Background()
+	uc := NewChannelUseCase(memory.NewChannelRepository(memory.NewStore()), defaultChannel)
+
+	check := func(step string, chatID int64, want int64) {
+		t.Helper()
+
+		got, err := uc.ChannelFor(ctx, chatID)
+		if err != nil {
+			t.Fatalf("%s: ChannelFor(%d): %v", step, chatID, err)
+		}
+		if got != want {
+			t.Errorf("%s: ChannelFor(%d) = %d, want %d", step, chatID, got, want)
+		}
+	}
+
+	check("not bound", domain.GlobalSpace, defaultChannel)
+	check("not bound", groupChat, 0)
+
+	if err := uc.Bind(ctx, domain.GlobalSpace, boundChannel); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if err := uc.Bind(ctx, groupChat, boundChannel); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	check("bound", domain.GlobalSpace, boundChannel)
+	check("bound", groupChat, boundChannel)
+
+	// отвязка отключает и канал из конфигурации
+	if err := uc.Unbind(ctx, domain.GlobalSpace); err != nil {
+		t.Fatalf("Unbind: %v", err)
+	}
+	if err := uc.Unbind(ctx, groupChat); err != nil {
+		t.Fatalf("Unbind: %v", err)
+	}
+	check("unbound", domain.GlobalSpace, 0)
+	check("unbound", groupChat, 0)
+}
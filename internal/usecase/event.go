@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	domain "github.com/binaryty/evbot/internal/domain/entities"
@@ -9,15 +10,24 @@ import (
 )
 
 type EventUseCase struct {
-	repo repository.EventRepository
+	repo         repository.EventRepository
+	questionRepo repository.QuestionRepository
+	tx           repository.Transactor
 }
 
-func NewEventUseCase(repo repository.EventRepository) *EventUseCase {
+func NewEventUseCase(
+	repo repository.EventRepository,
+	questionRepo repository.QuestionRepository,
+	tx repository.Transactor,
+) *EventUseCase {
 	return &EventUseCase{
-		repo: repo,
+		repo:         repo,
+		questionRepo: questionRepo,
+		tx:           tx,
 	}
 }
 
+// CreateEvent сохраняет событие вместе с вопросами участникам одной транзакцией.
 func (uc *EventUseCase) CreateEvent(ctx context.Context, userID int64, event domain.Event) (int64, error) {
 	if event.Title == "" {
 		return 0, domain.ErrInvalidEventTitle
@@ -26,17 +36,135 @@ func (uc *EventUseCase) CreateEvent(ctx context.Context, userID int64, event dom
 	event.UserID = userID
 	event.CreatedAt = time.Now().UTC()
 
-	return uc.repo.Save(ctx, event)
+	var id int64
+
+	// событие без своих вопросов не сохраняется: регистрация прошла бы без них
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		id, err = uc.repo.Save(ctx, event)
+		if err != nil {
+			return err
+		}
+
+		if len(event.Questions) > 0 {
+			return uc.questionRepo.SaveQuestions(ctx, id, event.Questions)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// ImportEvents создаёт события одной транзакцией: если хотя бы одно
+// не сохранилось, не создаётся ни одно.
+func (uc *EventUseCase) ImportEvents(ctx context.Context, userID int64, events []domain.Event) ([]int64, error) {
+	ids := make([]int64, 0, len(events))
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, event := range events {
+			id, err := uc.CreateEvent(ctx, userID, event)
+			if err != nil {
+				return err
+			}
+
+			ids = append(ids, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Duplicates отмечает события, которые уже есть в пространстве chatID или
+// встречаются в списке раньше: совпадают название (без учёта регистра) и дата.
+func (uc *EventUseCase) Duplicates(ctx context.Context, chatID int64, events []domain.Event) ([]bool, error) {
+	existing, err := uc.repo.GetByChatID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(existing)+len(events))
+	for _, e := range existing {
+		seen[duplicateKey(e)] = true
+	}
+
+	duplicates := make([]bool, len(events))
+	for i, e := range events {
+		key := duplicateKey(e)
+		duplicates[i] = seen[key]
+		seen[key] = true
+	}
+
+	return duplicates, nil
+}
+
+// duplicateKey ...
+func duplicateKey(e domain.Event) string {
+	return strings.ToLower(strings.TrimSpace(e.Title)) + "\x00" + e.Date.UTC().Format(time.RFC3339)
+}
+
+// Questions вопросы, которые задаются участникам при регистрации.
+func (uc *EventUseCase) Questions(ctx context.Context, eventID int64) ([]domain.Question, error) {
+	return uc.questionRepo.GetQuestions(ctx, eventID)
 }
 
 func (uc *EventUseCase) ListUserEvents(ctx context.Context, userID int64) ([]domain.Event, error) {
 	return uc.repo.GetByUserID(ctx, userID)
 }
 
-func (uc *EventUseCase) ListEvents(ctx context.Context) ([]domain.Event, error) {
-	return uc.repo.GetAll(ctx)
+func (uc *EventUseCase) GetEvent(ctx context.Context, eventID int64) (*domain.Event, error) {
+	return uc.repo.GetByID(ctx, eventID)
+}
+
+// ListEvents возвращает события пространства чата (см. domain.GlobalSpace).
+func (uc *EventUseCase) ListEvents(ctx context.Context, chatID int64) ([]domain.Event, error) {
+	return uc.repo.GetByChatID(ctx, chatID)
 }
 
 func (uc *EventUseCase) DeleteEvent(ctx context.Context, eventID int64) error {
 	return uc.repo.Delete(ctx, eventID)
 }
+
+// SetApproval включает или выключает подтверждение заявок автором;
+// доступно только автору события.
+func (uc *EventUseCase) SetApproval(ctx context.Context, userID int64, eventID int64, required bool) error {
+	event, err := uc.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.UserID != userID {
+		return domain.ErrAccessDenied
+	}
+
+	return uc.repo.SetApproval(ctx, eventID, required)
+}
+
+// SetNotifyMode меняет режим уведомлений о регистрациях; доступно только автору события.
+func (uc *EventUseCase) SetNotifyMode(ctx context.Context, userID int64, eventID int64, mode domain.NotifyMode) error {
+	switch mode {
+	case domain.NotifyOff, domain.NotifyInstant, domain.NotifyDigest:
+	default:
+		return domain.ErrInvalidNotifyMode
+	}
+
+	event, err := uc.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.UserID != userID {
+		return domain.ErrAccessDenied
+	}
+
+	return uc.repo.SetNotifyMode(ctx, eventID, mode)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/repository/memory"
+	"github.com/binaryty/evbot/internal/repository/repotest"
+)
+
+func TestDuplicates(t *testing.T) {
+	const (
+		groupChat int64 = -42
+		otherChat int64 = -43
+	)
+
+	ctx := context.Background()
+	store := memory.NewStore()
+	uc := NewEventUseCase(memory.NewEventRepository(store), memory.NewQuestionRepository(store), memory.NewTransactor(store))
+
+	evening := time.Date(2026, time.November, 20, 19, 0, 0, 0, time.UTC)
+	for _, e := range []domain.Event{
+		{ChatID: groupChat, Title: "Митап", Date: evening},
+		{ChatID: otherChat, Title: "Лекция", Date: evening},
+	} {
+		if _, err := uc.CreateEvent(ctx, 1, e); err != nil {
+			t.Fatalf("CreateEvent: %v", err)
+		}
+	}
+
+	imported := []domain.Event{
+		{Title: "  митап ", Date: evening},             // уже есть: регистр и пробелы не важны
+		{Title: "Митап", Date: evening.Add(time.Hour)}, // другое время
+		{Title: "Лекция", Date: evening},               // есть, но в другом чате
+		{Title: "Лекция", Date: evening},               // повтор внутри файла
+		{Title: "Воркшоп", Date: evening},
+	}
+
+	got, err := uc.Duplicates(ctx, groupChat, imported)
+	if err != nil {
+		t.Fatalf("Duplicates: %v", err)
+	}
+
+	want := []bool{true, false, false, true, false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Duplicates = %v, want %v", got, want)
+	}
+}
+
+// failingQuestions репозиторий вопросов, который не может их сохранить.
+type failingQuestions struct {
+	repository.QuestionRepository
+}
+
+func (failingQuestions) SaveQuestions(context.Context, int64, []domain.Question) error {
+	return errDiskFull
+}
+
+func TestCreateEventRollback(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+		ctx := context.Background()
+		uc := NewEventUseCase(s.Events, failingQuestions{s.Questions}, s.Transactor)
+
+		event := domain.Event{
+			Title:     "Митап",
+			Date:      evening,
+			Questions: []domain.Question{{Kind: domain.QuestionText, Text: "Откуда вы?"}},
+		}
+		if _, err := uc.CreateEvent(ctx, author, event); !errors.Is(err, errDiskFull) {
+			t.Fatalf("CreateEvent error = %v, want %v", err, errDiskFull)
+		}
+
+		// событие без вопросов не осталось
+		if events, err := s.Events.GetByUserID(ctx, author); err != nil || len(events) != 0 {
+			t.Errorf("events after failed CreateEvent = %+v, %v; want none", events, err)
+		}
+	})
+}
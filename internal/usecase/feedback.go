@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+type FeedbackUseCase struct {
+	repo             repository.FeedbackRepository
+	registrationRepo repository.RegistrationRepository
+}
+
+func NewFeedbackUseCase(repo repository.FeedbackRepository, registrationRepo repository.RegistrationRepository) *FeedbackUseCase {
+	return &FeedbackUseCase{
+		repo:             repo,
+		registrationRepo: registrationRepo,
+	}
+}
+
+// DueSurveys события, начавшиеся позже after и не позже before, участники
+// которых ещё не получили опрос.
+func (uc *FeedbackUseCase) DueSurveys(ctx context.Context, after, before time.Time) ([]domain.Event, error) {
+	return uc.repo.EventsAwaitingSurvey(ctx, after, before)
+}
+
+func (uc *FeedbackUseCase) MarkSurveySent(ctx context.Context, eventID int64) error {
+	return uc.repo.MarkSurveySent(ctx, eventID, time.Now().UTC())
+}
+
+// Rate сохраняет оценку участника события. Оценивают только подтверждённые
+// участники: заявка, которую автор не принял, — domain.ErrRegistrationPending.
+func (uc *FeedbackUseCase) Rate(ctx context.Context, eventID int64, user domain.User, rating int) error {
+	if rating < domain.MinRating || rating > domain.MaxRating {
+		return domain.ErrInvalidRating
+	}
+
+	status, err := uc.registrationRepo.Status(ctx, eventID, user.ID)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case domain.RegistrationApproved:
+	case domain.RegistrationPending:
+		return domain.ErrRegistrationPending
+	default:
+		return domain.ErrRegistrationNotFound
+	}
+
+	return uc.repo.SaveRating(ctx, domain.Feedback{
+		EventID:   eventID,
+		User:      user,
+		Rating:    rating,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// Comment добавляет комментарий к уже поставленной оценке.
+func (uc *FeedbackUseCase) Comment(ctx context.Context, eventID int64, userID int64, comment string) error {
+	return uc.repo.SaveComment(ctx, eventID, userID, comment)
+}
+
+// Summary сводка отзывов о событии.
+func (uc *FeedbackUseCase) Summary(ctx context.Context, eventID int64) (*domain.FeedbackSummary, error) {
+	feedback, err := uc.repo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary domain.FeedbackSummary
+	total := 0
+	for _, f := range feedback {
+		summary.Count++
+		summary.Distribution[f.Rating-1]++
+		total += f.Rating
+
+		if f.Comment != "" {
+			summary.Comments = append(summary.Comments, f)
+		}
+	}
+
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository/memory"
+)
+
+func TestRate(t *testing.T) {
+	const (
+		eventID  int64 = 1
+		approved int64 = 10
+		pending  int64 = 11
+		stranger int64 = 12
+	)
+
+	ctx := context.Background()
+	store := memory.NewStore()
+	registrations := memory.NewRegistrationRepository(store)
+	uc := NewFeedbackUseCase(memory.NewFeedbackRepository(store), registrations)
+
+	if err := registrations.Register(ctx, eventID, approved, "AAAA", domain.RegistrationApproved); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := registrations.Register(ctx, eventID, pending, "BBBB", domain.RegistrationPending); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  int64
+		rating  int
+		wantErr error
+	}{
+		{name: "approved participant", userID: approved, rating: 5},
+		{name: "pending request", userID: pending, rating: 4, wantErr: domain.ErrRegistrationPending},
+		{name: "not registered", userID: stranger, rating: 4, wantErr: domain.ErrRegistrationNotFound},
+		{name: "rating too low", userID: approved, rating: domain.MinRating - 1, wantErr: domain.ErrInvalidRating},
+		{name: "rating too high", userID: approved, rating: domain.MaxRating + 1, wantErr: domain.ErrInvalidRating},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.Rate(ctx, eventID, domain.User{ID: tt.userID}, tt.rating)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Rate(user %d, %d) error = %v, want %v", tt.userID, tt.rating, err, tt.wantErr)
+			}
+		})
+	}
+
+	summary, err := uc.Summary(ctx, eventID)
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+	if summary.Count != 1 || summary.Average != 5 {
+		t.Errorf("Summary = %d ratings, average %.1f; want 1 rating, average 5", summary.Count, summary.Average)
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+)
+
+// Notifier доставляет авторам событий уведомления о регистрациях,
+// а участникам — об отмене событий.
+type Notifier interface {
+	// NotifyRegistration сообщает об одной регистрации или отмене.
+	NotifyRegistration(ctx context.Context, event domain.Event, change domain.RegistrationChange) error
+	// NotifyDigest отправляет сводку накопленных изменений по событию.
+	NotifyDigest(ctx context.Context, event domain.Event, changes []domain.RegistrationChange) error
+	// NotifyCancellation сообщает записавшимся на событие, что оно отменено.
+	NotifyCancellation(ctx context.Context, event domain.Event, participants []domain.Participant) error
+}
@@ -2,58 +2,448 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	domain "github.com/binaryty/evbot/internal/domain/entities"
 	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/ticket"
 )
 
 type RegistrationUseCase struct {
 	eventRepo        repository.EventRepository
 	registrationRepo repository.RegistrationRepository
+	questionRepo     repository.QuestionRepository
+	notificationRepo repository.NotificationRepository
+	notifier         Notifier
+	tx               repository.Transactor
 }
 
 func NewRegistrationUseCase(
 	eventRepo repository.EventRepository,
 	registrationRepo repository.RegistrationRepository,
+	questionRepo repository.QuestionRepository,
+	notificationRepo repository.NotificationRepository,
+	notifier Notifier,
+	tx repository.Transactor,
 ) *RegistrationUseCase {
 	return &RegistrationUseCase{
 		eventRepo:        eventRepo,
 		registrationRepo: registrationRepo,
+		questionRepo:     questionRepo,
+		notificationRepo: notificationRepo,
+		notifier:         notifier,
+		tx:               tx,
 	}
 }
 
+// ToggleRegistration регистрирует пользователя или отменяет регистрацию
+// (заявку) и возвращает новое состояние. Если у события есть вопросы,
+// регистрация не выполняется: возвращается domain.ErrAnswersRequired,
+// ответы собираются и передаются в Register.
+// Проверка и изменение выполняются в одной транзакции: повторное нажатие,
+// пришедшее одновременно с первым, не приводит к ошибке.
 func (uc *RegistrationUseCase) ToggleRegistration(
 	ctx context.Context,
 	eventID int64,
 	user *domain.User,
-) (bool, error) {
-	_, err := uc.eventRepo.GetByID(ctx, eventID)
+) (domain.RegistrationStatus, error) {
+	var (
+		event          *domain.Event
+		previous, next domain.RegistrationStatus
+	)
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		event, err = uc.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return domain.ErrEventNotFound
+		}
+
+		previous, err = uc.registrationRepo.Status(ctx, eventID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if previous != domain.RegistrationNone {
+			next = domain.RegistrationNone
+			return uc.unregister(ctx, eventID, user.ID)
+		}
+
+		questions, err := uc.questionRepo.GetQuestions(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		if len(questions) > 0 {
+			return domain.ErrAnswersRequired
+		}
+
+		next, err = uc.register(ctx, event, user, nil)
+		return err
+	})
+	if errors.Is(err, domain.ErrAlreadyRegistered) || errors.Is(err, domain.ErrRegistrationNotFound) {
+		// параллельное нажатие успело изменить регистрацию первым
+		return uc.registrationRepo.Status(ctx, eventID, user.ID)
+	}
+	if err != nil {
+		return domain.RegistrationNone, err
+	}
+
+	// изменение уже сохранено: сбой уведомления не должен выглядеть как его ошибка;
+	// о заявках автору не сообщаем — участником пользователь ещё не стал
+	switch {
+	case next == domain.RegistrationApproved:
+		_ = uc.notify(ctx, event, user, true)
+	case previous == domain.RegistrationApproved:
+		_ = uc.notify(ctx, event, user, false)
+	}
+
+	return next, nil
+}
+
+// Register регистрирует пользователя с ответами на вопросы события
+// и возвращает состояние регистрации.
+func (uc *RegistrationUseCase) Register(
+	ctx context.Context,
+	eventID int64,
+	user *domain.User,
+	answers []domain.Answer,
+) (domain.RegistrationStatus, error) {
+	var (
+		event  *domain.Event
+		status domain.RegistrationStatus
+	)
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		event, err = uc.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return domain.ErrEventNotFound
+		}
+
+		current, err := uc.registrationRepo.Status(ctx, eventID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if current != domain.RegistrationNone {
+			return domain.ErrAlreadyRegistered
+		}
+
+		questions, err := uc.questionRepo.GetQuestions(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		if err := validateAnswers(questions, answers); err != nil {
+			return err
+		}
+
+		status, err = uc.register(ctx, event, user, answers)
+		return err
+	})
+	if err != nil {
+		return domain.RegistrationNone, err
+	}
+
+	if status == domain.RegistrationApproved {
+		_ = uc.notify(ctx, event, user, true)
+	}
+
+	return status, nil
+}
+
+// register выпускает билет и сохраняет регистрацию с ответами; если событие
+// требует подтверждения, регистрация остаётся заявкой. Вызывается внутри
+// транзакции: автора уведомляет вызывающий код после её фиксации.
+func (uc *RegistrationUseCase) register(
+	ctx context.Context,
+	event *domain.Event,
+	user *domain.User,
+	answers []domain.Answer,
+) (domain.RegistrationStatus, error) {
+	token, err := ticket.NewToken()
+	if err != nil {
+		return domain.RegistrationNone, err
+	}
+
+	status := domain.RegistrationApproved
+	if event.RequiresApproval {
+		status = domain.RegistrationPending
+	}
+
+	if err := uc.registrationRepo.Register(ctx, event.ID, user.ID, token, status); err != nil {
+		return domain.RegistrationNone, err
+	}
+
+	if len(answers) > 0 {
+		if err := uc.questionRepo.SaveAnswers(ctx, event.ID, user.ID, answers); err != nil {
+			return domain.RegistrationNone, err
+		}
+	}
+
+	return status, nil
+}
+
+// unregister удаляет регистрацию вместе с ответами; вызывается внутри транзакции.
+func (uc *RegistrationUseCase) unregister(ctx context.Context, eventID int64, userID int64) error {
+	if err := uc.registrationRepo.Unregister(ctx, eventID, userID); err != nil {
+		return err
+	}
+
+	return uc.questionRepo.DeleteAnswers(ctx, eventID, userID)
+}
+
+// Approve подтверждает заявку на участие; доступно только автору события.
+func (uc *RegistrationUseCase) Approve(ctx context.Context, authorID int64, eventID int64, userID int64) (*domain.Event, error) {
+	event, err := uc.authorEvent(ctx, authorID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.registrationRepo.Approve(ctx, eventID, userID); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Reject отклоняет заявку на участие; доступно только автору события.
+// Подтверждённую регистрацию отклонить нельзя: возвращается domain.ErrRegistrationNotFound.
+func (uc *RegistrationUseCase) Reject(ctx context.Context, authorID int64, eventID int64, userID int64) (*domain.Event, error) {
+	event, err := uc.authorEvent(ctx, authorID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		status, err := uc.registrationRepo.Status(ctx, eventID, userID)
+		if err != nil {
+			return err
+		}
+
+		if status != domain.RegistrationPending {
+			return domain.ErrRegistrationNotFound
+		}
+
+		return uc.unregister(ctx, eventID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// CancelEvent удаляет событие и сообщает об отмене всем, кто был на него записан.
+// Список адресатов читается в одной транзакции с удалением: записавшийся
+// в последний момент тоже получит сообщение.
+func (uc *RegistrationUseCase) CancelEvent(ctx context.Context, eventID int64) error {
+	var (
+		event        *domain.Event
+		participants []domain.Participant
+	)
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		event, err = uc.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		participants, err = uc.registrationRepo.GetParticipants(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		return uc.eventRepo.Delete(ctx, eventID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// событие уже удалено: недоставленные сообщения не отменяют удаление
+	_ = uc.notifier.NotifyCancellation(ctx, *event, participants)
+
+	return nil
+}
+
+// authorEvent ...
+func (uc *RegistrationUseCase) authorEvent(ctx context.Context, authorID int64, eventID int64) (*domain.Event, error) {
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
-		return false, domain.ErrEventNotFound
+		return nil, err
+	}
+
+	if event.UserID != authorID {
+		return nil, domain.ErrAccessDenied
+	}
+
+	return event, nil
+}
+
+// Questions вопросы события в порядке показа.
+func (uc *RegistrationUseCase) Questions(ctx context.Context, eventID int64) ([]domain.Question, error) {
+	return uc.questionRepo.GetQuestions(ctx, eventID)
+}
+
+// validateAnswers на каждый вопрос по порядку дан непустой ответ,
+// а для вопросов с выбором — только из предложенных вариантов.
+func validateAnswers(questions []domain.Question, answers []domain.Answer) error {
+	if len(answers) != len(questions) {
+		return domain.ErrInvalidAnswer
+	}
+
+	for i, q := range questions {
+		a := answers[i]
+		if a.QuestionID != q.ID || strings.TrimSpace(a.Value) == "" {
+			return domain.ErrInvalidAnswer
+		}
+
+		var values []string
+		switch q.Kind {
+		case domain.QuestionSingle:
+			values = []string{a.Value}
+		case domain.QuestionMulti:
+			values = strings.Split(a.Value, domain.AnswerSeparator)
+		}
+
+		for _, v := range values {
+			if !hasOption(q, v) {
+				return domain.ErrInvalidAnswer
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasOption(q domain.Question, value string) bool {
+	for _, o := range q.Options {
+		if o == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notify сообщает автору события об изменении согласно настройке события.
+// Собственные регистрации автора не сообщаются.
+func (uc *RegistrationUseCase) notify(ctx context.Context, event *domain.Event, user *domain.User, registered bool) error {
+	if user.ID == event.UserID {
+		return nil
+	}
+
+	change := domain.RegistrationChange{
+		EventID:    event.ID,
+		User:       *user,
+		Registered: registered,
+		CreatedAt:  time.Now().UTC(),
 	}
 
-	isRegistered, err := uc.registrationRepo.IsRegistered(ctx, eventID, user.ID)
+	switch event.NotifyMode {
+	case domain.NotifyInstant:
+		return uc.notifier.NotifyRegistration(ctx, *event, change)
+	case domain.NotifyDigest:
+		return uc.notificationRepo.Enqueue(ctx, change)
+	}
+
+	return nil
+}
+
+// SendDigests отправляет авторам сводки накопленных изменений и очищает очередь.
+// Изменения событий, у которых сводка с тех пор выключена, отбрасываются.
+func (uc *RegistrationUseCase) SendDigests(ctx context.Context) error {
+	changes, err := uc.notificationRepo.Pending(ctx)
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	if isRegistered {
-		err = uc.registrationRepo.Unregister(ctx, eventID, user.ID)
-		return false, err
+	var order []int64
+	byEvent := make(map[int64][]domain.RegistrationChange)
+	for _, c := range changes {
+		if _, ok := byEvent[c.EventID]; !ok {
+			order = append(order, c.EventID)
+		}
+		byEvent[c.EventID] = append(byEvent[c.EventID], c)
 	}
 
-	if err = uc.registrationRepo.Register(ctx, eventID, user.ID); err != nil {
-		return false, err
+	for _, eventID := range order {
+		list := byEvent[eventID]
+
+		event, err := uc.eventRepo.GetByID(ctx, eventID)
+		if err != nil && !errors.Is(err, domain.ErrEventNotFound) {
+			return err
+		}
+
+		if err == nil && event.NotifyMode == domain.NotifyDigest {
+			// недоставленная сводка не повторяется: автор мог заблокировать бота,
+			// и очередь росла бы бесконечно
+			_ = uc.notifier.NotifyDigest(ctx, *event, list)
+		}
+
+		ids := make([]int64, 0, len(list))
+		for _, c := range list {
+			ids = append(ids, c.ID)
+		}
+
+		if err := uc.notificationRepo.Delete(ctx, ids); err != nil {
+			return err
+		}
 	}
 
-	return true, nil
+	return nil
 }
 
+// GetParticipants подтверждённые участники события вместе с ответами на вопросы.
 func (uc *RegistrationUseCase) GetParticipants(
 	ctx context.Context,
 	eventID int64,
 ) ([]domain.Participant, error) {
-	return uc.registrationRepo.GetParticipants(ctx, eventID)
+	return uc.participants(ctx, eventID, domain.RegistrationApproved)
+}
+
+// GetPending заявки, ожидающие решения автора события.
+func (uc *RegistrationUseCase) GetPending(
+	ctx context.Context,
+	eventID int64,
+) ([]domain.Participant, error) {
+	return uc.participants(ctx, eventID, domain.RegistrationPending)
+}
+
+// participants регистрации события в заданном состоянии вместе с ответами.
+func (uc *RegistrationUseCase) participants(
+	ctx context.Context,
+	eventID int64,
+	status domain.RegistrationStatus,
+) ([]domain.Participant, error) {
+	all, err := uc.registrationRepo.GetParticipants(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := uc.questionRepo.GetAnswers(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var participants []domain.Participant
+	for _, p := range all {
+		if p.Status != status {
+			continue
+		}
+
+		p.Answers = answers[p.ID]
+		participants = append(participants, p)
+	}
+
+	return participants, nil
 }
 
 func (uc *RegistrationUseCase) GetParticipantsPaginated(
@@ -68,3 +458,44 @@ func (uc *RegistrationUseCase) GetParticipantsPaginated(
 func (uc *RegistrationUseCase) IsRegistered(ctx context.Context, eventID int64, userID int64) (bool, error) {
 	return uc.registrationRepo.IsRegistered(ctx, eventID, userID)
 }
+
+// Status состояние регистрации пользователя на событие.
+func (uc *RegistrationUseCase) Status(ctx context.Context, eventID int64, userID int64) (domain.RegistrationStatus, error) {
+	return uc.registrationRepo.Status(ctx, eventID, userID)
+}
+
+// Ticket билет пользователя на событие.
+func (uc *RegistrationUseCase) Ticket(ctx context.Context, eventID int64, userID int64) (*domain.Ticket, error) {
+	return uc.registrationRepo.GetTicket(ctx, eventID, userID)
+}
+
+// TicketByToken билет по коду, предъявленному на входе.
+func (uc *RegistrationUseCase) TicketByToken(ctx context.Context, token string) (*domain.Ticket, error) {
+	return uc.registrationRepo.GetTicketByToken(ctx, token)
+}
+
+// CheckIn отмечает приход участника. Для уже отмеченного билета возвращает
+// domain.ErrAlreadyCheckedIn вместе с билетом, чтобы показать время первой отметки;
+// по неподтверждённой заявке — domain.ErrRegistrationPending.
+func (uc *RegistrationUseCase) CheckIn(ctx context.Context, token string) (*domain.Ticket, error) {
+	t, err := uc.registrationRepo.GetTicketByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status == domain.RegistrationPending {
+		return t, domain.ErrRegistrationPending
+	}
+
+	err = uc.registrationRepo.CheckIn(ctx, token, time.Now().UTC())
+	if err != nil && !errors.Is(err, domain.ErrAlreadyCheckedIn) {
+		return nil, err
+	}
+
+	t, getErr := uc.registrationRepo.GetTicketByToken(ctx, token)
+	if getErr != nil {
+		return nil, getErr
+	}
+
+	return t, err
+}
@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+	"github.com/binaryty/evbot/internal/repository/repotest"
+)
+
+const (
+	author int64 = 1
+	anna   int64 = 10
+	boris  int64 = 11
+)
+
+var evening = time.Date(2026, time.November, 20, 19, 0, 0, 0, time.UTC)
+
+// newRegistrationUseCase usecase на хранилище s; события создаёт автор author.
+func newRegistrationUseCase(s repotest.Storage, notifier Notifier) *RegistrationUseCase {
+	return NewRegistrationUseCase(s.Events, s.Registrations, s.Questions, s.Notifications, notifier, s.Transactor)
+}
+
+// createEvent сохраняет событие автора author и пользователей anna и boris.
+func createEvent(t *testing.T, s repotest.Storage, event domain.Event) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	for _, u := range []domain.User{{ID: anna, FirstName: "Анна"}, {ID: boris, FirstName: "Борис"}} {
+		if err := s.Users.CreateOrUpdate(ctx, &u); err != nil {
+			t.Fatalf("Users.CreateOrUpdate: %v", err)
+		}
+	}
+
+	event.Title = "Митап"
+	event.Date = evening
+	id, err := NewEventUseCase(s.Events, s.Questions, s.Transactor).CreateEvent(ctx, author, event)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	return id
+}
+
+// status текущее состояние регистрации пользователя.
+func status(t *testing.T, s repotest.Storage, eventID int64, userID int64) domain.RegistrationStatus {
+	t.Helper()
+
+	got, err := s.Registrations.Status(context.Background(), eventID, userID)
+	if err != nil {
+		t.Fatalf("Registrations.Status: %v", err)
+	}
+
+	return got
+}
+
+func TestToggleRegistration(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   domain.Event
+		want    []domain.RegistrationStatus
+		wantErr error
+		// notified сколько мгновенных уведомлений получит автор.
+		notified int
+	}{
+		{
+			name:     "register and cancel",
+			event:    domain.Event{NotifyMode: domain.NotifyInstant},
+			want:     []domain.RegistrationStatus{domain.RegistrationApproved, domain.RegistrationNone},
+			notified: 2,
+		},
+		{
+			name:  "request and withdraw",
+			event: domain.Event{RequiresApproval: true, NotifyMode: domain.NotifyInstant},
+			want:  []domain.RegistrationStatus{domain.RegistrationPending, domain.RegistrationNone},
+		},
+		{
+			name:    "questions must be answered",
+			event:   domain.Event{Questions: []domain.Question{{Kind: domain.QuestionText, Text: "Откуда вы?"}}},
+			want:    []domain.RegistrationStatus{domain.RegistrationNone},
+			wantErr: domain.ErrAnswersRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+				ctx := context.Background()
+				notifier := &recordingNotifier{}
+				uc := newRegistrationUseCase(s, notifier)
+				eventID := createEvent(t, s, tt.event)
+
+				for i, want := range tt.want {
+					got, err := uc.ToggleRegistration(ctx, eventID, &domain.User{ID: anna})
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("toggle %d: error = %v, want %v", i, err, tt.wantErr)
+					}
+					if got != want || status(t, s, eventID, anna) != want {
+						t.Errorf("toggle %d: status = %q (stored %q), want %q", i, got, status(t, s, eventID, anna), want)
+					}
+				}
+
+				if len(notifier.registrations) != tt.notified {
+					t.Errorf("notifications = %+v, want %d", notifier.registrations, tt.notified)
+				}
+			})
+		})
+	}
+}
+
+func TestToggleRegistrationConcurrent(t *testing.T) {
+	const presses = 10
+
+	forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+		ctx := context.Background()
+		uc := newRegistrationUseCase(s, &recordingNotifier{})
+		eventID := createEvent(t, s, domain.Event{})
+
+		// одновременные нажатия одного пользователя выполняются по очереди:
+		// каждое переключает регистрацию, чётное число нажатий её отменяет
+		var wg sync.WaitGroup
+		errs := make(chan error, 2*presses)
+		for i := 0; i < presses; i++ {
+			for _, userID := range []int64{anna, boris} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					if _, err := uc.ToggleRegistration(ctx, eventID, &domain.User{ID: userID}); err != nil {
+						errs <- err
+					}
+				}()
+			}
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Errorf("ToggleRegistration: %v", err)
+		}
+
+		for _, userID := range []int64{anna, boris} {
+			if got := status(t, s, eventID, userID); got != domain.RegistrationNone {
+				t.Errorf("user %d after %d presses: status = %q, want none", userID, presses, got)
+			}
+		}
+	})
+}
+
+// staleStatus репозиторий регистраций, первый Status которого возвращает
+// устаревшее состояние: так выглядит нажатие, которое опередило параллельное.
+type staleStatus struct {
+	repository.RegistrationRepository
+	stale domain.RegistrationStatus
+	once  sync.Once
+}
+
+func (r *staleStatus) Status(ctx context.Context, eventID int64, userID int64) (domain.RegistrationStatus, error) {
+	stale := false
+	r.once.Do(func() { stale = true })
+	if stale {
+		return r.stale, nil
+	}
+
+	return r.RegistrationRepository.Status(ctx, eventID, userID)
+}
+
+func TestToggleRegistrationRace(t *testing.T) {
+	tests := []struct {
+		name string
+		// registered зарегистрирован ли пользователь на самом деле.
+		registered bool
+		stale      domain.RegistrationStatus
+		want       domain.RegistrationStatus
+	}{
+		// регистрация уже сохранена параллельным нажатием: ErrAlreadyRegistered
+		{"already registered", true, domain.RegistrationNone, domain.RegistrationApproved},
+		// регистрация уже отменена параллельным нажатием: ErrRegistrationNotFound
+		{"already cancelled", false, domain.RegistrationApproved, domain.RegistrationNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+				ctx := context.Background()
+				eventID := createEvent(t, s, domain.Event{})
+
+				if tt.registered {
+					if err := s.Registrations.Register(ctx, eventID, anna, "AAAA", domain.RegistrationApproved); err != nil {
+						t.Fatalf("Registrations.Register: %v", err)
+					}
+				}
+
+				s.Registrations = &staleStatus{RegistrationRepository: s.Registrations, stale: tt.stale}
+				uc := newRegistrationUseCase(s, &recordingNotifier{})
+
+				got, err := uc.ToggleRegistration(ctx, eventID, &domain.User{ID: anna})
+				if err != nil || got != tt.want {
+					t.Errorf("ToggleRegistration = %q, %v; want %q", got, err, tt.want)
+				}
+				if stored := status(t, s, eventID, anna); stored != tt.want {
+					t.Errorf("stored status = %q, want %q", stored, tt.want)
+				}
+			})
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+		ctx := context.Background()
+		uc := newRegistrationUseCase(s, &recordingNotifier{})
+		eventID := createEvent(t, s, domain.Event{Questions: []domain.Question{
+			{Kind: domain.QuestionText, Text: "Откуда вы?"},
+			{Kind: domain.QuestionSingle, Text: "Формат", Options: []string{"Очно", "Онлайн"}},
+		}})
+
+		questions, err := uc.Questions(ctx, eventID)
+		if err != nil || len(questions) != 2 {
+			t.Fatalf("Questions = %+v, %v; want 2", questions, err)
+		}
+		answer := func(values ...string) []domain.Answer {
+			answers := make([]domain.Answer, len(values))
+			for i, v := range values {
+				answers[i] = domain.Answer{QuestionID: questions[i].ID, Value: v}
+			}
+			return answers
+		}
+
+		tests := []struct {
+			name    string
+			userID  int64
+			answers []domain.Answer
+			want    domain.RegistrationStatus
+			wantErr error
+		}{
+			{"not all answered", anna, answer("Москва"), domain.RegistrationNone, domain.ErrInvalidAnswer},
+			{"unknown option", anna, answer("Москва", "Заочно"), domain.RegistrationNone, domain.ErrInvalidAnswer},
+			{"answered", anna, answer("Москва", "Онлайн"), domain.RegistrationApproved, nil},
+			{"twice", anna, answer("Казань", "Очно"), domain.RegistrationNone, domain.ErrAlreadyRegistered},
+		}
+
+		for _, tt := range tests {
+			got, err := uc.Register(ctx, eventID, &domain.User{ID: tt.userID}, tt.answers)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: Register = %q, %v; want %q, %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		}
+
+		// повторная регистрация не изменила первые ответы
+		participants, err := uc.GetParticipants(ctx, eventID)
+		if err != nil {
+			t.Fatalf("GetParticipants: %v", err)
+		}
+		if len(participants) != 1 || len(participants[0].Answers) != 2 || participants[0].Answers[0].Value != "Москва" {
+			t.Errorf("participants = %+v, want anna with the first answers", participants)
+		}
+	})
+}
+
+func TestReject(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+		ctx := context.Background()
+		uc := newRegistrationUseCase(s, &recordingNotifier{})
+		eventID := createEvent(t, s, domain.Event{RequiresApproval: true})
+
+		for _, userID := range []int64{anna, boris} {
+			if _, err := uc.ToggleRegistration(ctx, eventID, &domain.User{ID: userID}); err != nil {
+				t.Fatalf("ToggleRegistration: %v", err)
+			}
+		}
+		if _, err := uc.Approve(ctx, author, eventID, boris); err != nil {
+			t.Fatalf("Approve: %v", err)
+		}
+
+		tests := []struct {
+			name     string
+			authorID int64
+			userID   int64
+			wantErr  error
+			want     domain.RegistrationStatus
+		}{
+			{"not the author", anna, anna, domain.ErrAccessDenied, domain.RegistrationPending},
+			{"approved registration", author, boris, domain.ErrRegistrationNotFound, domain.RegistrationApproved},
+			{"pending request", author, anna, nil, domain.RegistrationNone},
+			{"already rejected", author, anna, domain.ErrRegistrationNotFound, domain.RegistrationNone},
+		}
+
+		for _, tt := range tests {
+			if _, err := uc.Reject(ctx, tt.authorID, eventID, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: Reject error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if got := status(t, s, eventID, tt.userID); got != tt.want {
+				t.Errorf("%s: status = %q, want %q", tt.name, got, tt.want)
+			}
+		}
+	})
+}
+
+// failingDelete репозиторий событий, который удаляет событие, но сообщает
+// об ошибке: удаление должно откатиться вместе с транзакцией.
+type failingDelete struct {
+	repository.EventRepository
+}
+
+var errDiskFull = errors.New("disk full")
+
+func (r failingDelete) Delete(ctx context.Context, eventID int64) error {
+	if err := r.EventRepository.Delete(ctx, eventID); err != nil {
+		return err
+	}
+
+	return errDiskFull
+}
+
+func TestCancelEvent(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+		ctx := context.Background()
+		notifier := &recordingNotifier{}
+		uc := newRegistrationUseCase(s, notifier)
+		eventID := createEvent(t, s, domain.Event{})
+
+		if _, err := uc.ToggleRegistration(ctx, eventID, &domain.User{ID: anna}); err != nil {
+			t.Fatalf("ToggleRegistration: %v", err)
+		}
+
+		if err := uc.CancelEvent(ctx, eventID); err != nil {
+			t.Fatalf("CancelEvent: %v", err)
+		}
+
+		if _, err := s.Events.GetByID(ctx, eventID); !errors.Is(err, domain.ErrEventNotFound) {
+			t.Errorf("Events.GetByID after CancelEvent error = %v, want ErrEventNotFound", err)
+		}
+		if got := notifier.cancellations[eventID]; len(got) != 1 || got[0].ID != anna {
+			t.Errorf("cancellation sent to %+v, want anna", got)
+		}
+	})
+}
+
+func TestCancelEventRollback(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, s repotest.Storage) {
+		ctx := context.Background()
+		notifier := &recordingNotifier{}
+		eventID := createEvent(t, s, domain.Event{})
+
+		if _, err := newRegistrationUseCase(s, notifier).ToggleRegistration(ctx, eventID, &domain.User{ID: anna}); err != nil {
+			t.Fatalf("ToggleRegistration: %v", err)
+		}
+
+		s.Events = failingDelete{s.Events}
+		if err := newRegistrationUseCase(s, notifier).CancelEvent(ctx, eventID); !errors.Is(err, errDiskFull) {
+			t.Fatalf("CancelEvent error = %v, want %v", err, errDiskFull)
+		}
+
+		// удаление откатилось: событие и регистрации на месте, отмена не разослана
+		if _, err := s.Events.GetByID(ctx, eventID); err != nil {
+			t.Errorf("Events.GetByID after failed CancelEvent: %v", err)
+		}
+		if got := status(t, s, eventID, anna); got != domain.RegistrationApproved {
+			t.Errorf("status after failed CancelEvent = %q, want approved", got)
+		}
+		if len(notifier.cancellations) != 0 {
+			t.Errorf("cancellations = %+v, want none", notifier.cancellations)
+		}
+	})
+}
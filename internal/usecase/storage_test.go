@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository/memory"
+	"github.com/binaryty/evbot/internal/repository/repotest"
+	"github.com/binaryty/evbot/internal/repository/sqlite"
+)
+
+// storages хранилища, на которых проверяются транзакционные сценарии:
+// память и SQLite откатывают и сериализуют транзакции по-разному.
+var storages = map[string]func(t *testing.T) repotest.Storage{
+	"memory": func(t *testing.T) repotest.Storage {
+		s := memory.NewStore()
+
+		return repotest.Storage{
+			Events:        memory.NewEventRepository(s),
+			Users:         memory.NewUserRepository(s),
+			Registrations: memory.NewRegistrationRepository(s),
+			Notifications: memory.NewNotificationRepository(s),
+			Questions:     memory.NewQuestionRepository(s),
+			Transactor:    memory.NewTransactor(s),
+		}
+	},
+	"sqlite": func(t *testing.T) repotest.Storage {
+		db, err := sql.Open("sqlite3", sqlite.DataSource(filepath.Join(t.TempDir(), "bot.db")))
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := sqlite.Migrate(context.Background(), db); err != nil {
+			t.Fatalf("Migrate: %v", err)
+		}
+
+		return repotest.Storage{
+			Events:        sqlite.NewEventRepository(db),
+			Users:         sqlite.NewUserRepository(db),
+			Registrations: sqlite.NewRegistrationRepository(db),
+			Notifications: sqlite.NewNotificationRepository(db),
+			Questions:     sqlite.NewQuestionRepository(db),
+			Transactor:    sqlite.NewTransactor(db),
+		}
+	},
+}
+
+// forEachStorage запускает проверку на каждом хранилище из storages.
+func forEachStorage(t *testing.T, test func(t *testing.T, s repotest.Storage)) {
+	for name, open := range storages {
+		t.Run(name, func(t *testing.T) {
+			test(t, open(t))
+		})
+	}
+}
+
+// recordingNotifier запоминает уведомления вместо отправки.
+type recordingNotifier struct {
+	mu            sync.Mutex
+	registrations []domain.RegistrationChange
+	cancellations map[int64][]domain.Participant
+}
+
+func (n *recordingNotifier) NotifyRegistration(_ context.Context, _ domain.Event, change domain.RegistrationChange) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.registrations = append(n.registrations, change)
+
+	return nil
+}
+
+func (n *recordingNotifier) NotifyDigest(context.Context, domain.Event, []domain.RegistrationChange) error {
+	return nil
+}
+
+func (n *recordingNotifier) NotifyCancellation(_ context.Context, event domain.Event, participants []domain.Participant) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.cancellations == nil {
+		n.cancellations = make(map[int64][]domain.Participant)
+	}
+	n.cancellations[event.ID] = participants
+
+	return nil
+}
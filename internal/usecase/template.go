@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/binaryty/evbot/internal/domain/entities"
+	"github.com/binaryty/evbot/internal/repository"
+)
+
+type TemplateUseCase struct {
+	repo      repository.TemplateRepository
+	eventRepo repository.EventRepository
+}
+
+func NewTemplateUseCase(repo repository.TemplateRepository, eventRepo repository.EventRepository) *TemplateUseCase {
+	return &TemplateUseCase{
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+// CreateFromEvent сохраняет событие как шаблон пользователя userID.
+func (uc *TemplateUseCase) CreateFromEvent(ctx context.Context, userID int64, eventID int64) (int64, error) {
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+
+	return uc.repo.Save(ctx, domain.Template{
+		UserID:         userID,
+		ChatID:         event.ChatID,
+		Title:          event.Title,
+		Description:    event.Description,
+		PhotoFileID:    event.PhotoFileID,
+		DocumentFileID: event.DocumentFileID,
+		Hour:           event.Date.Hour(),
+		Minute:         event.Date.Minute(),
+		CreatedAt:      time.Now().UTC(),
+	})
+}
+
+// Template возвращает шаблон, только если он принадлежит пользователю.
+func (uc *TemplateUseCase) Template(ctx context.Context, userID int64, templateID int64) (*domain.Template, error) {
+	t, err := uc.repo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.UserID != userID {
+		return nil, domain.ErrTemplateNotFound
+	}
+
+	return t, nil
+}
+
+func (uc *TemplateUseCase) ListTemplates(ctx context.Context, userID int64) ([]domain.Template, error) {
+	return uc.repo.GetByUserID(ctx, userID)
+}
+
+func (uc *TemplateUseCase) DeleteTemplate(ctx context.Context, userID int64, templateID int64) error {
+	if _, err := uc.Template(ctx, userID, templateID); err != nil {
+		return err
+	}
+
+	return uc.repo.Delete(ctx, templateID)
+}
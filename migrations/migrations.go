@@ -0,0 +1,16 @@
+// Package migrations схема базы данных.
+package migrations
+
+import "embed"
+
+// SQLite миграции схемы SQLite. Файл NNNN_описание.sql переводит базу
+// в версию NNNN; применённая версия хранится в PRAGMA user_version.
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
+
+// Postgres миграции схемы Postgres в том же формате; применённые версии
+// хранятся в таблице schema_migrations.
+//
+//go:embed postgres/*.sql
+var Postgres embed.FS
@@ -0,0 +1,136 @@
+CREATE TABLE IF NOT EXISTS events (
+                                      id BIGSERIAL PRIMARY KEY,
+                                      user_id BIGINT NOT NULL,
+                                      chat_id BIGINT NOT NULL DEFAULT 0,
+                                      title TEXT NOT NULL,
+                                      description TEXT,
+                                      photo_file_id TEXT NOT NULL DEFAULT '',
+                                      document_file_id TEXT NOT NULL DEFAULT '',
+                                      date TIMESTAMPTZ NOT NULL,
+                                      notify_mode TEXT NOT NULL DEFAULT 'off',
+                                      requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+                                      created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_chat_id ON events(chat_id);
+
+CREATE TABLE IF NOT EXISTS users (
+                                     user_id BIGINT PRIMARY KEY,
+                                     first_name TEXT NOT NULL,
+                                     username TEXT,
+                                     created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                     updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS registrations (
+                                             event_id BIGINT NOT NULL,
+                                             user_id BIGINT NOT NULL,
+                                             ticket TEXT,
+                                             checked_in_at TIMESTAMPTZ,
+                                             status TEXT NOT NULL DEFAULT 'approved',
+                                             created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                             PRIMARY KEY (event_id, user_id),
+                                             FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_ticket ON registrations(ticket);
+
+CREATE TABLE IF NOT EXISTS questions (
+                                         id BIGSERIAL PRIMARY KEY,
+                                         event_id BIGINT NOT NULL,
+                                         position INTEGER NOT NULL,
+                                         kind TEXT NOT NULL,
+                                         text TEXT NOT NULL,
+                                         options TEXT NOT NULL DEFAULT '[]',
+                                         FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_questions_event_id ON questions(event_id);
+
+CREATE TABLE IF NOT EXISTS answers (
+                                       event_id BIGINT NOT NULL,
+                                       user_id BIGINT NOT NULL,
+                                       question_id BIGINT NOT NULL,
+                                       value TEXT NOT NULL,
+                                       PRIMARY KEY (event_id, user_id, question_id),
+                                       FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS registration_changes (
+                                                    id BIGSERIAL PRIMARY KEY,
+                                                    event_id BIGINT NOT NULL,
+                                                    user_id BIGINT NOT NULL,
+                                                    first_name TEXT NOT NULL,
+                                                    username TEXT,
+                                                    registered BOOLEAN NOT NULL,
+                                                    created_at TIMESTAMPTZ NOT NULL,
+                                                    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS surveys (
+                                       event_id BIGINT PRIMARY KEY,
+                                       sent_at TIMESTAMPTZ NOT NULL,
+                                       FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS feedback (
+                                        event_id BIGINT NOT NULL,
+                                        user_id BIGINT NOT NULL,
+                                        rating INTEGER NOT NULL,
+                                        comment TEXT NOT NULL DEFAULT '',
+                                        created_at TIMESTAMPTZ NOT NULL,
+                                        PRIMARY KEY (event_id, user_id),
+                                        FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS channels (
+                                        chat_id BIGINT PRIMARY KEY,
+                                        channel_id BIGINT NOT NULL,
+                                        created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS event_posts (
+                                           event_id BIGINT NOT NULL,
+                                           channel_id BIGINT NOT NULL,
+                                           message_id BIGINT NOT NULL,
+                                           created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                           PRIMARY KEY (event_id, channel_id),
+                                           FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS templates (
+                                         id BIGSERIAL PRIMARY KEY,
+                                         user_id BIGINT NOT NULL,
+                                         chat_id BIGINT NOT NULL DEFAULT 0,
+                                         title TEXT NOT NULL,
+                                         description TEXT,
+                                         photo_file_id TEXT NOT NULL DEFAULT '',
+                                         document_file_id TEXT NOT NULL DEFAULT '',
+                                         hour INTEGER NOT NULL,
+                                         minute INTEGER NOT NULL,
+                                         created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_templates_user_id ON templates(user_id);
+
+CREATE TABLE IF NOT EXISTS drafts (
+                                      id BIGSERIAL PRIMARY KEY,
+                                      user_id BIGINT NOT NULL,
+                                      name TEXT NOT NULL,
+                                      state_data TEXT NOT NULL,
+                                      created_at TIMESTAMPTZ NOT NULL,
+                                      updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_drafts_user_id ON drafts(user_id);
+
+CREATE TABLE IF NOT EXISTS active_drafts (
+                                             user_id BIGINT PRIMARY KEY,
+                                             draft_id BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS dialogs (
+                                       user_id BIGINT PRIMARY KEY,
+                                       state_data TEXT NOT NULL,
+                                       updated_at TIMESTAMPTZ NOT NULL
+);
@@ -27,4 +27,4 @@ CREATE TABLE IF NOT EXISTS user_states (
                                            user_id INTEGER PRIMARY KEY,
                                            state_data TEXT NOT NULL,
                                            created_at DATETIME NOT NULL
-);
\ No newline at end of file
+);
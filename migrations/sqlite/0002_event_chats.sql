@@ -0,0 +1,4 @@
+-- события привязаны к чату, в котором созданы
+ALTER TABLE events ADD COLUMN chat_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_events_chat_id ON events(chat_id);
@@ -0,0 +1,14 @@
+-- канал для анонсов и опубликованные в нём посты
+CREATE TABLE IF NOT EXISTS channels (
+                                        chat_id INTEGER PRIMARY KEY,
+                                        channel_id INTEGER NOT NULL,
+                                        created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS event_posts (
+                                           event_id INTEGER NOT NULL,
+                                           channel_id INTEGER NOT NULL,
+                                           message_id INTEGER NOT NULL,
+                                           created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+                                           PRIMARY KEY (event_id, channel_id)
+);
@@ -0,0 +1,3 @@
+-- обложка и вложение события
+ALTER TABLE events ADD COLUMN photo_file_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN document_file_id TEXT NOT NULL DEFAULT '';
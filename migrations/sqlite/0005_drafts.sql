@@ -0,0 +1,31 @@
+-- несколько черновиков на пользователя вместо единственного состояния
+-- в user_states
+CREATE TABLE IF NOT EXISTS drafts (
+                                      id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                      user_id INTEGER NOT NULL,
+                                      name TEXT NOT NULL,
+                                      state_data TEXT NOT NULL,
+                                      created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_drafts_user_id ON drafts(user_id);
+
+CREATE TABLE IF NOT EXISTS active_drafts (
+                                             user_id INTEGER PRIMARY KEY,
+                                             draft_id INTEGER NOT NULL
+);
+
+-- незавершённые мастера становятся активными черновиками
+INSERT INTO drafts (user_id, name, state_data, created_at)
+SELECT user_id,
+       COALESCE(NULLIF(json_extract(state_data, '$.TempEvent.Title'), ''), 'Без названия'),
+       state_data,
+       created_at
+FROM user_states;
+
+INSERT INTO active_drafts (user_id, draft_id)
+SELECT user_id, MAX(id)
+FROM drafts
+GROUP BY user_id;
+
+DROP TABLE user_states;
@@ -0,0 +1,15 @@
+-- шаблоны событий
+CREATE TABLE IF NOT EXISTS templates (
+                                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                         user_id INTEGER NOT NULL,
+                                         chat_id INTEGER NOT NULL DEFAULT 0,
+                                         title TEXT NOT NULL,
+                                         description TEXT,
+                                         photo_file_id TEXT NOT NULL DEFAULT '',
+                                         document_file_id TEXT NOT NULL DEFAULT '',
+                                         hour INTEGER NOT NULL,
+                                         minute INTEGER NOT NULL,
+                                         created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_templates_user_id ON templates(user_id);
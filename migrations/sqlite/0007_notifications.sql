@@ -0,0 +1,13 @@
+-- уведомления автора о регистрациях и очередь для сводок
+ALTER TABLE events ADD COLUMN notify_mode TEXT NOT NULL DEFAULT 'off';
+
+CREATE TABLE IF NOT EXISTS registration_changes (
+                                                    id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                                    event_id INTEGER NOT NULL,
+                                                    user_id INTEGER NOT NULL,
+                                                    first_name TEXT NOT NULL,
+                                                    username TEXT,
+                                                    registered BOOLEAN NOT NULL,
+                                                    created_at DATETIME NOT NULL,
+                                                    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+-- билеты и отметка прихода
+ALTER TABLE registrations ADD COLUMN ticket TEXT;
+ALTER TABLE registrations ADD COLUMN checked_in_at DATETIME;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_ticket ON registrations(ticket);
@@ -0,0 +1,16 @@
+-- опросы после событий
+CREATE TABLE IF NOT EXISTS surveys (
+                                       event_id INTEGER PRIMARY KEY,
+                                       sent_at DATETIME NOT NULL,
+                                       FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS feedback (
+                                        event_id INTEGER NOT NULL,
+                                        user_id INTEGER NOT NULL,
+                                        rating INTEGER NOT NULL,
+                                        comment TEXT NOT NULL DEFAULT '',
+                                        created_at DATETIME NOT NULL,
+                                        PRIMARY KEY (event_id, user_id),
+                                        FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
@@ -0,0 +1,21 @@
+-- вопросы участникам и их ответы
+CREATE TABLE IF NOT EXISTS questions (
+                                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                         event_id INTEGER NOT NULL,
+                                         position INTEGER NOT NULL,
+                                         kind TEXT NOT NULL,
+                                         text TEXT NOT NULL,
+                                         options TEXT NOT NULL DEFAULT '[]',
+                                         FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_questions_event_id ON questions(event_id);
+
+CREATE TABLE IF NOT EXISTS answers (
+                                       event_id INTEGER NOT NULL,
+                                       user_id INTEGER NOT NULL,
+                                       question_id INTEGER NOT NULL,
+                                       value TEXT NOT NULL,
+                                       PRIMARY KEY (event_id, user_id, question_id),
+                                       FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- подтверждение заявок организатором
+ALTER TABLE events ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE registrations ADD COLUMN status TEXT NOT NULL DEFAULT 'approved';
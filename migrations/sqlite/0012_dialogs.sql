@@ -0,0 +1,11 @@
+-- служебный диалог хранится отдельно от черновиков, а черновики
+-- запоминают время последнего изменения
+ALTER TABLE drafts ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+UPDATE drafts SET updated_at = created_at;
+
+CREATE TABLE IF NOT EXISTS dialogs (
+                                       user_id INTEGER PRIMARY KEY,
+                                       state_data TEXT NOT NULL,
+                                       updated_at DATETIME NOT NULL
+);
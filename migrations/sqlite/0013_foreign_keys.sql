@@ -0,0 +1,27 @@
+-- внешние ключи раньше не включались, и удаление события оставляло
+-- связанные записи: убираем их, прежде чем ключи начнут проверяться
+DELETE FROM registrations WHERE event_id NOT IN (SELECT id FROM events);
+DELETE FROM questions WHERE event_id NOT IN (SELECT id FROM events);
+DELETE FROM answers WHERE event_id NOT IN (SELECT id FROM events);
+DELETE FROM registration_changes WHERE event_id NOT IN (SELECT id FROM events);
+DELETE FROM surveys WHERE event_id NOT IN (SELECT id FROM events);
+DELETE FROM feedback WHERE event_id NOT IN (SELECT id FROM events);
+
+-- анонсы удаляются вместе с событием
+CREATE TABLE event_posts_new (
+                                 event_id INTEGER NOT NULL,
+                                 channel_id INTEGER NOT NULL,
+                                 message_id INTEGER NOT NULL,
+                                 created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+                                 PRIMARY KEY (event_id, channel_id),
+                                 FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+INSERT INTO event_posts_new (event_id, channel_id, message_id, created_at)
+SELECT event_id, channel_id, message_id, created_at
+FROM event_posts
+WHERE event_id IN (SELECT id FROM events);
+
+DROP TABLE event_posts;
+
+ALTER TABLE event_posts_new RENAME TO event_posts;